@@ -0,0 +1,107 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"runtime/debug"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/deepmap/oapi-codegen/pkg/codegen"
+)
+
+// The module which the generator is part of.
+const modulePath = "github.com/deepmap/oapi-codegen"
+
+// setVersion takes the version of the generator from the build info, unless
+// it was set when building, with -ldflags. The generator is the main module
+// when it's installed, and a dependency when it's run from another module,
+// such as with go run. Builds of commits which aren't released, which have
+// pseudo-versions, and builds with local changes, are still devel, since
+// their versions would change with each commit, and so with every file.
+func setVersion() {
+	if codegen.Version != "devel" {
+		return
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return
+	}
+	module := &info.Main
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			module = dep
+		}
+	}
+	if module.Path == modulePath && module.Version != "" && module.Version != "(devel)" &&
+		!unreleasedVersionRE.MatchString(module.Version) {
+		codegen.Version = module.Version
+	}
+}
+
+// Matches pseudo-versions, such as v0.0.0-20191019101321-d87b1facb369, and
+// the versions of builds with local changes, such as v1.2.3+dirty, which Go
+// stamps builds of checkouts with.
+var unreleasedVersionRE = regexp.MustCompile(`-(.*[.-])?[0-9]{14}-[0-9a-f]{12}(\+.*)?$|\+dirty$`)
+
+// checkGenerated compares code with what's in the file it would be written
+// to, and if they differ, prints a unified diff from the file to the code,
+// and returns false.
+func checkGenerated(outputFile string, code string) bool {
+	current, err := ioutil.ReadFile(outputFile)
+	if err != nil && !os.IsNotExist(err) {
+		errExit("error reading %s: %s\n", outputFile, err)
+	}
+	if string(current) == code {
+		return true
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(current)),
+		B:        difflib.SplitLines(code),
+		FromFile: outputFile,
+		ToFile:   outputFile + " (generated)",
+		Context:  3,
+	})
+	if err != nil {
+		errExit("error comparing %s: %s\n", outputFile, err)
+	}
+	fmt.Print(diff)
+	_, _ = fmt.Fprintf(os.Stderr, "%s is out of date, generate it again\n", outputFile)
+	return false
+}
+
+// checkGeneratedHeader compares the generator version and input hash which
+// the header of the file records with those which generating it now would
+// record, and exits non-zero if they differ. This is quicker than comparing
+// the code, but doesn't notice changes made to the file by hand.
+func checkGeneratedHeader(outputFile string, inputHash string) {
+	current, err := ioutil.ReadFile(outputFile)
+	if err != nil {
+		errExit("error reading %s: %s\n", outputFile, err)
+	}
+	header, ok := codegen.ReadGeneratedHeader(current)
+	switch {
+	case !ok:
+		errExit("%s doesn't record what it was generated by, generate it again\n", outputFile)
+	case header.Version != codegen.Version:
+		errExit("%s was generated by version %s, rather than %s, generate it again\n",
+			outputFile, header.Version, codegen.Version)
+	case header.InputHash != inputHash:
+		errExit("%s was generated from another spec or options, generate it again\n", outputFile)
+	}
+}
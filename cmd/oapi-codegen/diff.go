@@ -0,0 +1,93 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/deepmap/oapi-codegen/pkg/codegen"
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+// diff runs the diff subcommand, which compares two versions of a spec, and
+// exits non-zero if the changes break anything, for pre-merge checks.
+func diff(args []string) {
+	var (
+		format   string
+		failOn   string
+		optFlags optionFlags
+	)
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "Usage: oapi-codegen diff [flags] old.yaml new.yaml\n")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&format, "format", "human", `The output format: "human" or "json"`)
+	flags.StringVar(&failOn, "fail-on", "any",
+		`Which breaking changes to exit non-zero for: "wire", "go" or "any"`)
+	optFlags.register(flags)
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	switch failOn {
+	case "wire", "go", "any":
+	default:
+		errExit("unknown diff fail-on %s\n", failOn)
+	}
+	oldSwagger, err := util.LoadSwagger(flags.Arg(0))
+	if err != nil {
+		errExit("error loading old swagger spec\n: %s", err)
+	}
+	newSwagger, err := util.LoadSwagger(flags.Arg(1))
+	if err != nil {
+		errExit("error loading new swagger spec\n: %s", err)
+	}
+	changes, err := codegen.DiffSwaggers(oldSwagger, newSwagger, optFlags.options())
+	if err != nil {
+		errExit("error comparing specs: %s\n", err)
+	}
+
+	switch format {
+	case "human":
+		for _, change := range changes {
+			fmt.Println(change)
+		}
+	case "json":
+		if changes == nil {
+			changes = []codegen.Change{}
+		}
+		out, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			errExit("error marshaling changes: %s\n", err)
+		}
+		fmt.Println(string(out))
+	default:
+		errExit("unknown diff format %s\n", format)
+	}
+
+	for _, change := range changes {
+		switch {
+		case failOn == "wire" && change.BreaksWire,
+			failOn == "go" && change.BreaksGo,
+			failOn == "any" && change.Breaking():
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/deepmap/oapi-codegen/pkg/codegen"
+	"github.com/deepmap/oapi-codegen/pkg/util"
+)
+
+// lint runs the lint subcommand, which reports what the generator doesn't
+// support in a spec, and exits non-zero if there's anything it fails on.
+func lint(args []string) {
+	var (
+		format   string
+		optFlags optionFlags
+	)
+	flags := flag.NewFlagSet("lint", flag.ExitOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "Usage: oapi-codegen lint [flags] spec.yaml\n")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&format, "format", "human", `The output format: "human", "json" or "sarif"`)
+	optFlags.register(flags)
+	_ = flags.Parse(args)
+
+	if flags.NArg() < 1 {
+		flags.Usage()
+		os.Exit(1)
+	}
+	specPath := flags.Arg(0)
+
+	swagger, err := util.LoadSwagger(specPath)
+	if err != nil {
+		errExit("error loading swagger spec\n: %s", err)
+	}
+	issues, err := codegen.Lint(swagger, optFlags.options())
+	if err != nil {
+		errExit("error linting spec: %s\n", err)
+	}
+
+	switch format {
+	case "human":
+		for _, issue := range issues {
+			fmt.Printf("%s: %s\n", specPath, issue)
+			if issue.Fix != "" {
+				fmt.Printf("\tfix: %s\n", issue.Fix)
+			}
+		}
+	case "json":
+		if issues == nil {
+			issues = []codegen.LintIssue{}
+		}
+		out, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			errExit("error marshaling issues: %s\n", err)
+		}
+		fmt.Println(string(out))
+	case "sarif":
+		out, err := codegen.LintSARIF(issues, specPath)
+		if err != nil {
+			errExit("error marshaling issues: %s\n", err)
+		}
+		fmt.Println(string(out))
+	default:
+		errExit("unknown lint format %s\n", format)
+	}
+
+	for _, issue := range issues {
+		if issue.Severity == codegen.SeverityError {
+			os.Exit(1)
+		}
+	}
+}
@@ -14,6 +14,7 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -30,22 +31,146 @@ func errExit(format string, args ...interface{}) {
 	os.Exit(1)
 }
 
+// formatMappings collects the repeatable -format-mapping flag, whose values
+// look like format=Type or format=Type@import/path.
+type formatMappings map[string]codegen.FormatMapping
+
+func (m formatMappings) String() string {
+	var parts []string
+	for format, mapping := range m {
+		part := format + "=" + mapping.Type
+		if mapping.Import != "" {
+			part += "@" + mapping.Import
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (m formatMappings) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("format mapping '%s' should look like format=Type[@import]", value)
+	}
+	mapping := codegen.FormatMapping{Type: parts[1]}
+	if idx := strings.Index(parts[1], "@"); idx >= 0 {
+		mapping.Type = parts[1][:idx]
+		mapping.Import = parts[1][idx+1:]
+	}
+	m[parts[0]] = mapping
+	return nil
+}
+
+// Splits a comma-separated flag value, which may be empty.
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// optionFlags are the flags which decide how code is generated, which the
+// subcommands share with generation.
+type optionFlags struct {
+	splitReadWriteTypes bool
+	strictTypeNames     bool
+	validateParams      bool
+	synthesizeOpIDs     bool
+	includeTags         string
+	excludeTags         string
+	includeOperationIDs string
+	excludeOperationIDs string
+	includePaths        string
+	excludePaths        string
+	formats             formatMappings
+}
+
+func (f *optionFlags) register(flags *flag.FlagSet) {
+	f.formats = make(formatMappings)
+	flags.BoolVar(&f.splitReadWriteTypes, "split-read-write-types", false,
+		"Generate separate Request and Response types for schemas with readOnly or writeOnly properties")
+	flags.BoolVar(&f.strictTypeNames, "strict-type-names", false,
+		"Fail when component type names collide, rather than renaming them")
+	flags.BoolVar(&f.validateParams, "validate-params", false,
+		"Make the server wrapper validate parameters against their schemas, and reject invalid requests")
+	flags.BoolVar(&f.synthesizeOpIDs, "synthesize-operation-ids", false,
+		"Name operations without an operationId from their method and path, eg GetPetsId for GET /pets/{id}")
+	flags.StringVar(&f.includeTags, "include-tags", "", "Only generate operations with one of these comma-separated tags")
+	flags.StringVar(&f.excludeTags, "exclude-tags", "", "Don't generate operations with any of these comma-separated tags")
+	flags.StringVar(&f.includeOperationIDs, "include-operation-ids", "", "Only generate these comma-separated operations")
+	flags.StringVar(&f.excludeOperationIDs, "exclude-operation-ids", "", "Don't generate these comma-separated operations")
+	flags.StringVar(&f.includePaths, "include-paths", "",
+		`Only generate operations on paths matching one of these comma-separated globs, eg "/pets/**"`)
+	flags.StringVar(&f.excludePaths, "exclude-paths", "",
+		"Don't generate operations on paths matching any of these comma-separated globs")
+	flags.Var(f.formats, "format-mapping",
+		`Maps a schema format to a Go type, eg "money=decimal.Decimal@github.com/shopspring/decimal", may be repeated`)
+}
+
+func (f *optionFlags) options() codegen.Options {
+	return codegen.Options{
+		SplitReadWriteTypes:    f.splitReadWriteTypes,
+		FormatMappings:         f.formats,
+		StrictTypeNames:        f.strictTypeNames,
+		ValidateParams:         f.validateParams,
+		SynthesizeOperationIDs: f.synthesizeOpIDs,
+		Filter: codegen.OperationFilter{
+			IncludeTags:         splitList(f.includeTags),
+			ExcludeTags:         splitList(f.excludeTags),
+			IncludeOperationIDs: splitList(f.includeOperationIDs),
+			ExcludeOperationIDs: splitList(f.excludeOperationIDs),
+			IncludePaths:        splitList(f.includePaths),
+			ExcludePaths:        splitList(f.excludePaths),
+		},
+	}
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "lint":
+			lint(os.Args[2:])
+			return
+		case "diff":
+			diff(os.Args[2:])
+			return
+		}
+	}
+
 	var (
 		packageName string
 		generate    string
 		outputFile  string
+		check       bool
+		checkHeader bool
+		plugins     pluginFlags
+		pluginDir   string
+		optFlags    optionFlags
 	)
 	flag.StringVar(&packageName, "package", "", "The package name for generated code")
 	flag.StringVar(&generate, "generate", "types,client,server,spec",
-		`Comma-separated list of code to generate; valid options: "types", client", "server", "spec"  (default types,client,server,"spec")`)
+		`Comma-separated list of code to generate; valid options: "types", client", "server", "spec"  (default types,client,server,"spec"), `+
+			`or "model", alone, for a JSON description of the code, or nothing, to only run plugins`)
 	flag.StringVar(&outputFile, "o", "", "Where to output generated code, stdout is default")
+	flag.BoolVar(&check, "check", false,
+		"Don't write the output file, but check that it's up to date, printing a diff and failing if it isn't")
+	flag.BoolVar(&checkHeader, "check-header", false,
+		"Like -check, but only compare the generator version and input hash which the output file's header records")
+	flag.Var(&plugins, "plugin",
+		"Run the plugin oapi-codegen-gen-NAME, given as NAME or NAME=PARAMETER, and write the files it generates, may be repeated")
+	flag.StringVar(&pluginDir, "plugin-out", "",
+		"Where plugins write their files, the directory of -o, or the current directory, is default")
+	optFlags.register(flag.CommandLine)
 	flag.Parse()
 
 	if flag.NArg() < 1 {
 		fmt.Println("Please specify a path to a OpenAPI 3.0 spec file")
 		os.Exit(1)
 	}
+	setVersion()
 
 	// If the package name has not been specified, we will use the name of the
 	// swagger file.
@@ -57,9 +182,12 @@ func main() {
 		packageName = codegen.ToCamelCase(nameParts[0])
 	}
 
-	opts := codegen.Options{}
-	for _, g := range strings.Split(generate, ",") {
+	opts := optFlags.options()
+	var generateModel bool
+	for _, g := range splitList(generate) {
 		switch g {
+		case "model":
+			generateModel = true
 		case "client":
 			opts.GenerateClient = true
 		case "server":
@@ -75,22 +203,128 @@ func main() {
 		}
 	}
 
+	if generateModel && generate != "model" {
+		errExit("model can't be generated along with code\n")
+	}
+	if generateModel && checkHeader {
+		errExit("-check-header can't check a model, which has no header, use -check\n")
+	}
+	// With plugins, -generate may be empty, to only run them.
+	generateCode := len(splitList(generate)) > 0
+	if !generateCode && len(plugins) == 0 {
+		errExit("there's nothing to generate\n")
+	}
+	if (check || checkHeader) && generateCode && outputFile == "" {
+		errExit("-check and -check-header need the output file, given with -o\n")
+	}
+	if checkHeader && len(plugins) > 0 {
+		errExit("-check-header can't check the files of plugins, use -check\n")
+	}
+	if pluginDir == "" && outputFile != "" {
+		pluginDir = filepath.Dir(outputFile)
+	}
+
 	swagger, err := util.LoadSwagger(flag.Arg(0))
 	if err != nil {
 		errExit("error loading swagger spec\n: %s", err)
 	}
 
-	code, err := codegen.Generate(swagger, packageName, opts)
+	if checkHeader {
+		inputHash, err := codegen.InputHash(swagger, packageName, opts)
+		if err != nil {
+			errExit("error hashing input: %s\n", err)
+		}
+		checkGeneratedHeader(outputFile, inputHash)
+		return
+	}
+
+	// The model is built once, for -generate model and for the plugins.
+	var code string
+	var model *codegen.Model
+	var renames []codegen.TypeRename
+	if generateModel || len(plugins) > 0 {
+		model, renames, err = codegen.BuildModelWithRenames(swagger, packageName, opts)
+		if err != nil {
+			errExit("error building model: %s\n", err)
+		}
+	}
+	if generateModel {
+		code, err = codegen.MarshalModel(model)
+	} else if generateCode {
+		code, renames, err = codegen.GenerateWithRenames(swagger, packageName, opts)
+	}
 	if err != nil {
 		errExit("error generating code: %s\n", err)
 	}
 
-	if outputFile != "" {
+	// Let the user know about any types which we had to rename.
+	for _, r := range renames {
+		_, _ = fmt.Fprintf(os.Stderr, "warning: %s\n", r)
+	}
+
+	// Plugins all run before anything is written, so that when one fails, or
+	// generates a file which another also does, nothing is half generated.
+	var pluginFiles []pluginOutputFile
+	if len(plugins) > 0 {
+		request := codegen.PluginRequest{Options: opts, Model: model}
+		request.Spec, err = json.Marshal(swagger)
+		if err != nil {
+			errExit("error marshaling spec for plugins: %s\n", err)
+		}
+
+		// Plugins may not write the same files as each other, or as we do.
+		written := make(map[string]string)
+		if generateCode && outputFile != "" {
+			written[filepath.Clean(outputFile)] = "-o"
+		}
+		for _, plugin := range plugins {
+			files, err := runPlugin(plugin, request)
+			if err != nil {
+				errExit("%s\n", err)
+			}
+			for _, file := range files {
+				filePath, err := pluginFilePath(pluginDir, file.Name)
+				if err != nil {
+					errExit("plugin %s generated a bad file name: %s\n", plugin.name, err)
+				}
+				if writer, found := written[filePath]; found {
+					errExit("plugin %s generated %s, which %s also generated\n", plugin.name, filePath, writer)
+				}
+				written[filePath] = "plugin " + plugin.name
+				pluginFiles = append(pluginFiles, pluginOutputFile{
+					plugin:  plugin.name,
+					path:    filePath,
+					content: file.Content,
+				})
+			}
+		}
+	}
+
+	upToDate := true
+	switch {
+	case !generateCode:
+	case check:
+		upToDate = checkGenerated(outputFile, code)
+	case outputFile != "":
 		err = ioutil.WriteFile(outputFile, []byte(code), 0644)
 		if err != nil {
 			errExit("error writing generated code to file: %s", err)
 		}
-	} else {
+	default:
 		fmt.Println(code)
 	}
+
+	for _, file := range pluginFiles {
+		if check {
+			upToDate = checkGenerated(file.path, file.content) && upToDate
+			continue
+		}
+		if err := writePluginFile(file.path, file.content); err != nil {
+			errExit("error writing file of plugin %s: %s\n", file.plugin, err)
+		}
+	}
+
+	if !upToDate {
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,130 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/deepmap/oapi-codegen/pkg/codegen"
+)
+
+// pluginFlag is a plugin to run, with its parameter.
+type pluginFlag struct {
+	name      string
+	parameter string
+}
+
+// pluginFlags collects the repeatable -plugin flag, whose values look like
+// name or name=parameter.
+type pluginFlags []pluginFlag
+
+// Plugin names become part of the name of an executable, so they can't be
+// paths.
+var pluginNameRE = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+func (p *pluginFlags) String() string {
+	var parts []string
+	for _, plugin := range *p {
+		part := plugin.name
+		if plugin.parameter != "" {
+			part += "=" + plugin.parameter
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (p *pluginFlags) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if !pluginNameRE.MatchString(parts[0]) {
+		return fmt.Errorf("plugin '%s' should look like name[=parameter]", value)
+	}
+	plugin := pluginFlag{name: parts[0]}
+	if len(parts) == 2 {
+		plugin.parameter = parts[1]
+	}
+	*p = append(*p, plugin)
+	return nil
+}
+
+// runPlugin runs the executable of a plugin, passing it the request on its
+// standard input, and returns the files it generates. What the plugin writes
+// to its standard error is passed on, so that it can report progress.
+func runPlugin(plugin pluginFlag, request codegen.PluginRequest) ([]codegen.PluginFile, error) {
+	executable, err := exec.LookPath(codegen.PluginPrefix + plugin.name)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s isn't installed, %s%s should be on the PATH",
+			plugin.name, codegen.PluginPrefix, plugin.name)
+	}
+	request.Parameter = plugin.parameter
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling request for plugin %s: %s", plugin.name, err)
+	}
+
+	var output bytes.Buffer
+	cmd := exec.Command(executable)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &output
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("plugin %s failed: %s", plugin.name, err)
+	}
+
+	var response codegen.PluginResponse
+	if err := json.Unmarshal(output.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("plugin %s returned an invalid response: %s", plugin.name, err)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("plugin %s: %s", plugin.name, response.Error)
+	}
+	return response.Files, nil
+}
+
+// pluginOutputFile is a file which a plugin generated, which is written once
+// every plugin has run.
+type pluginOutputFile struct {
+	plugin  string
+	path    string
+	content string
+}
+
+// pluginFilePath returns the path which a file generated by a plugin is
+// written to, in dir, or an error if its name would put it elsewhere.
+func pluginFilePath(dir string, name string) (string, error) {
+	cleaned := path.Clean(name)
+	if name == "" || path.IsAbs(name) || filepath.IsAbs(name) || cleaned == "." || cleaned == ".." ||
+		strings.HasPrefix(cleaned, "../") || filepath.VolumeName(name) != "" {
+		return "", fmt.Errorf("'%s' isn't a relative path inside the plugin output directory", name)
+	}
+	return filepath.Join(dir, filepath.FromSlash(cleaned)), nil
+}
+
+// writePluginFile writes a file generated by a plugin, creating the
+// directories it's in.
+func writePluginFile(filePath string, content string) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filePath, []byte(content), 0644)
+}
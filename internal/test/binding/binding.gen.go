@@ -0,0 +1,1891 @@
+// Package binding provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version devel DO NOT EDIT.
+// Input hash: sha256:8876705adc4bdb82b00cfb4afe15f3083d05a74d521729347ecb44b6d01e2756
+package binding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+	"github.com/labstack/echo/v4"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Color defines model for Color.
+type Color string
+
+// Filter defines model for Filter.
+type Filter struct {
+	Active *bool    `json:"active,omitempty" validate:"bool"`
+	Limit  *int     `json:"limit,omitempty" validate:"numeric"`
+	Name   string   `json:"name" validate:"required"`
+	Ratio  *float32 `json:"ratio,omitempty" validate:"numeric"`
+}
+
+// Count defines model for count.
+type Count json.Number
+
+// GetHeadersParams defines parameters for GetHeaders.
+type GetHeadersParams struct {
+	XCount  json.Number  `schema:"X-Count" validate:"required,numeric"`
+	XTags   *[]string    `schema:"X-Tags,omitempty"`
+	XFilter *Filter      `schema:"X-Filter,omitempty"`
+	Session *string      `schema:"session,omitempty"`
+	Ratio   *json.Number `schema:"ratio,omitempty" validate:"omitempty,numeric"`
+}
+
+// GetQueryParams defines parameters for GetQuery.
+type GetQueryParams struct {
+	Id      json.Number    `schema:"id" validate:"required,numeric"`
+	Verbose *bool          `schema:"verbose,omitempty" validate:"omitempty,bool"`
+	Color   *Color         `schema:"color,omitempty" validate:"omitempty,oneof=red green blue"`
+	Tags    *[]string      `schema:"tags,omitempty"`
+	Ids     *[]json.Number `schema:"ids,omitempty"`
+	Filter  *Filter        `schema:"filter,omitempty"`
+	Page    *struct {
+		Offset *json.Number `json:"offset,omitempty" validate:"numeric"`
+		Size   *json.Number `json:"size,omitempty" validate:"numeric"`
+	} `schema:"page,omitempty"`
+	Sort *struct {
+		Field *string `json:"field,omitempty"`
+		Order *string `json:"order,omitempty"`
+	} `schema:"sort,omitempty"`
+}
+
+// GetReservedParams defines parameters for GetReserved.
+type GetReservedParams struct {
+	Q      *string `schema:"q,omitempty"`
+	Raw    *string `schema:"raw,omitempty"`
+	Filter *Filter `schema:"filter,omitempty"`
+}
+
+// Validate checks Color against the constraints of its schema, and
+// returns every violation as openapi_types.ValidationErrors.
+func (v Color) Validate() error {
+	var errs openapi_types.ValidationErrors
+	switch string(v) {
+	case "red", "green", "blue":
+	default:
+		errs.Add("", "must be one of %s", "\"red\", \"green\", \"blue\"")
+	}
+
+	return errs.Err()
+}
+
+// Validate checks GetHeadersParams against the constraints of its schema, and
+// returns every violation as openapi_types.ValidationErrors.
+func (v GetHeadersParams) Validate() error {
+	var errs openapi_types.ValidationErrors
+	if v.XFilter != nil {
+		errs.Nest("/X-Filter", openapi_types.ValidateValue(*v.XFilter))
+	}
+
+	return errs.Err()
+}
+
+// Validate checks GetQueryParams against the constraints of its schema, and
+// returns every violation as openapi_types.ValidationErrors.
+func (v GetQueryParams) Validate() error {
+	var errs openapi_types.ValidationErrors
+	if v.Color != nil {
+		errs.Nest("/color", openapi_types.ValidateValue(*v.Color))
+	}
+	if v.Filter != nil {
+		errs.Nest("/filter", openapi_types.ValidateValue(*v.Filter))
+	}
+
+	return errs.Err()
+}
+
+// Validate checks GetReservedParams against the constraints of its schema, and
+// returns every violation as openapi_types.ValidationErrors.
+func (v GetReservedParams) Validate() error {
+	var errs openapi_types.ValidationErrors
+	if v.Filter != nil {
+		errs.Nest("/filter", openapi_types.ValidateValue(*v.Filter))
+	}
+
+	return errs.Err()
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(req *http.Request, ctx context.Context) error
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example.
+	Server string
+
+	// HTTP client with any customized settings, such as certificate chains.
+	Client http.Client
+
+	// A callback for modifying requests which are generated before sending over
+	// the network.
+	RequestEditor RequestEditorFn
+
+	// The providers of the credentials of the security schemes, by the names
+	// which the spec gives them, as the ...SecurityScheme constants do. Each
+	// request is authenticated with those which its operation's security
+	// requirements ask for, before the RequestEditor is called.
+	SecurityProviders map[string]runtime.SecurityProvider
+
+	// Optional instrumentation, which is told of each operation the client
+	// sends, for tracing and metrics.
+	Instrumentation runtime.Instrumentation
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetArrays request
+	GetArrays(ctx context.Context, simple []json.Number, label []string, labelExploded []string, matrix []bool, matrixExploded []Color) (*http.Response, error)
+
+	// GetHeaders request
+	GetHeaders(ctx context.Context, params *GetHeadersParams) (*http.Response, error)
+
+	// GetObjects request
+	GetObjects(ctx context.Context, simple Filter, simpleExploded Filter, label Filter, matrixExploded Filter) (*http.Response, error)
+
+	// GetPrimitives request
+	GetPrimitives(ctx context.Context, simple string, label json.Number, matrix bool, count Count) (*http.Response, error)
+
+	// GetQuery request
+	GetQuery(ctx context.Context, params *GetQueryParams) (*http.Response, error)
+
+	// GetReserved request
+	//
+	// Parameters with reserved characters, which the client escapes.
+	GetReserved(ctx context.Context, id string, labels []string, params *GetReservedParams) (*http.Response, error)
+}
+
+func (c *Client) GetArrays(ctx context.Context, simple []json.Number, label []string, labelExploded []string, matrix []bool, matrixExploded []Color) (*http.Response, error) {
+	req, err := NewGetArraysRequest(c.Server, simple, label, labelExploded, matrix, matrixExploded)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[0])
+}
+
+func (c *Client) GetHeaders(ctx context.Context, params *GetHeadersParams) (*http.Response, error) {
+	req, err := NewGetHeadersRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[1])
+}
+
+func (c *Client) GetObjects(ctx context.Context, simple Filter, simpleExploded Filter, label Filter, matrixExploded Filter) (*http.Response, error) {
+	req, err := NewGetObjectsRequest(c.Server, simple, simpleExploded, label, matrixExploded)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[2])
+}
+
+func (c *Client) GetPrimitives(ctx context.Context, simple string, label json.Number, matrix bool, count Count) (*http.Response, error) {
+	req, err := NewGetPrimitivesRequest(c.Server, simple, label, matrix, count)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[3])
+}
+
+func (c *Client) GetQuery(ctx context.Context, params *GetQueryParams) (*http.Response, error) {
+	req, err := NewGetQueryRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[4])
+}
+
+// Parameters with reserved characters, which the client escapes.
+func (c *Client) GetReserved(ctx context.Context, id string, labels []string, params *GetReservedParams) (*http.Response, error) {
+	req, err := NewGetReservedRequest(c.Server, id, labels, params)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[5])
+}
+
+// Sends the request for the operation, as the instrumentation observes it.
+func (c *Client) do(ctx context.Context, req *http.Request, op *runtime.OperationInfo) (*http.Response, error) {
+	if c.Instrumentation == nil {
+		return c.send(ctx, req, op)
+	}
+	return runtime.InstrumentRequest(ctx, req, op, c.Instrumentation, c.send)
+}
+
+// Authenticates and edits the request, then sends it.
+func (c *Client) send(ctx context.Context, req *http.Request, op *runtime.OperationInfo) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	err := runtime.ApplySecurity(req, op.Security, c.SecurityProviders)
+	if err != nil {
+		return nil, err
+	}
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(req, ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetArraysRequest generates requests for GetArrays
+func NewGetArraysRequest(server string, simple []json.Number, label []string, labelExploded []string, matrix []bool, matrixExploded []Color) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "simple", simple, runtime.StyleParamOptions{Location: runtime.ParamLocationPath})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("label", false, "label", label, runtime.StyleParamOptions{Location: runtime.ParamLocationPath})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithOptions("label", true, "labelExploded", labelExploded, runtime.StyleParamOptions{Location: runtime.ParamLocationPath})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam3 string
+
+	pathParam3, err = runtime.StyleParamWithOptions("matrix", false, "matrix", matrix, runtime.StyleParamOptions{Location: runtime.ParamLocationPath})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam4 string
+
+	pathParam4, err = runtime.StyleParamWithOptions("matrix", true, "matrixExploded", matrixExploded, runtime.StyleParamOptions{Location: runtime.ParamLocationPath})
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/arrays/%s/%s/%s/%s/%s", pathParam0, pathParam1, pathParam2, pathParam3, pathParam4)
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetHeadersRequest generates requests for GetHeaders
+func NewGetHeadersRequest(server string, params *GetHeadersParams) (*http.Request, error) {
+	var err error
+
+	operationPath := "/headers"
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var headerParam0 string
+
+	headerParam0, err = runtime.StyleParam("simple", false, "X-Count", params.XCount)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("X-Count", headerParam0)
+
+	if params.XTags != nil {
+		var headerParam1 string
+
+		headerParam1, err = runtime.StyleParam("simple", false, "X-Tags", *params.XTags)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Add("X-Tags", headerParam1)
+	}
+
+	if params.XFilter != nil {
+		var headerParam2 string
+
+		headerParam2, err = runtime.StyleParam("simple", true, "X-Filter", *params.XFilter)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Add("X-Filter", headerParam2)
+	}
+
+	if params.Session != nil {
+		var cookieParam0 string
+
+		cookieParam0, err = runtime.StyleParam("simple", true, "session", *params.Session)
+		if err != nil {
+			return nil, err
+		}
+
+		cookie0 := &http.Cookie{
+			Name:  "session",
+			Value: cookieParam0,
+		}
+		req.AddCookie(cookie0)
+	}
+
+	if params.Ratio != nil {
+		var cookieParam1 string
+
+		cookieParam1, err = runtime.StyleParam("simple", true, "ratio", *params.Ratio)
+		if err != nil {
+			return nil, err
+		}
+
+		cookie1 := &http.Cookie{
+			Name:  "ratio",
+			Value: cookieParam1,
+		}
+		req.AddCookie(cookie1)
+	}
+
+	return req, nil
+}
+
+// NewGetObjectsRequest generates requests for GetObjects
+func NewGetObjectsRequest(server string, simple Filter, simpleExploded Filter, label Filter, matrixExploded Filter) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "simple", simple, runtime.StyleParamOptions{Location: runtime.ParamLocationPath})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("simple", true, "simpleExploded", simpleExploded, runtime.StyleParamOptions{Location: runtime.ParamLocationPath})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithOptions("label", false, "label", label, runtime.StyleParamOptions{Location: runtime.ParamLocationPath})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam3 string
+
+	pathParam3, err = runtime.StyleParamWithOptions("matrix", true, "matrixExploded", matrixExploded, runtime.StyleParamOptions{Location: runtime.ParamLocationPath})
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/objects/%s/%s/%s/%s", pathParam0, pathParam1, pathParam2, pathParam3)
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPrimitivesRequest generates requests for GetPrimitives
+func NewGetPrimitivesRequest(server string, simple string, label json.Number, matrix bool, count Count) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "simple", simple, runtime.StyleParamOptions{Location: runtime.ParamLocationPath})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("label", false, "label", label, runtime.StyleParamOptions{Location: runtime.ParamLocationPath})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithOptions("matrix", true, "matrix", matrix, runtime.StyleParamOptions{Location: runtime.ParamLocationPath})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam3 string
+
+	pathParam3, err = runtime.StyleParamWithOptions("simple", false, "count", count, runtime.StyleParamOptions{Location: runtime.ParamLocationPath})
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/primitives/%s/%s/%s/%s", pathParam0, pathParam1, pathParam2, pathParam3)
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetQueryRequest generates requests for GetQuery
+func NewGetQueryRequest(server string, params *GetQueryParams) (*http.Request, error) {
+	var err error
+
+	operationPath := "/query"
+
+	var queryStrings []string
+
+	var queryParam0 string
+
+	queryParam0, err = runtime.StyleParamWithOptions("form", true, "id", params.Id, runtime.StyleParamOptions{Location: runtime.ParamLocationQuery})
+	if err != nil {
+		return nil, err
+	}
+
+	queryStrings = append(queryStrings, queryParam0)
+
+	var queryParam1 string
+	if params.Verbose != nil {
+
+		queryParam1, err = runtime.StyleParamWithOptions("form", true, "verbose", *params.Verbose, runtime.StyleParamOptions{Location: runtime.ParamLocationQuery})
+		if err != nil {
+			return nil, err
+		}
+
+		queryStrings = append(queryStrings, queryParam1)
+	}
+
+	var queryParam2 string
+	if params.Color != nil {
+
+		queryParam2, err = runtime.StyleParamWithOptions("form", true, "color", *params.Color, runtime.StyleParamOptions{Location: runtime.ParamLocationQuery})
+		if err != nil {
+			return nil, err
+		}
+
+		queryStrings = append(queryStrings, queryParam2)
+	}
+
+	var queryParam3 string
+	if params.Tags != nil {
+
+		queryParam3, err = runtime.StyleParamWithOptions("form", true, "tags", *params.Tags, runtime.StyleParamOptions{Location: runtime.ParamLocationQuery})
+		if err != nil {
+			return nil, err
+		}
+
+		queryStrings = append(queryStrings, queryParam3)
+	}
+
+	var queryParam4 string
+	if params.Ids != nil {
+
+		queryParam4, err = runtime.StyleParamWithOptions("form", false, "ids", *params.Ids, runtime.StyleParamOptions{Location: runtime.ParamLocationQuery})
+		if err != nil {
+			return nil, err
+		}
+
+		queryStrings = append(queryStrings, queryParam4)
+	}
+
+	var queryParam5 string
+	if params.Filter != nil {
+
+		queryParam5, err = runtime.StyleParamWithOptions("form", false, "filter", *params.Filter, runtime.StyleParamOptions{Location: runtime.ParamLocationQuery})
+		if err != nil {
+			return nil, err
+		}
+
+		queryStrings = append(queryStrings, queryParam5)
+	}
+
+	var queryParam6 string
+	if params.Page != nil {
+
+		queryParam6, err = runtime.StyleParamWithOptions("form", true, "page", *params.Page, runtime.StyleParamOptions{Location: runtime.ParamLocationQuery})
+		if err != nil {
+			return nil, err
+		}
+
+		queryStrings = append(queryStrings, queryParam6)
+	}
+
+	var queryParam7 string
+	if params.Sort != nil {
+
+		queryParam7, err = runtime.StyleParamWithOptions("deepObject", true, "sort", *params.Sort, runtime.StyleParamOptions{Location: runtime.ParamLocationQuery})
+		if err != nil {
+			return nil, err
+		}
+
+		queryStrings = append(queryStrings, queryParam7)
+	}
+
+	queryURL, err := runtime.OperationURL(server, operationPath, queryStrings)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetReservedRequest generates requests for GetReserved
+func NewGetReservedRequest(server string, id string, labels []string, params *GetReservedParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{Location: runtime.ParamLocationPath})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("label", true, "labels", labels, runtime.StyleParamOptions{Location: runtime.ParamLocationPath})
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reserved/%s/%s", pathParam0, pathParam1)
+
+	var queryStrings []string
+
+	var queryParam0 string
+	if params.Q != nil {
+
+		queryParam0, err = runtime.StyleParamWithOptions("form", true, "q", *params.Q, runtime.StyleParamOptions{Location: runtime.ParamLocationQuery})
+		if err != nil {
+			return nil, err
+		}
+
+		queryStrings = append(queryStrings, queryParam0)
+	}
+
+	var queryParam1 string
+	if params.Raw != nil {
+
+		queryParam1, err = runtime.StyleParamWithOptions("form", true, "raw", *params.Raw, runtime.StyleParamOptions{Location: runtime.ParamLocationQuery, AllowReserved: true})
+		if err != nil {
+			return nil, err
+		}
+
+		queryStrings = append(queryStrings, queryParam1)
+	}
+
+	var queryParam2 string
+	if params.Filter != nil {
+
+		queryParam2, err = runtime.StyleParamWithOptions("deepObject", true, "filter", *params.Filter, runtime.StyleParamOptions{Location: runtime.ParamLocationQuery})
+		if err != nil {
+			return nil, err
+		}
+
+		queryStrings = append(queryStrings, queryParam2)
+	}
+
+	queryURL, err := runtime.OperationURL(server, operationPath, queryStrings)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses returns a ClientWithResponses with a default Client:
+func NewClientWithResponses(server string) *ClientWithResponses {
+	return &ClientWithResponses{
+		ClientInterface: &Client{
+			Client: http.Client{},
+			Server: server,
+		},
+	}
+}
+
+// NewClientWithResponsesAndRequestEditorFunc takes in a RequestEditorFn callback function and returns a ClientWithResponses with a default Client:
+func NewClientWithResponsesAndRequestEditorFunc(server string, reqEditorFn RequestEditorFn) *ClientWithResponses {
+	return &ClientWithResponses{
+		ClientInterface: &Client{
+			Client:        http.Client{},
+			Server:        server,
+			RequestEditor: reqEditorFn,
+		},
+	}
+}
+
+// NewClientWithResponsesAndSecurityProviders returns a ClientWithResponses with a default Client, which authenticates requests with the given providers of security schemes, by name:
+func NewClientWithResponsesAndSecurityProviders(server string, providers map[string]runtime.SecurityProvider) *ClientWithResponses {
+	return &ClientWithResponses{
+		ClientInterface: &Client{
+			Client:            http.Client{},
+			Server:            server,
+			SecurityProviders: providers,
+		},
+	}
+}
+
+type getArraysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r getArraysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r getArraysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type getHeadersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r getHeadersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r getHeadersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type getObjectsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r getObjectsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r getObjectsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type getPrimitivesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r getPrimitivesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r getPrimitivesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type getQueryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r getQueryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r getQueryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type getReservedResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r getReservedResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r getReservedResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetArraysWithResponse request returning *GetArraysResponse
+func (c *ClientWithResponses) GetArraysWithResponse(ctx context.Context, simple []json.Number, label []string, labelExploded []string, matrix []bool, matrixExploded []Color) (*getArraysResponse, error) {
+	rsp, err := c.GetArrays(ctx, simple, label, labelExploded, matrix, matrixExploded)
+	if err != nil {
+		return nil, err
+	}
+	return ParsegetArraysResponse(rsp)
+}
+
+// GetHeadersWithResponse request returning *GetHeadersResponse
+func (c *ClientWithResponses) GetHeadersWithResponse(ctx context.Context, params *GetHeadersParams) (*getHeadersResponse, error) {
+	rsp, err := c.GetHeaders(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return ParsegetHeadersResponse(rsp)
+}
+
+// GetObjectsWithResponse request returning *GetObjectsResponse
+func (c *ClientWithResponses) GetObjectsWithResponse(ctx context.Context, simple Filter, simpleExploded Filter, label Filter, matrixExploded Filter) (*getObjectsResponse, error) {
+	rsp, err := c.GetObjects(ctx, simple, simpleExploded, label, matrixExploded)
+	if err != nil {
+		return nil, err
+	}
+	return ParsegetObjectsResponse(rsp)
+}
+
+// GetPrimitivesWithResponse request returning *GetPrimitivesResponse
+func (c *ClientWithResponses) GetPrimitivesWithResponse(ctx context.Context, simple string, label json.Number, matrix bool, count Count) (*getPrimitivesResponse, error) {
+	rsp, err := c.GetPrimitives(ctx, simple, label, matrix, count)
+	if err != nil {
+		return nil, err
+	}
+	return ParsegetPrimitivesResponse(rsp)
+}
+
+// GetQueryWithResponse request returning *GetQueryResponse
+func (c *ClientWithResponses) GetQueryWithResponse(ctx context.Context, params *GetQueryParams) (*getQueryResponse, error) {
+	rsp, err := c.GetQuery(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return ParsegetQueryResponse(rsp)
+}
+
+// Parameters with reserved characters, which the client escapes.
+//
+// GetReservedWithResponse request returning *GetReservedResponse
+func (c *ClientWithResponses) GetReservedWithResponse(ctx context.Context, id string, labels []string, params *GetReservedParams) (*getReservedResponse, error) {
+	rsp, err := c.GetReserved(ctx, id, labels, params)
+	if err != nil {
+		return nil, err
+	}
+	return ParsegetReservedResponse(rsp)
+}
+
+// ParsegetArraysResponse parses an HTTP response from a GetArraysWithResponse call
+func ParsegetArraysResponse(rsp *http.Response) (*getArraysResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &getArraysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case rsp.StatusCode == 204:
+		break // No content-type
+	}
+
+	return response, nil
+}
+
+// ParsegetHeadersResponse parses an HTTP response from a GetHeadersWithResponse call
+func ParsegetHeadersResponse(rsp *http.Response) (*getHeadersResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &getHeadersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case rsp.StatusCode == 204:
+		break // No content-type
+	}
+
+	return response, nil
+}
+
+// ParsegetObjectsResponse parses an HTTP response from a GetObjectsWithResponse call
+func ParsegetObjectsResponse(rsp *http.Response) (*getObjectsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &getObjectsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case rsp.StatusCode == 204:
+		break // No content-type
+	}
+
+	return response, nil
+}
+
+// ParsegetPrimitivesResponse parses an HTTP response from a GetPrimitivesWithResponse call
+func ParsegetPrimitivesResponse(rsp *http.Response) (*getPrimitivesResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &getPrimitivesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case rsp.StatusCode == 204:
+		break // No content-type
+	}
+
+	return response, nil
+}
+
+// ParsegetQueryResponse parses an HTTP response from a GetQueryWithResponse call
+func ParsegetQueryResponse(rsp *http.Response) (*getQueryResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &getQueryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case rsp.StatusCode == 204:
+		break // No content-type
+	}
+
+	return response, nil
+}
+
+// ParsegetReservedResponse parses an HTTP response from a GetReservedWithResponse call
+func ParsegetReservedResponse(rsp *http.Response) (*getReservedResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &getReservedResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case rsp.StatusCode == 204:
+		break // No content-type
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// (GET /arrays/{simple}/{label}/{labelExploded}/{matrix}/{matrixExploded})
+	GetArrays(ctx echo.Context, simple []json.Number, label []string, labelExploded []string, matrix []bool, matrixExploded []Color) error
+	// (GET /headers)
+	GetHeaders(ctx echo.Context, params GetHeadersParams) error
+	// (GET /objects/{simple}/{simpleExploded}/{label}/{matrixExploded})
+	GetObjects(ctx echo.Context, simple Filter, simpleExploded Filter, label Filter, matrixExploded Filter) error
+	// (GET /primitives/{simple}/{label}/{matrix}/{count})
+	GetPrimitives(ctx echo.Context, simple string, label json.Number, matrix bool, count Count) error
+	// (GET /query)
+	GetQuery(ctx echo.Context, params GetQueryParams) error
+	// Parameters with reserved characters, which the client escapes.
+	//
+	// (GET /reserved/{id}/{labels})
+	GetReserved(ctx echo.Context, id string, labels []string, params GetReservedParams) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// GetArrays converts echo context to params.
+func (w *ServerInterfaceWrapper) GetArrays(ctx echo.Context) error {
+	runtime.SetOperationInfo(ctx, &Operations[0])
+
+	var err error
+	// ------------- Path parameter "simple" -------------
+	var simple []json.Number
+
+	{
+		parts, err := runtime.StyledParameterParts("simple", false, false, "simple", runtime.PathParam(ctx, "simple"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter simple: %s", err))
+		}
+		array := make([]json.Number, len(parts))
+		for i, part := range parts {
+			array[i] = json.Number(part)
+		}
+		simple = array
+	}
+
+	// ------------- Path parameter "label" -------------
+	var label []string
+
+	{
+		parts, err := runtime.StyledParameterParts("label", false, false, "label", runtime.PathParam(ctx, "label"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter label: %s", err))
+		}
+		array := make([]string, len(parts))
+		for i, part := range parts {
+			array[i] = part
+		}
+		label = array
+	}
+
+	// ------------- Path parameter "labelExploded" -------------
+	var labelExploded []string
+
+	{
+		parts, err := runtime.StyledParameterParts("label", true, false, "labelExploded", runtime.PathParam(ctx, "labelExploded"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter labelExploded: %s", err))
+		}
+		array := make([]string, len(parts))
+		for i, part := range parts {
+			array[i] = part
+		}
+		labelExploded = array
+	}
+
+	// ------------- Path parameter "matrix" -------------
+	var matrix []bool
+
+	{
+		parts, err := runtime.StyledParameterParts("matrix", false, false, "matrix", runtime.PathParam(ctx, "matrix"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter matrix: %s", err))
+		}
+		array := make([]bool, len(parts))
+		for i, part := range parts {
+			parsed1, err := strconv.ParseBool(part)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter matrix: %s", err))
+			}
+			array[i] = parsed1
+		}
+		matrix = array
+	}
+
+	// ------------- Path parameter "matrixExploded" -------------
+	var matrixExploded []Color
+
+	{
+		parts, err := runtime.StyledParameterParts("matrix", true, false, "matrixExploded", runtime.PathParam(ctx, "matrixExploded"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter matrixExploded: %s", err))
+		}
+		array := make([]Color, len(parts))
+		for i, part := range parts {
+			array[i] = Color(part)
+		}
+		matrixExploded = array
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetArrays(ctx, simple, label, labelExploded, matrix, matrixExploded)
+	return err
+}
+
+// GetHeaders converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHeaders(ctx echo.Context) error {
+	runtime.SetOperationInfo(ctx, &Operations[1])
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetHeadersParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Count" -------------
+	if valueList, found := headers["X-Count"]; found {
+		var XCount json.Number
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Count, got %d", n))
+		}
+
+		{
+			value, err := runtime.StyledParameterValue("simple", false, "X-Count", valueList[0])
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Count: %s", err))
+			}
+			XCount = json.Number(value)
+		}
+
+		params.XCount = XCount
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Count is required, but not found"))
+	}
+	// ------------- Optional header parameter "X-Tags" -------------
+	if valueList, found := headers["X-Tags"]; found {
+		var XTags []string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Tags, got %d", n))
+		}
+
+		{
+			parts, err := runtime.StyledParameterParts("simple", false, false, "X-Tags", valueList[0])
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Tags: %s", err))
+			}
+			array := make([]string, len(parts))
+			for i, part := range parts {
+				array[i] = part
+			}
+			XTags = array
+		}
+
+		params.XTags = &XTags
+	}
+	// ------------- Optional header parameter "X-Filter" -------------
+	if valueList, found := headers["X-Filter"]; found {
+		var XFilter Filter
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Filter, got %d", n))
+		}
+
+		{
+			parts, err := runtime.StyledParameterParts("simple", true, true, "X-Filter", valueList[0])
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Filter: %s", err))
+			}
+			var object Filter
+			for _, part := range parts {
+				property, value, err := runtime.SplitStyledProperty("X-Filter", part)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Filter: %s", err))
+				}
+				switch property {
+				case "active":
+					parsed1, err := strconv.ParseBool(value)
+					if err != nil {
+						return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Filter: %s", err))
+					}
+					field1 := parsed1
+					object.Active = &field1
+				case "limit":
+					parsed1, err := strconv.ParseInt(value, 10, 0)
+					if err != nil {
+						return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Filter: %s", err))
+					}
+					field1 := int(parsed1)
+					object.Limit = &field1
+				case "name":
+					object.Name = value
+				case "ratio":
+					parsed1, err := strconv.ParseFloat(value, 32)
+					if err != nil {
+						return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Filter: %s", err))
+					}
+					field1 := float32(parsed1)
+					object.Ratio = &field1
+				}
+			}
+			XFilter = object
+		}
+
+		params.XFilter = &XFilter
+	}
+
+	if cookie, err := ctx.Cookie("session"); err == nil {
+
+		var value string
+		{
+			valueValue, err := runtime.StyledParameterValue("simple", true, "session", cookie.Value)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter session: %s", err))
+			}
+			value = valueValue
+		}
+
+		params.Session = &value
+
+	}
+
+	if cookie, err := ctx.Cookie("ratio"); err == nil {
+
+		var value json.Number
+		{
+			valueValue, err := runtime.StyledParameterValue("simple", true, "ratio", cookie.Value)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter ratio: %s", err))
+			}
+			value = json.Number(valueValue)
+		}
+
+		params.Ratio = &value
+
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetHeaders(ctx, params)
+	return err
+}
+
+// GetObjects converts echo context to params.
+func (w *ServerInterfaceWrapper) GetObjects(ctx echo.Context) error {
+	runtime.SetOperationInfo(ctx, &Operations[2])
+
+	var err error
+	// ------------- Path parameter "simple" -------------
+	var simple Filter
+
+	{
+		parts, err := runtime.StyledParameterParts("simple", false, true, "simple", runtime.PathParam(ctx, "simple"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter simple: %s", err))
+		}
+		var object Filter
+		if len(parts)%2 != 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid format for parameter simple: property/values need to be pairs")
+		}
+		for i := 0; i < len(parts); i += 2 {
+			property, value := parts[i], parts[i+1]
+			switch property {
+			case "active":
+				parsed1, err := strconv.ParseBool(value)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter simple: %s", err))
+				}
+				field1 := parsed1
+				object.Active = &field1
+			case "limit":
+				parsed1, err := strconv.ParseInt(value, 10, 0)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter simple: %s", err))
+				}
+				field1 := int(parsed1)
+				object.Limit = &field1
+			case "name":
+				object.Name = value
+			case "ratio":
+				parsed1, err := strconv.ParseFloat(value, 32)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter simple: %s", err))
+				}
+				field1 := float32(parsed1)
+				object.Ratio = &field1
+			}
+		}
+		simple = object
+	}
+
+	// ------------- Path parameter "simpleExploded" -------------
+	var simpleExploded Filter
+
+	{
+		parts, err := runtime.StyledParameterParts("simple", true, true, "simpleExploded", runtime.PathParam(ctx, "simpleExploded"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter simpleExploded: %s", err))
+		}
+		var object Filter
+		for _, part := range parts {
+			property, value, err := runtime.SplitStyledProperty("simpleExploded", part)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter simpleExploded: %s", err))
+			}
+			switch property {
+			case "active":
+				parsed1, err := strconv.ParseBool(value)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter simpleExploded: %s", err))
+				}
+				field1 := parsed1
+				object.Active = &field1
+			case "limit":
+				parsed1, err := strconv.ParseInt(value, 10, 0)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter simpleExploded: %s", err))
+				}
+				field1 := int(parsed1)
+				object.Limit = &field1
+			case "name":
+				object.Name = value
+			case "ratio":
+				parsed1, err := strconv.ParseFloat(value, 32)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter simpleExploded: %s", err))
+				}
+				field1 := float32(parsed1)
+				object.Ratio = &field1
+			}
+		}
+		simpleExploded = object
+	}
+
+	// ------------- Path parameter "label" -------------
+	var label Filter
+
+	{
+		parts, err := runtime.StyledParameterParts("label", false, true, "label", runtime.PathParam(ctx, "label"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter label: %s", err))
+		}
+		var object Filter
+		if len(parts)%2 != 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid format for parameter label: property/values need to be pairs")
+		}
+		for i := 0; i < len(parts); i += 2 {
+			property, value := parts[i], parts[i+1]
+			switch property {
+			case "active":
+				parsed1, err := strconv.ParseBool(value)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter label: %s", err))
+				}
+				field1 := parsed1
+				object.Active = &field1
+			case "limit":
+				parsed1, err := strconv.ParseInt(value, 10, 0)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter label: %s", err))
+				}
+				field1 := int(parsed1)
+				object.Limit = &field1
+			case "name":
+				object.Name = value
+			case "ratio":
+				parsed1, err := strconv.ParseFloat(value, 32)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter label: %s", err))
+				}
+				field1 := float32(parsed1)
+				object.Ratio = &field1
+			}
+		}
+		label = object
+	}
+
+	// ------------- Path parameter "matrixExploded" -------------
+	var matrixExploded Filter
+
+	{
+		parts, err := runtime.StyledParameterParts("matrix", true, true, "matrixExploded", runtime.PathParam(ctx, "matrixExploded"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter matrixExploded: %s", err))
+		}
+		var object Filter
+		for _, part := range parts {
+			property, value, err := runtime.SplitStyledProperty("matrixExploded", part)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter matrixExploded: %s", err))
+			}
+			switch property {
+			case "active":
+				parsed1, err := strconv.ParseBool(value)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter matrixExploded: %s", err))
+				}
+				field1 := parsed1
+				object.Active = &field1
+			case "limit":
+				parsed1, err := strconv.ParseInt(value, 10, 0)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter matrixExploded: %s", err))
+				}
+				field1 := int(parsed1)
+				object.Limit = &field1
+			case "name":
+				object.Name = value
+			case "ratio":
+				parsed1, err := strconv.ParseFloat(value, 32)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter matrixExploded: %s", err))
+				}
+				field1 := float32(parsed1)
+				object.Ratio = &field1
+			}
+		}
+		matrixExploded = object
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetObjects(ctx, simple, simpleExploded, label, matrixExploded)
+	return err
+}
+
+// GetPrimitives converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPrimitives(ctx echo.Context) error {
+	runtime.SetOperationInfo(ctx, &Operations[3])
+
+	var err error
+	// ------------- Path parameter "simple" -------------
+	var simple string
+
+	{
+		value, err := runtime.StyledParameterValue("simple", false, "simple", runtime.PathParam(ctx, "simple"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter simple: %s", err))
+		}
+		simple = value
+	}
+
+	// ------------- Path parameter "label" -------------
+	var label json.Number
+
+	{
+		value, err := runtime.StyledParameterValue("label", false, "label", runtime.PathParam(ctx, "label"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter label: %s", err))
+		}
+		label = json.Number(value)
+	}
+
+	// ------------- Path parameter "matrix" -------------
+	var matrix bool
+
+	{
+		value, err := runtime.StyledParameterValue("matrix", true, "matrix", runtime.PathParam(ctx, "matrix"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter matrix: %s", err))
+		}
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter matrix: %s", err))
+		}
+		matrix = parsed
+	}
+
+	// ------------- Path parameter "count" -------------
+	var count Count
+
+	{
+		value, err := runtime.StyledParameterValue("simple", false, "count", runtime.PathParam(ctx, "count"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter count: %s", err))
+		}
+		count = Count(json.Number(value))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetPrimitives(ctx, simple, label, matrix, count)
+	return err
+}
+
+// GetQuery converts echo context to params.
+func (w *ServerInterfaceWrapper) GetQuery(ctx echo.Context) error {
+	runtime.SetOperationInfo(ctx, &Operations[4])
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetQueryParams
+	// ------------- Required query parameter "id" -------------
+	if paramValue := ctx.QueryParam("id"); paramValue != "" {
+
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument id is required, but not found"))
+	}
+
+	{
+		if values, found := ctx.QueryParams()["id"]; found {
+			if len(values) != 1 {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid format for parameter id: multiple values for single value parameter")
+			}
+			params.Id = json.Number(values[0])
+		}
+	}
+
+	// ------------- Optional query parameter "verbose" -------------
+	if paramValue := ctx.QueryParam("verbose"); paramValue != "" {
+
+	}
+
+	{
+		if values, found := ctx.QueryParams()["verbose"]; found {
+			if len(values) != 1 {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid format for parameter verbose: multiple values for single value parameter")
+			}
+			parsed, err := strconv.ParseBool(values[0])
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter verbose: %s", err))
+			}
+			value := parsed
+			params.Verbose = &value
+		}
+	}
+
+	// ------------- Optional query parameter "color" -------------
+	if paramValue := ctx.QueryParam("color"); paramValue != "" {
+
+	}
+
+	{
+		if values, found := ctx.QueryParams()["color"]; found {
+			if len(values) != 1 {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid format for parameter color: multiple values for single value parameter")
+			}
+			value := Color(values[0])
+			params.Color = &value
+		}
+	}
+
+	// ------------- Optional query parameter "tags" -------------
+	if paramValue := ctx.QueryParam("tags"); paramValue != "" {
+
+	}
+
+	{
+		if values, found := ctx.QueryParams()["tags"]; found {
+			array := make([]string, len(values))
+			for i, part := range values {
+				array[i] = part
+			}
+			value := array
+			params.Tags = &value
+		}
+	}
+
+	// ------------- Optional query parameter "ids" -------------
+	if paramValue := ctx.QueryParam("ids"); paramValue != "" {
+
+	}
+
+	{
+		if values, found := ctx.QueryParams()["ids"]; found {
+			if len(values) != 1 {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid format for parameter ids: parameter is not exploded, but is specified multiple times")
+			}
+			parts := strings.Split(values[0], ",")
+			array := make([]json.Number, len(parts))
+			for i, part := range parts {
+				array[i] = json.Number(part)
+			}
+			value := array
+			params.Ids = &value
+		}
+	}
+
+	// ------------- Optional query parameter "filter" -------------
+	if paramValue := ctx.QueryParam("filter"); paramValue != "" {
+
+	}
+
+	{
+		if values, found := ctx.QueryParams()["filter"]; found {
+			if len(values) != 1 {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid format for parameter filter: parameter is not exploded, but is specified multiple times")
+			}
+			parts := strings.Split(values[0], ",")
+			var object Filter
+			if len(parts)%2 != 0 {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid format for parameter filter: property/values need to be pairs")
+			}
+			for i := 0; i < len(parts); i += 2 {
+				property, value := parts[i], parts[i+1]
+				switch property {
+				case "active":
+					parsed1, err := strconv.ParseBool(value)
+					if err != nil {
+						return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter filter: %s", err))
+					}
+					field1 := parsed1
+					object.Active = &field1
+				case "limit":
+					parsed1, err := strconv.ParseInt(value, 10, 0)
+					if err != nil {
+						return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter filter: %s", err))
+					}
+					field1 := int(parsed1)
+					object.Limit = &field1
+				case "name":
+					object.Name = value
+				case "ratio":
+					parsed1, err := strconv.ParseFloat(value, 32)
+					if err != nil {
+						return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter filter: %s", err))
+					}
+					field1 := float32(parsed1)
+					object.Ratio = &field1
+				}
+			}
+			value := object
+			params.Filter = &value
+		}
+	}
+
+	// ------------- Optional query parameter "page" -------------
+	if paramValue := ctx.QueryParam("page"); paramValue != "" {
+
+	}
+
+	{
+		var object struct {
+			Offset *json.Number `json:"offset,omitempty" validate:"numeric"`
+			Size   *json.Number `json:"size,omitempty" validate:"numeric"`
+		}
+		if values, found := ctx.QueryParams()["offset"]; found {
+			if len(values) != 1 {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid format for parameter page: field 'offset' is specified multiple times")
+			}
+			field := json.Number(values[0])
+			object.Offset = &field
+		}
+		if values, found := ctx.QueryParams()["size"]; found {
+			if len(values) != 1 {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid format for parameter page: field 'size' is specified multiple times")
+			}
+			field := json.Number(values[0])
+			object.Size = &field
+		}
+		value := object
+		params.Page = &value
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+	if paramValue := ctx.QueryParam("sort"); paramValue != "" {
+
+	}
+
+	{
+		var object struct {
+			Field *string `json:"field,omitempty"`
+			Order *string `json:"order,omitempty"`
+		}
+		for key, values := range ctx.QueryParams() {
+			if !strings.HasPrefix(key, "sort[") {
+				continue
+			}
+			split := strings.Split(key, "[")
+			if len(split) != 2 {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: '%s' does not match deepObject style", key))
+			}
+			switch strings.TrimSuffix(split[1], "]") {
+			case "field":
+				field1 := values[0]
+				object.Field = &field1
+			case "order":
+				field1 := values[0]
+				object.Order = &field1
+			}
+		}
+		value := object
+		params.Sort = &value
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetQuery(ctx, params)
+	return err
+}
+
+// GetReserved converts echo context to params.
+func (w *ServerInterfaceWrapper) GetReserved(ctx echo.Context) error {
+	runtime.SetOperationInfo(ctx, &Operations[5])
+
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	{
+		value, err := runtime.StyledParameterValue("simple", false, "id", runtime.PathParam(ctx, "id"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		}
+		id = value
+	}
+
+	// ------------- Path parameter "labels" -------------
+	var labels []string
+
+	{
+		parts, err := runtime.StyledParameterParts("label", true, false, "labels", runtime.PathParam(ctx, "labels"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter labels: %s", err))
+		}
+		array := make([]string, len(parts))
+		for i, part := range parts {
+			array[i] = part
+		}
+		labels = array
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReservedParams
+	// ------------- Optional query parameter "q" -------------
+	if paramValue := ctx.QueryParam("q"); paramValue != "" {
+
+	}
+
+	{
+		if values, found := ctx.QueryParams()["q"]; found {
+			if len(values) != 1 {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid format for parameter q: multiple values for single value parameter")
+			}
+			value := values[0]
+			params.Q = &value
+		}
+	}
+
+	// ------------- Optional query parameter "raw" -------------
+	if paramValue := ctx.QueryParam("raw"); paramValue != "" {
+
+	}
+
+	{
+		if values, found := ctx.QueryParams()["raw"]; found {
+			if len(values) != 1 {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid format for parameter raw: multiple values for single value parameter")
+			}
+			value := values[0]
+			params.Raw = &value
+		}
+	}
+
+	// ------------- Optional query parameter "filter" -------------
+	if paramValue := ctx.QueryParam("filter"); paramValue != "" {
+
+	}
+
+	{
+		var object Filter
+		for key, values := range ctx.QueryParams() {
+			if !strings.HasPrefix(key, "filter[") {
+				continue
+			}
+			split := strings.Split(key, "[")
+			if len(split) != 2 {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter filter: '%s' does not match deepObject style", key))
+			}
+			switch strings.TrimSuffix(split[1], "]") {
+			case "active":
+				parsed1, err := strconv.ParseBool(values[0])
+				if err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter filter: %s", err))
+				}
+				field1 := parsed1
+				object.Active = &field1
+			case "limit":
+				parsed1, err := strconv.ParseInt(values[0], 10, 0)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter filter: %s", err))
+				}
+				field1 := int(parsed1)
+				object.Limit = &field1
+			case "name":
+				object.Name = values[0]
+			case "ratio":
+				parsed1, err := strconv.ParseFloat(values[0], 32)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter filter: %s", err))
+				}
+				field1 := float32(parsed1)
+				object.Ratio = &field1
+			}
+		}
+		value := object
+		params.Filter = &value
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetReserved(ctx, id, labels, params)
+	return err
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router runtime.EchoRouter, si ServerInterface) {
+	registerHandlers(router, si, "", nil)
+}
+
+// RegisterHandlersWithBaseURL adds each server route to the EchoRouter, under
+// the path of baseURL, which is typically the URL of one of the servers in the
+// spec. The routes of https://example.com/v2 are under /v2, for example.
+func RegisterHandlersWithBaseURL(router runtime.EchoRouter, si ServerInterface, baseURL string) error {
+	basePath, err := runtime.BaseURLPath(baseURL)
+	if err != nil {
+		return err
+	}
+	registerHandlers(router, si, basePath, nil)
+	return nil
+}
+
+// RegisterInstrumentedHandlers adds each server route to the EchoRouter under
+// the path of baseURL, as RegisterHandlersWithBaseURL does, and tells
+// instrumentation of each request which the routes handle, for tracing and
+// metrics.
+func RegisterInstrumentedHandlers(router runtime.EchoRouter, si ServerInterface, baseURL string, instrumentation runtime.Instrumentation) error {
+	basePath, err := runtime.BaseURLPath(baseURL)
+	if err != nil {
+		return err
+	}
+	registerHandlers(router, si, basePath, instrumentation)
+	return nil
+}
+
+func registerHandlers(router runtime.EchoRouter, si ServerInterface, basePath string, instrumentation runtime.Instrumentation) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET(basePath+"/arrays/:simple/:label/:labelExploded/:matrix/:matrixExploded", runtime.InstrumentHandler(wrapper.GetArrays, &Operations[0], instrumentation))
+	router.GET(basePath+"/headers", runtime.InstrumentHandler(wrapper.GetHeaders, &Operations[1], instrumentation))
+	router.GET(basePath+"/objects/:simple/:simpleExploded/:label/:matrixExploded", runtime.InstrumentHandler(wrapper.GetObjects, &Operations[2], instrumentation))
+	router.GET(basePath+"/primitives/:simple/:label/:matrix/:count", runtime.InstrumentHandler(wrapper.GetPrimitives, &Operations[3], instrumentation))
+	router.GET(basePath+"/query", runtime.InstrumentHandler(wrapper.GetQuery, &Operations[4], instrumentation))
+	router.GET(basePath+"/reserved/:id/:labels", runtime.InstrumentHandler(wrapper.GetReserved, &Operations[5], instrumentation))
+
+}
+
+// Operations describes each of the operations of the API, as the spec does, in
+// the order of their paths and methods.
+var Operations = []runtime.OperationInfo{
+	{
+		OperationID: "getArrays",
+		Method:      "GET",
+		Path:        "/arrays/{simple}/{label}/{labelExploded}/{matrix}/{matrixExploded}",
+	},
+	{
+		OperationID: "getHeaders",
+		Method:      "GET",
+		Path:        "/headers",
+	},
+	{
+		OperationID: "getObjects",
+		Method:      "GET",
+		Path:        "/objects/{simple}/{simpleExploded}/{label}/{matrixExploded}",
+	},
+	{
+		OperationID: "getPrimitives",
+		Method:      "GET",
+		Path:        "/primitives/{simple}/{label}/{matrix}/{count}",
+	},
+	{
+		OperationID: "getQuery",
+		Method:      "GET",
+		Path:        "/query",
+	},
+	{
+		OperationID: "getReserved",
+		Method:      "GET",
+		Path:        "/reserved/{id}/{labels}",
+	},
+}
+
+// OperationsByRoute indexes Operations by method and path, eg "GET /pets/{id}",
+// as runtime.RouteKey makes them.
+var OperationsByRoute = map[string]*runtime.OperationInfo{
+	"GET /arrays/{simple}/{label}/{labelExploded}/{matrix}/{matrixExploded}": &Operations[0],
+	"GET /headers": &Operations[1],
+	"GET /objects/{simple}/{simpleExploded}/{label}/{matrixExploded}": &Operations[2],
+	"GET /primitives/{simple}/{label}/{matrix}/{count}":               &Operations[3],
+	"GET /query":                  &Operations[4],
+	"GET /reserved/{id}/{labels}": &Operations[5],
+}
+
+// OperationsByID indexes Operations by operationId. Operations without one,
+// which are named by x-go-name, are only in OperationsByRoute.
+var OperationsByID = map[string]*runtime.OperationInfo{
+	"getArrays":     &Operations[0],
+	"getHeaders":    &Operations[1],
+	"getObjects":    &Operations[2],
+	"getPrimitives": &Operations[3],
+	"getQuery":      &Operations[4],
+	"getReserved":   &Operations[5],
+}
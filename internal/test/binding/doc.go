@@ -0,0 +1,3 @@
+package binding
+
+//go:generate go run github.com/deepmap/oapi-codegen/cmd/oapi-codegen --package=binding --generate=types,client,server -o binding.gen.go binding.yaml
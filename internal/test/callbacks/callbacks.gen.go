@@ -0,0 +1,647 @@
+// Package callbacks provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version devel DO NOT EDIT.
+// Input hash: sha256:752c2e32eaa462ff60edb998ea142c399a8519c4827648c084445c39dc3da340
+package callbacks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+	"github.com/labstack/echo/v4"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// Event defines model for Event.
+type Event struct {
+	Kind string `json:"kind" validate:"required"`
+}
+
+// Subscription defines model for Subscription.
+type Subscription struct {
+	CallbackUrl string `json:"callbackUrl" validate:"required"`
+}
+
+// CancelSubscriptionParams defines parameters for CancelSubscription.
+type CancelSubscriptionParams struct {
+	Reason *string `schema:"reason,omitempty"`
+}
+
+// SubscribeOnEventParams defines parameters for SubscribeOnEvent.
+type SubscribeOnEventParams struct {
+	XDelivery json.Number `schema:"X-Delivery" validate:"required,numeric"`
+}
+
+// NewPetJSONBody defines parameters for NewPet.
+type NewPetJSONBody struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// SubscribeRequestBody defines body for Subscribe for application/json ContentType.
+type SubscribeJSONRequestBody Subscription
+
+// Validate checks SubscribeJSONRequestBody against the constraints of its schema.
+func (v SubscribeJSONRequestBody) Validate() error {
+	return openapi_types.ValidateValue(Subscription(v))
+}
+
+// SubscribeOnEventRequestBody defines body for SubscribeOnEvent for application/json ContentType.
+type SubscribeOnEventJSONRequestBody Event
+
+// Validate checks SubscribeOnEventJSONRequestBody against the constraints of its schema.
+func (v SubscribeOnEventJSONRequestBody) Validate() error {
+	return openapi_types.ValidateValue(Event(v))
+}
+
+// NewPetRequestBody defines body for NewPet for application/json ContentType.
+type NewPetJSONRequestBody NewPetJSONBody
+
+// Validate checks NewPetJSONRequestBody against the constraints of its schema.
+func (v NewPetJSONRequestBody) Validate() error {
+	return openapi_types.ValidateValue(NewPetJSONBody(v))
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(req *http.Request, ctx context.Context) error
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example.
+	Server string
+
+	// HTTP client with any customized settings, such as certificate chains.
+	Client http.Client
+
+	// A callback for modifying requests which are generated before sending over
+	// the network.
+	RequestEditor RequestEditorFn
+
+	// The providers of the credentials of the security schemes, by the names
+	// which the spec gives them, as the ...SecurityScheme constants do. Each
+	// request is authenticated with those which its operation's security
+	// requirements ask for, before the RequestEditor is called.
+	SecurityProviders map[string]runtime.SecurityProvider
+
+	// Optional instrumentation, which is told of each operation the client
+	// sends, for tracing and metrics.
+	Instrumentation runtime.Instrumentation
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// Subscribe request with any body
+	SubscribeWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error)
+
+	Subscribe(ctx context.Context, body Subscription) (*http.Response, error)
+}
+
+func (c *Client) SubscribeWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := NewSubscribeRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[0])
+}
+
+func (c *Client) Subscribe(ctx context.Context, body Subscription) (*http.Response, error) {
+	req, err := NewSubscribeRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[0])
+}
+
+// Sends the request for the operation, as the instrumentation observes it.
+func (c *Client) do(ctx context.Context, req *http.Request, op *runtime.OperationInfo) (*http.Response, error) {
+	if c.Instrumentation == nil {
+		return c.send(ctx, req, op)
+	}
+	return runtime.InstrumentRequest(ctx, req, op, c.Instrumentation, c.send)
+}
+
+// Authenticates and edits the request, then sends it.
+func (c *Client) send(ctx context.Context, req *http.Request, op *runtime.OperationInfo) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	err := runtime.ApplySecurity(req, op.Security, c.SecurityProviders)
+	if err != nil {
+		return nil, err
+	}
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(req, ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.Do(req)
+}
+
+// NewSubscribeRequest calls the generic Subscribe builder with application/json body
+func NewSubscribeRequest(server string, body Subscription) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSubscribeRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewSubscribeRequestWithBody generates requests for Subscribe with any type of body
+func NewSubscribeRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	operationPath := "/subscriptions"
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+	return req, nil
+}
+
+// CallbackServerInterface represents the handlers for the callbacks and
+// webhooks which this API makes.
+type CallbackServerInterface interface {
+	// (DELETE {$request.body#/callbackUrl}/cancel)
+	CancelSubscription(ctx echo.Context, params CancelSubscriptionParams) error
+	// (POST {$request.body#/callbackUrl}/events)
+	SubscribeOnEvent(ctx echo.Context, params SubscribeOnEventParams) error
+	// (POST webhook newPet)
+	NewPet(ctx echo.Context) error
+}
+
+// CallbackServerInterfaceWrapper converts echo contexts to parameters.
+type CallbackServerInterfaceWrapper struct {
+	Handler CallbackServerInterface
+}
+
+// CancelSubscription converts echo context to params.
+func (w *CallbackServerInterfaceWrapper) CancelSubscription(ctx echo.Context) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CancelSubscriptionParams
+	// ------------- Optional query parameter "reason" -------------
+	if paramValue := ctx.QueryParam("reason"); paramValue != "" {
+
+	}
+
+	{
+		if values, found := ctx.QueryParams()["reason"]; found {
+			if len(values) != 1 {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid format for parameter reason: multiple values for single value parameter")
+			}
+			value := values[0]
+			params.Reason = &value
+		}
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.CancelSubscription(ctx, params)
+	return err
+}
+
+// SubscribeOnEvent converts echo context to params.
+func (w *CallbackServerInterfaceWrapper) SubscribeOnEvent(ctx echo.Context) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SubscribeOnEventParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Delivery" -------------
+	if valueList, found := headers["X-Delivery"]; found {
+		var XDelivery json.Number
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Delivery, got %d", n))
+		}
+
+		{
+			value, err := runtime.StyledParameterValue("simple", false, "X-Delivery", valueList[0])
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Delivery: %s", err))
+			}
+			XDelivery = json.Number(value)
+		}
+
+		params.XDelivery = XDelivery
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Delivery is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.SubscribeOnEvent(ctx, params)
+	return err
+}
+
+// NewPet converts echo context to params.
+func (w *CallbackServerInterfaceWrapper) NewPet(ctx echo.Context) error {
+
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.NewPet(ctx)
+	return err
+}
+
+// CallbackPaths holds the paths at which callbacks and webhooks are received,
+// which depend on the URLs given to the API. Those which are left empty aren't
+// registered.
+type CallbackPaths struct {
+	CancelSubscription string
+	SubscribeOnEvent   string
+	NewPet             string
+}
+
+// RegisterCallbackHandlers adds a route for each callback and webhook to the
+// EchoRouter, at its path in paths.
+func RegisterCallbackHandlers(router runtime.EchoRouter, si CallbackServerInterface, paths CallbackPaths) {
+	wrapper := CallbackServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	if paths.CancelSubscription != "" {
+		router.DELETE(paths.CancelSubscription, wrapper.CancelSubscription)
+	}
+	if paths.SubscribeOnEvent != "" {
+		router.POST(paths.SubscribeOnEvent, wrapper.SubscribeOnEvent)
+	}
+	if paths.NewPet != "" {
+		router.POST(paths.NewPet, wrapper.NewPet)
+	}
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses returns a ClientWithResponses with a default Client:
+func NewClientWithResponses(server string) *ClientWithResponses {
+	return &ClientWithResponses{
+		ClientInterface: &Client{
+			Client: http.Client{},
+			Server: server,
+		},
+	}
+}
+
+// NewClientWithResponsesAndRequestEditorFunc takes in a RequestEditorFn callback function and returns a ClientWithResponses with a default Client:
+func NewClientWithResponsesAndRequestEditorFunc(server string, reqEditorFn RequestEditorFn) *ClientWithResponses {
+	return &ClientWithResponses{
+		ClientInterface: &Client{
+			Client:        http.Client{},
+			Server:        server,
+			RequestEditor: reqEditorFn,
+		},
+	}
+}
+
+// NewClientWithResponsesAndSecurityProviders returns a ClientWithResponses with a default Client, which authenticates requests with the given providers of security schemes, by name:
+func NewClientWithResponsesAndSecurityProviders(server string, providers map[string]runtime.SecurityProvider) *ClientWithResponses {
+	return &ClientWithResponses{
+		ClientInterface: &Client{
+			Client:            http.Client{},
+			Server:            server,
+			SecurityProviders: providers,
+		},
+	}
+}
+
+type subscribeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r subscribeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r subscribeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// SubscribeWithBodyWithResponse request with arbitrary body returning *SubscribeResponse
+func (c *ClientWithResponses) SubscribeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*subscribeResponse, error) {
+	rsp, err := c.SubscribeWithBody(ctx, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	return ParsesubscribeResponse(rsp)
+}
+
+func (c *ClientWithResponses) SubscribeWithResponse(ctx context.Context, body Subscription) (*subscribeResponse, error) {
+	rsp, err := c.Subscribe(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	return ParsesubscribeResponse(rsp)
+}
+
+// ParsesubscribeResponse parses an HTTP response from a SubscribeWithResponse call
+func ParsesubscribeResponse(rsp *http.Response) (*subscribeResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &subscribeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case rsp.StatusCode == 201:
+		break // No content-type
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// (POST /subscriptions)
+	Subscribe(ctx echo.Context) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// Subscribe converts echo context to params.
+func (w *ServerInterfaceWrapper) Subscribe(ctx echo.Context) error {
+	runtime.SetOperationInfo(ctx, &Operations[0])
+
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.Subscribe(ctx)
+	return err
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router runtime.EchoRouter, si ServerInterface) {
+	registerHandlers(router, si, "", nil)
+}
+
+// RegisterHandlersWithBaseURL adds each server route to the EchoRouter, under
+// the path of baseURL, which is typically the URL of one of the servers in the
+// spec. The routes of https://example.com/v2 are under /v2, for example.
+func RegisterHandlersWithBaseURL(router runtime.EchoRouter, si ServerInterface, baseURL string) error {
+	basePath, err := runtime.BaseURLPath(baseURL)
+	if err != nil {
+		return err
+	}
+	registerHandlers(router, si, basePath, nil)
+	return nil
+}
+
+// RegisterInstrumentedHandlers adds each server route to the EchoRouter under
+// the path of baseURL, as RegisterHandlersWithBaseURL does, and tells
+// instrumentation of each request which the routes handle, for tracing and
+// metrics.
+func RegisterInstrumentedHandlers(router runtime.EchoRouter, si ServerInterface, baseURL string, instrumentation runtime.Instrumentation) error {
+	basePath, err := runtime.BaseURLPath(baseURL)
+	if err != nil {
+		return err
+	}
+	registerHandlers(router, si, basePath, instrumentation)
+	return nil
+}
+
+func registerHandlers(router runtime.EchoRouter, si ServerInterface, basePath string, instrumentation runtime.Instrumentation) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.POST(basePath+"/subscriptions", runtime.InstrumentHandler(wrapper.Subscribe, &Operations[0], instrumentation))
+
+}
+
+// CallbackClient sends the callbacks and webhooks which this API makes.
+type CallbackClient struct {
+	// HTTP client with any customized settings, such as certificate chains.
+	Client http.Client
+
+	// A callback for modifying requests which are generated before sending over
+	// the network.
+	RequestEditor func(req *http.Request, ctx context.Context) error
+}
+
+// CancelSubscription sends the onCancel callback of Subscribe to {$request.body#/callbackUrl}/cancel,
+// which is resolved against the request which triggered it
+func (c *CallbackClient) CancelSubscription(ctx context.Context, trigger runtime.CallbackTrigger, params *CancelSubscriptionParams) (*http.Response, error) {
+	callbackURL, err := runtime.ResolveCallbackURL("{$request.body#/callbackUrl}/cancel", trigger)
+	if err != nil {
+		return nil, err
+	}
+	req, err := NewCancelSubscriptionRequest(callbackURL, params)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req)
+}
+
+// SubscribeOnEventWithBody sends the onEvent callback of Subscribe to {$request.body#/callbackUrl}/events,
+// which is resolved against the request which triggered it, with any body
+func (c *CallbackClient) SubscribeOnEventWithBody(ctx context.Context, trigger runtime.CallbackTrigger, params *SubscribeOnEventParams, contentType string, body io.Reader) (*http.Response, error) {
+	callbackURL, err := runtime.ResolveCallbackURL("{$request.body#/callbackUrl}/events", trigger)
+	if err != nil {
+		return nil, err
+	}
+	req, err := NewSubscribeOnEventRequestWithBody(callbackURL, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req)
+}
+
+// SubscribeOnEvent sends SubscribeOnEvent with a application/json body
+func (c *CallbackClient) SubscribeOnEvent(ctx context.Context, trigger runtime.CallbackTrigger, params *SubscribeOnEventParams, body Event) (*http.Response, error) {
+	callbackURL, err := runtime.ResolveCallbackURL("{$request.body#/callbackUrl}/events", trigger)
+	if err != nil {
+		return nil, err
+	}
+	req, err := NewSubscribeOnEventRequest(callbackURL, params, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req)
+}
+
+// NewPetWithBody sends the newPet webhook to the given URL, with any body
+func (c *CallbackClient) NewPetWithBody(ctx context.Context, webhookURL string, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := NewNewPetRequestWithBody(webhookURL, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req)
+}
+
+// NewPet sends NewPet with a application/json body
+func (c *CallbackClient) NewPet(ctx context.Context, webhookURL string, body NewPetJSONBody) (*http.Response, error) {
+	req, err := NewNewPetRequest(webhookURL, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req)
+}
+
+func (c *CallbackClient) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	if c.RequestEditor != nil {
+		err := c.RequestEditor(req, ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.Do(req)
+}
+
+// NewCancelSubscriptionRequest generates requests for CancelSubscription
+func NewCancelSubscriptionRequest(server string, params *CancelSubscriptionParams) (*http.Request, error) {
+	var err error
+
+	operationPath := ""
+
+	var queryStrings []string
+
+	var queryParam0 string
+	if params.Reason != nil {
+
+		queryParam0, err = runtime.StyleParamWithOptions("form", true, "reason", *params.Reason, runtime.StyleParamOptions{Location: runtime.ParamLocationQuery})
+		if err != nil {
+			return nil, err
+		}
+
+		queryStrings = append(queryStrings, queryParam0)
+	}
+
+	queryURL, err := runtime.OperationURL(server, operationPath, queryStrings)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSubscribeOnEventRequest calls the generic SubscribeOnEvent builder with application/json body
+func NewSubscribeOnEventRequest(server string, params *SubscribeOnEventParams, body Event) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSubscribeOnEventRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewSubscribeOnEventRequestWithBody generates requests for SubscribeOnEvent with any type of body
+func NewSubscribeOnEventRequestWithBody(server string, params *SubscribeOnEventParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	operationPath := ""
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var headerParam0 string
+
+	headerParam0, err = runtime.StyleParam("simple", false, "X-Delivery", params.XDelivery)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("X-Delivery", headerParam0)
+
+	req.Header.Add("Content-Type", contentType)
+	return req, nil
+}
+
+// NewNewPetRequest calls the generic NewPet builder with application/json body
+func NewNewPetRequest(server string, body NewPetJSONBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewNewPetRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewNewPetRequestWithBody generates requests for NewPet with any type of body
+func NewNewPetRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	operationPath := ""
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+	return req, nil
+}
+
+// Operations describes each of the operations of the API, as the spec does, in
+// the order of their paths and methods.
+var Operations = []runtime.OperationInfo{
+	{
+		OperationID: "subscribe",
+		Method:      "POST",
+		Path:        "/subscriptions",
+	},
+}
+
+// OperationsByRoute indexes Operations by method and path, eg "GET /pets/{id}",
+// as runtime.RouteKey makes them.
+var OperationsByRoute = map[string]*runtime.OperationInfo{
+	"POST /subscriptions": &Operations[0],
+}
+
+// OperationsByID indexes Operations by operationId. Operations without one,
+// which are named by x-go-name, are only in OperationsByRoute.
+var OperationsByID = map[string]*runtime.OperationInfo{
+	"subscribe": &Operations[0],
+}
@@ -0,0 +1,95 @@
+package callbacks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+)
+
+// receiver records the callbacks which it receives.
+type receiver struct {
+	events   []Event
+	delivery string
+	reason   string
+	pets     []string
+}
+
+func (r *receiver) CancelSubscription(ctx echo.Context, params CancelSubscriptionParams) error {
+	if params.Reason != nil {
+		r.reason = *params.Reason
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (r *receiver) SubscribeOnEvent(ctx echo.Context, params SubscribeOnEventParams) error {
+	var event Event
+	if err := ctx.Bind(&event); err != nil {
+		return err
+	}
+	r.events = append(r.events, event)
+	r.delivery = params.XDelivery.String()
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (r *receiver) NewPet(ctx echo.Context) error {
+	var pet NewPetJSONBody
+	if err := ctx.Bind(&pet); err != nil {
+		return err
+	}
+	r.pets = append(r.pets, pet.Name)
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func TestCallbacks(t *testing.T) {
+	r := &receiver{}
+	e := echo.New()
+	RegisterCallbackHandlers(e, r, CallbackPaths{
+		SubscribeOnEvent:   "/hooks/events",
+		CancelSubscription: "/hooks/cancel",
+		NewPet:             "/hooks/pets",
+	})
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	// The request which subscribes to callbacks gives the URL to send them to.
+	subscribe, err := NewSubscribeRequest("http://api.example.com", Subscription{
+		CallbackUrl: server.URL + "/hooks",
+	})
+	assert.NoError(t, err)
+	var subscription Subscription
+	assert.NoError(t, json.NewDecoder(subscribe.Body).Decode(&subscription))
+	trigger := runtime.CallbackTrigger{Request: subscribe, RequestBody: subscription}
+
+	client := &CallbackClient{}
+	rsp, err := client.SubscribeOnEvent(context.Background(), trigger,
+		&SubscribeOnEventParams{XDelivery: "7"}, Event{Kind: "fed"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rsp.StatusCode)
+	assert.Equal(t, []Event{{Kind: "fed"}}, r.events)
+	assert.Equal(t, "7", r.delivery)
+
+	reason := "moved"
+	rsp, err = client.CancelSubscription(context.Background(), trigger,
+		&CancelSubscriptionParams{Reason: &reason})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rsp.StatusCode)
+	assert.Equal(t, "moved", r.reason)
+
+	// Webhooks go wherever we're told.
+	rsp, err = client.NewPet(context.Background(), server.URL+"/hooks/pets", NewPetJSONBody{Name: "Rex"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rsp.StatusCode)
+	assert.Equal(t, []string{"Rex"}, r.pets)
+
+	// Without the URL, the callback can't be sent.
+	_, err = client.SubscribeOnEvent(context.Background(), runtime.CallbackTrigger{Request: subscribe},
+		&SubscribeOnEventParams{XDelivery: "8"}, Event{Kind: "fed"})
+	assert.Error(t, err)
+}
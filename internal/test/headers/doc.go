@@ -0,0 +1,3 @@
+package headers
+
+//go:generate go run github.com/deepmap/oapi-codegen/cmd/oapi-codegen --package=headers --generate=types,client -o headers.gen.go headers.yaml
@@ -0,0 +1,635 @@
+// Package headers provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version devel DO NOT EDIT.
+// Input hash: sha256:9c3b6dacd04daa85d389ed14a4e7dc0142a3a5841a957f1bdfc99b11cd78fa8e
+package headers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Pet defines model for Pet.
+type Pet struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// Error defines model for Error.
+//
+// An error
+type Error struct {
+	Message *string `json:"message,omitempty"`
+}
+
+// AddPetRequestBody defines body for AddPet for application/json ContentType.
+type AddPetJSONRequestBody Pet
+
+// Validate checks AddPetJSONRequestBody against the constraints of its schema.
+func (v AddPetJSONRequestBody) Validate() error {
+	return openapi_types.ValidateValue(Pet(v))
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(req *http.Request, ctx context.Context) error
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example.
+	Server string
+
+	// HTTP client with any customized settings, such as certificate chains.
+	Client http.Client
+
+	// A callback for modifying requests which are generated before sending over
+	// the network.
+	RequestEditor RequestEditorFn
+
+	// The providers of the credentials of the security schemes, by the names
+	// which the spec gives them, as the ...SecurityScheme constants do. Each
+	// request is authenticated with those which its operation's security
+	// requirements ask for, before the RequestEditor is called.
+	SecurityProviders map[string]runtime.SecurityProvider
+
+	// Optional instrumentation, which is told of each operation the client
+	// sends, for tracing and metrics.
+	Instrumentation runtime.Instrumentation
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// ListPets request
+	ListPets(ctx context.Context) (*http.Response, error)
+
+	// AddPet request with any body
+	AddPetWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error)
+
+	AddPet(ctx context.Context, body Pet) (*http.Response, error)
+
+	// WatchPets request
+	WatchPets(ctx context.Context) (*http.Response, error)
+}
+
+func (c *Client) ListPets(ctx context.Context) (*http.Response, error) {
+	req, err := NewListPetsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[0])
+}
+
+func (c *Client) AddPetWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := NewAddPetRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[1])
+}
+
+func (c *Client) AddPet(ctx context.Context, body Pet) (*http.Response, error) {
+	req, err := NewAddPetRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[1])
+}
+
+func (c *Client) WatchPets(ctx context.Context) (*http.Response, error) {
+	req, err := NewWatchPetsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[2])
+}
+
+// Sends the request for the operation, as the instrumentation observes it.
+func (c *Client) do(ctx context.Context, req *http.Request, op *runtime.OperationInfo) (*http.Response, error) {
+	if c.Instrumentation == nil {
+		return c.send(ctx, req, op)
+	}
+	return runtime.InstrumentRequest(ctx, req, op, c.Instrumentation, c.send)
+}
+
+// Authenticates and edits the request, then sends it.
+func (c *Client) send(ctx context.Context, req *http.Request, op *runtime.OperationInfo) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	err := runtime.ApplySecurity(req, op.Security, c.SecurityProviders)
+	if err != nil {
+		return nil, err
+	}
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(req, ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.Do(req)
+}
+
+// NewListPetsRequest generates requests for ListPets
+func NewListPetsRequest(server string) (*http.Request, error) {
+	var err error
+
+	operationPath := "/pets"
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddPetRequest calls the generic AddPet builder with application/json body
+func NewAddPetRequest(server string, body Pet) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddPetRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAddPetRequestWithBody generates requests for AddPet with any type of body
+func NewAddPetRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	operationPath := "/pets"
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+	return req, nil
+}
+
+// NewWatchPetsRequest generates requests for WatchPets
+func NewWatchPetsRequest(server string) (*http.Request, error) {
+	var err error
+
+	operationPath := "/pets/events"
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses returns a ClientWithResponses with a default Client:
+func NewClientWithResponses(server string) *ClientWithResponses {
+	return &ClientWithResponses{
+		ClientInterface: &Client{
+			Client: http.Client{},
+			Server: server,
+		},
+	}
+}
+
+// NewClientWithResponsesAndRequestEditorFunc takes in a RequestEditorFn callback function and returns a ClientWithResponses with a default Client:
+func NewClientWithResponsesAndRequestEditorFunc(server string, reqEditorFn RequestEditorFn) *ClientWithResponses {
+	return &ClientWithResponses{
+		ClientInterface: &Client{
+			Client:        http.Client{},
+			Server:        server,
+			RequestEditor: reqEditorFn,
+		},
+	}
+}
+
+// NewClientWithResponsesAndSecurityProviders returns a ClientWithResponses with a default Client, which authenticates requests with the given providers of security schemes, by name:
+func NewClientWithResponsesAndSecurityProviders(server string, providers map[string]runtime.SecurityProvider) *ClientWithResponses {
+	return &ClientWithResponses{
+		ClientInterface: &Client{
+			Client:            http.Client{},
+			Server:            server,
+			SecurityProviders: providers,
+		},
+	}
+}
+
+type listPetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Pet
+	JSONDefault  *struct {
+		Message *string `json:"message,omitempty"`
+	}
+	Headers200     *ListPets200ResponseHeaders
+	HeadersDefault *ListPetsDefaultResponseHeaders
+}
+
+// ListPets200ResponseHeaders holds the headers of the 200 response to ListPets.
+type ListPets200ResponseHeaders struct {
+	ETag                *string
+	LastModified        *time.Time
+	XNextPage           *[]string
+	XRateLimitRemaining *int
+}
+
+// ListPetsDefaultResponseHeaders holds the headers of the default response to ListPets.
+type ListPetsDefaultResponseHeaders struct {
+	RetryAfter *int
+}
+
+// Status returns HTTPResponse.Status
+func (r listPetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r listPetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type addPetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	Headers201   *AddPet201ResponseHeaders
+}
+
+// AddPet201ResponseHeaders holds the headers of the 201 response to AddPet.
+type AddPet201ResponseHeaders struct {
+	Location *string
+}
+
+// Status returns HTTPResponse.Status
+func (r addPetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r addPetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type watchPetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	SSE200       *WatchPetsSSE200Stream
+	Headers200   *WatchPets200ResponseHeaders
+}
+
+// WatchPets200ResponseHeaders holds the headers of the 200 response to WatchPets.
+type WatchPets200ResponseHeaders struct {
+	XStreamId *string
+}
+
+// Status returns HTTPResponse.Status
+func (r watchPetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r watchPetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ListPetsWithResponse request returning *ListPetsResponse
+func (c *ClientWithResponses) ListPetsWithResponse(ctx context.Context) (*listPetsResponse, error) {
+	rsp, err := c.ListPets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ParselistPetsResponse(rsp)
+}
+
+// AddPetWithBodyWithResponse request with arbitrary body returning *AddPetResponse
+func (c *ClientWithResponses) AddPetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*addPetResponse, error) {
+	rsp, err := c.AddPetWithBody(ctx, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	return ParseaddPetResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddPetWithResponse(ctx context.Context, body Pet) (*addPetResponse, error) {
+	rsp, err := c.AddPet(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	return ParseaddPetResponse(rsp)
+}
+
+// WatchPetsWithResponse request returning *WatchPetsResponse
+func (c *ClientWithResponses) WatchPetsWithResponse(ctx context.Context) (*watchPetsResponse, error) {
+	rsp, err := c.WatchPets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ParsewatchPetsResponse(rsp)
+}
+
+// ParselistPetsResponse parses an HTTP response from a ListPetsWithResponse call
+func ParselistPetsResponse(rsp *http.Response) (*listPetsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &listPetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	if err := decodeListPetsResponseHeaders(response); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		response.JSON200 = &[]Pet{}
+		if err := json.Unmarshal(bodyBytes, response.JSON200); err != nil {
+			return nil, err
+		}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json"):
+		response.JSONDefault = &struct {
+			Message *string `json:"message,omitempty"`
+		}{}
+		if err := json.Unmarshal(bodyBytes, response.JSONDefault); err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+// ParseaddPetResponse parses an HTTP response from a AddPetWithResponse call
+func ParseaddPetResponse(rsp *http.Response) (*addPetResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &addPetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	if err := decodeAddPetResponseHeaders(response); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case rsp.StatusCode == 201:
+		break // No content-type
+	}
+
+	return response, nil
+}
+
+// ParsewatchPetsResponse parses an HTTP response from a WatchPetsWithResponse call
+func ParsewatchPetsResponse(rsp *http.Response) (*watchPetsResponse, error) {
+	// Streams are handed over unread, and closing them is up to the caller.
+	if strings.Contains(rsp.Header.Get("Content-Type"), "text/event-stream") && rsp.StatusCode == 200 {
+		response := &watchPetsResponse{
+			HTTPResponse: rsp,
+			SSE200:       &WatchPetsSSE200Stream{reader: runtime.NewSSEReader(rsp.Body)},
+		}
+		if err := decodeWatchPetsResponseHeaders(response); err != nil {
+			rsp.Body.Close()
+			return nil, err
+		}
+		return response, nil
+	}
+
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &watchPetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	if err := decodeWatchPetsResponseHeaders(response); err != nil {
+		return nil, err
+	}
+
+	switch {
+	}
+
+	return response, nil
+}
+
+// decodeListPetsResponseHeaders decodes the headers which the spec declares for
+// the status code of the response.
+func decodeListPetsResponseHeaders(response *listPetsResponse) error {
+	rsp := response.HTTPResponse
+	switch rsp.StatusCode {
+	case 200:
+		headers := &ListPets200ResponseHeaders{}
+		if value := rsp.Header.Get("ETag"); value != "" {
+			var eTag string
+			if err := runtime.BindStyledParameter("simple", false, "ETag", value, &eTag); err != nil {
+				return fmt.Errorf("invalid format for header ETag: %s", err)
+			}
+			headers.ETag = &eTag
+		}
+		if value := rsp.Header.Get("Last-Modified"); value != "" {
+			var lastModified time.Time
+			if err := runtime.BindStyledParameter("simple", false, "Last-Modified", value, &lastModified); err != nil {
+				return fmt.Errorf("invalid format for header Last-Modified: %s", err)
+			}
+			headers.LastModified = &lastModified
+		}
+		if value := rsp.Header.Get("X-Next-Page"); value != "" {
+			var xNextPage []string
+			if err := runtime.BindStyledParameter("simple", false, "X-Next-Page", value, &xNextPage); err != nil {
+				return fmt.Errorf("invalid format for header X-Next-Page: %s", err)
+			}
+			headers.XNextPage = &xNextPage
+		}
+		if value := rsp.Header.Get("X-Rate-Limit-Remaining"); value != "" {
+			var xRateLimitRemaining int
+			if err := runtime.BindStyledParameter("simple", false, "X-Rate-Limit-Remaining", value, &xRateLimitRemaining); err != nil {
+				return fmt.Errorf("invalid format for header X-Rate-Limit-Remaining: %s", err)
+			}
+			headers.XRateLimitRemaining = &xRateLimitRemaining
+		}
+		response.Headers200 = headers
+	default:
+		headers := &ListPetsDefaultResponseHeaders{}
+		if value := rsp.Header.Get("Retry-After"); value != "" {
+			var retryAfter int
+			if err := runtime.BindStyledParameter("simple", false, "Retry-After", value, &retryAfter); err != nil {
+				return fmt.Errorf("invalid format for header Retry-After: %s", err)
+			}
+			headers.RetryAfter = &retryAfter
+		}
+		response.HeadersDefault = headers
+	}
+	return nil
+}
+
+// decodeAddPetResponseHeaders decodes the headers which the spec declares for
+// the status code of the response.
+func decodeAddPetResponseHeaders(response *addPetResponse) error {
+	rsp := response.HTTPResponse
+	switch rsp.StatusCode {
+	case 201:
+		headers := &AddPet201ResponseHeaders{}
+		if value := rsp.Header.Get("Location"); value != "" {
+			var location string
+			if err := runtime.BindStyledParameter("simple", false, "Location", value, &location); err != nil {
+				return fmt.Errorf("invalid format for header Location: %s", err)
+			}
+			headers.Location = &location
+		}
+		response.Headers201 = headers
+	}
+	return nil
+}
+
+// decodeWatchPetsResponseHeaders decodes the headers which the spec declares for
+// the status code of the response.
+func decodeWatchPetsResponseHeaders(response *watchPetsResponse) error {
+	rsp := response.HTTPResponse
+	switch rsp.StatusCode {
+	case 200:
+		headers := &WatchPets200ResponseHeaders{}
+		if value := rsp.Header.Get("X-Stream-Id"); value != "" {
+			var xStreamId string
+			if err := runtime.BindStyledParameter("simple", false, "X-Stream-Id", value, &xStreamId); err != nil {
+				return fmt.Errorf("invalid format for header X-Stream-Id: %s", err)
+			}
+			headers.XStreamId = &xStreamId
+		}
+		response.Headers200 = headers
+	}
+	return nil
+}
+
+// WatchPetsSSE200Stream iterates over the events of the text/event-stream response to WatchPets,
+// as they arrive.
+type WatchPetsSSE200Stream struct {
+	reader *runtime.SSEReader
+	event  Pet
+	err    error
+}
+
+// Next reads the next event, which Event then returns. It returns false at the end
+// of the stream, or on an error, which Err then returns.
+func (s *WatchPetsSSE200Stream) Next() bool {
+	if s.err != nil || !s.reader.Next() {
+		return false
+	}
+	var event Pet
+	if err := json.Unmarshal(s.reader.Event().Data, &event); err != nil {
+		s.err = err
+		return false
+	}
+	s.event = event
+	return true
+}
+
+// Event returns the data of the event which Next read.
+func (s *WatchPetsSSE200Stream) Event() Pet {
+	return s.event
+}
+
+// SSE returns the event which Next read, as it was sent, with its ID and type.
+func (s *WatchPetsSSE200Stream) SSE() runtime.SSEEvent {
+	return s.reader.Event()
+}
+
+// Err returns the error which stopped Next, if any.
+func (s *WatchPetsSSE200Stream) Err() error {
+	if s.err != nil {
+		return s.err
+	}
+	return s.reader.Err()
+}
+
+// Close closes the response body.
+func (s *WatchPetsSSE200Stream) Close() error {
+	return s.reader.Close()
+}
+
+// Operations describes each of the operations of the API, as the spec does, in
+// the order of their paths and methods.
+var Operations = []runtime.OperationInfo{
+	{
+		OperationID: "listPets",
+		Method:      "GET",
+		Path:        "/pets",
+	},
+	{
+		OperationID: "addPet",
+		Method:      "POST",
+		Path:        "/pets",
+	},
+	{
+		OperationID: "watchPets",
+		Method:      "GET",
+		Path:        "/pets/events",
+	},
+}
+
+// OperationsByRoute indexes Operations by method and path, eg "GET /pets/{id}",
+// as runtime.RouteKey makes them.
+var OperationsByRoute = map[string]*runtime.OperationInfo{
+	"GET /pets":        &Operations[0],
+	"POST /pets":       &Operations[1],
+	"GET /pets/events": &Operations[2],
+}
+
+// OperationsByID indexes Operations by operationId. Operations without one,
+// which are named by x-go-name, are only in OperationsByRoute.
+var OperationsByID = map[string]*runtime.OperationInfo{
+	"listPets":  &Operations[0],
+	"addPet":    &Operations[1],
+	"watchPets": &Operations[2],
+}
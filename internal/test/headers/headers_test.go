@@ -0,0 +1,106 @@
+package headers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newServer(status int, headers map[string]string, contentType string, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for name, value := range headers {
+			w.Header().Set(name, value)
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+}
+
+func TestResponseHeaders(t *testing.T) {
+	server := newServer(http.StatusOK, map[string]string{
+		"X-Rate-Limit-Remaining": "42",
+		"X-Next-Page":            "cursor,abc",
+		"Last-Modified":          "2019-10-21T12:30:00Z",
+		"ETag":                   `"v1"`,
+	}, "application/json", `[{"name": "Rex"}]`)
+	defer server.Close()
+
+	client := NewClientWithResponses(server.URL)
+	rsp, err := client.ListPetsWithResponse(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, rsp.JSON200)
+	assert.Nil(t, rsp.HeadersDefault)
+
+	headers := rsp.Headers200
+	require.NotNil(t, headers)
+	assert.Equal(t, 42, *headers.XRateLimitRemaining)
+	assert.Equal(t, []string{"cursor", "abc"}, *headers.XNextPage)
+	assert.Equal(t, time.Date(2019, 10, 21, 12, 30, 0, 0, time.UTC), *headers.LastModified)
+	assert.Equal(t, `"v1"`, *headers.ETag)
+}
+
+func TestMissingResponseHeaders(t *testing.T) {
+	server := newServer(http.StatusOK, nil, "application/json", `[]`)
+	defer server.Close()
+
+	client := NewClientWithResponses(server.URL)
+	rsp, err := client.ListPetsWithResponse(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, rsp.Headers200)
+	assert.Nil(t, rsp.Headers200.XRateLimitRemaining)
+	assert.Nil(t, rsp.Headers200.ETag)
+}
+
+func TestDefaultResponseHeaders(t *testing.T) {
+	server := newServer(http.StatusServiceUnavailable, map[string]string{"Retry-After": "30"},
+		"application/json", `{"message": "busy"}`)
+	defer server.Close()
+
+	client := NewClientWithResponses(server.URL)
+	rsp, err := client.ListPetsWithResponse(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, rsp.Headers200)
+	require.NotNil(t, rsp.HeadersDefault)
+	assert.Equal(t, 30, *rsp.HeadersDefault.RetryAfter)
+}
+
+func TestReferencedResponseHeader(t *testing.T) {
+	server := newServer(http.StatusCreated, map[string]string{"Location": "/pets/1"}, "text/plain", "")
+	defer server.Close()
+
+	client := NewClientWithResponses(server.URL)
+	rsp, err := client.AddPetWithResponse(context.Background(), Pet{Name: "Rex"})
+	require.NoError(t, err)
+	require.NotNil(t, rsp.Headers201)
+	assert.Equal(t, "/pets/1", *rsp.Headers201.Location)
+}
+
+func TestStreamingResponseHeaders(t *testing.T) {
+	server := newServer(http.StatusOK, map[string]string{"X-Stream-Id": "s1"}, "text/event-stream", "")
+	defer server.Close()
+
+	client := NewClientWithResponses(server.URL)
+	rsp, err := client.WatchPetsWithResponse(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, rsp.SSE200)
+	defer rsp.SSE200.Close()
+	require.NotNil(t, rsp.Headers200)
+	assert.Equal(t, "s1", *rsp.Headers200.XStreamId)
+}
+
+func TestInvalidResponseHeader(t *testing.T) {
+	server := newServer(http.StatusOK, map[string]string{"X-Rate-Limit-Remaining": "lots"}, "application/json", `[]`)
+	defer server.Close()
+
+	client := NewClientWithResponses(server.URL)
+	_, err := client.ListPetsWithResponse(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "X-Rate-Limit-Remaining")
+}
@@ -0,0 +1,3 @@
+package instrumentation
+
+//go:generate go run github.com/deepmap/oapi-codegen/cmd/oapi-codegen --package=instrumentation --generate=types,client,server -o instrumentation.gen.go instrumentation.yaml
@@ -0,0 +1,441 @@
+// Package instrumentation provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version devel DO NOT EDIT.
+// Input hash: sha256:8ee101b70874aa7f55135a04aab64cc865b7316300b41d930fd4972e290b8b7d
+package instrumentation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+	"github.com/labstack/echo/v4"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Pet defines model for Pet.
+type Pet struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// AddPetRequestBody defines body for AddPet for application/json ContentType.
+type AddPetJSONRequestBody Pet
+
+// Validate checks AddPetJSONRequestBody against the constraints of its schema.
+func (v AddPetJSONRequestBody) Validate() error {
+	return openapi_types.ValidateValue(Pet(v))
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(req *http.Request, ctx context.Context) error
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example.
+	Server string
+
+	// HTTP client with any customized settings, such as certificate chains.
+	Client http.Client
+
+	// A callback for modifying requests which are generated before sending over
+	// the network.
+	RequestEditor RequestEditorFn
+
+	// The providers of the credentials of the security schemes, by the names
+	// which the spec gives them, as the ...SecurityScheme constants do. Each
+	// request is authenticated with those which its operation's security
+	// requirements ask for, before the RequestEditor is called.
+	SecurityProviders map[string]runtime.SecurityProvider
+
+	// Optional instrumentation, which is told of each operation the client
+	// sends, for tracing and metrics.
+	Instrumentation runtime.Instrumentation
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// AddPet request with any body
+	AddPetWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error)
+
+	AddPet(ctx context.Context, body Pet) (*http.Response, error)
+
+	// GetPet request
+	GetPet(ctx context.Context, id string) (*http.Response, error)
+}
+
+func (c *Client) AddPetWithBody(ctx context.Context, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := NewAddPetRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[0])
+}
+
+func (c *Client) AddPet(ctx context.Context, body Pet) (*http.Response, error) {
+	req, err := NewAddPetRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[0])
+}
+
+func (c *Client) GetPet(ctx context.Context, id string) (*http.Response, error) {
+	req, err := NewGetPetRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[1])
+}
+
+// Sends the request for the operation, as the instrumentation observes it.
+func (c *Client) do(ctx context.Context, req *http.Request, op *runtime.OperationInfo) (*http.Response, error) {
+	if c.Instrumentation == nil {
+		return c.send(ctx, req, op)
+	}
+	return runtime.InstrumentRequest(ctx, req, op, c.Instrumentation, c.send)
+}
+
+// Authenticates and edits the request, then sends it.
+func (c *Client) send(ctx context.Context, req *http.Request, op *runtime.OperationInfo) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	err := runtime.ApplySecurity(req, op.Security, c.SecurityProviders)
+	if err != nil {
+		return nil, err
+	}
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(req, ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.Do(req)
+}
+
+// NewAddPetRequest calls the generic AddPet builder with application/json body
+func NewAddPetRequest(server string, body Pet) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddPetRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAddPetRequestWithBody generates requests for AddPet with any type of body
+func NewAddPetRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	operationPath := "/pets"
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+	return req, nil
+}
+
+// NewGetPetRequest generates requests for GetPet
+func NewGetPetRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{Location: runtime.ParamLocationPath})
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pets/%s", pathParam0)
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses returns a ClientWithResponses with a default Client:
+func NewClientWithResponses(server string) *ClientWithResponses {
+	return &ClientWithResponses{
+		ClientInterface: &Client{
+			Client: http.Client{},
+			Server: server,
+		},
+	}
+}
+
+// NewClientWithResponsesAndRequestEditorFunc takes in a RequestEditorFn callback function and returns a ClientWithResponses with a default Client:
+func NewClientWithResponsesAndRequestEditorFunc(server string, reqEditorFn RequestEditorFn) *ClientWithResponses {
+	return &ClientWithResponses{
+		ClientInterface: &Client{
+			Client:        http.Client{},
+			Server:        server,
+			RequestEditor: reqEditorFn,
+		},
+	}
+}
+
+// NewClientWithResponsesAndSecurityProviders returns a ClientWithResponses with a default Client, which authenticates requests with the given providers of security schemes, by name:
+func NewClientWithResponsesAndSecurityProviders(server string, providers map[string]runtime.SecurityProvider) *ClientWithResponses {
+	return &ClientWithResponses{
+		ClientInterface: &Client{
+			Client:            http.Client{},
+			Server:            server,
+			SecurityProviders: providers,
+		},
+	}
+}
+
+type addPetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r addPetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r addPetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type getPetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Pet
+}
+
+// Status returns HTTPResponse.Status
+func (r getPetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r getPetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// AddPetWithBodyWithResponse request with arbitrary body returning *AddPetResponse
+func (c *ClientWithResponses) AddPetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader) (*addPetResponse, error) {
+	rsp, err := c.AddPetWithBody(ctx, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	return ParseaddPetResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddPetWithResponse(ctx context.Context, body Pet) (*addPetResponse, error) {
+	rsp, err := c.AddPet(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	return ParseaddPetResponse(rsp)
+}
+
+// GetPetWithResponse request returning *GetPetResponse
+func (c *ClientWithResponses) GetPetWithResponse(ctx context.Context, id string) (*getPetResponse, error) {
+	rsp, err := c.GetPet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return ParsegetPetResponse(rsp)
+}
+
+// ParseaddPetResponse parses an HTTP response from a AddPetWithResponse call
+func ParseaddPetResponse(rsp *http.Response) (*addPetResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &addPetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case rsp.StatusCode == 201:
+		break // No content-type
+	}
+
+	return response, nil
+}
+
+// ParsegetPetResponse parses an HTTP response from a GetPetWithResponse call
+func ParsegetPetResponse(rsp *http.Response) (*getPetResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &getPetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		response.JSON200 = &Pet{}
+		if err := json.Unmarshal(bodyBytes, response.JSON200); err != nil {
+			return nil, err
+		}
+	case rsp.StatusCode == 404:
+		break // No content-type
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// (POST /pets)
+	AddPet(ctx echo.Context) error
+	// (GET /pets/{id})
+	GetPet(ctx echo.Context, id string) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// AddPet converts echo context to params.
+func (w *ServerInterfaceWrapper) AddPet(ctx echo.Context) error {
+	runtime.SetOperationInfo(ctx, &Operations[0])
+
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.AddPet(ctx)
+	return err
+}
+
+// GetPet converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPet(ctx echo.Context) error {
+	runtime.SetOperationInfo(ctx, &Operations[1])
+
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	{
+		value, err := runtime.StyledParameterValue("simple", false, "id", runtime.PathParam(ctx, "id"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		}
+		id = value
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetPet(ctx, id)
+	return err
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router runtime.EchoRouter, si ServerInterface) {
+	registerHandlers(router, si, "", nil)
+}
+
+// RegisterHandlersWithBaseURL adds each server route to the EchoRouter, under
+// the path of baseURL, which is typically the URL of one of the servers in the
+// spec. The routes of https://example.com/v2 are under /v2, for example.
+func RegisterHandlersWithBaseURL(router runtime.EchoRouter, si ServerInterface, baseURL string) error {
+	basePath, err := runtime.BaseURLPath(baseURL)
+	if err != nil {
+		return err
+	}
+	registerHandlers(router, si, basePath, nil)
+	return nil
+}
+
+// RegisterInstrumentedHandlers adds each server route to the EchoRouter under
+// the path of baseURL, as RegisterHandlersWithBaseURL does, and tells
+// instrumentation of each request which the routes handle, for tracing and
+// metrics.
+func RegisterInstrumentedHandlers(router runtime.EchoRouter, si ServerInterface, baseURL string, instrumentation runtime.Instrumentation) error {
+	basePath, err := runtime.BaseURLPath(baseURL)
+	if err != nil {
+		return err
+	}
+	registerHandlers(router, si, basePath, instrumentation)
+	return nil
+}
+
+func registerHandlers(router runtime.EchoRouter, si ServerInterface, basePath string, instrumentation runtime.Instrumentation) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.POST(basePath+"/pets", runtime.InstrumentHandler(wrapper.AddPet, &Operations[0], instrumentation))
+	router.GET(basePath+"/pets/:id", runtime.InstrumentHandler(wrapper.GetPet, &Operations[1], instrumentation))
+
+}
+
+// Operations describes each of the operations of the API, as the spec does, in
+// the order of their paths and methods.
+var Operations = []runtime.OperationInfo{
+	{
+		OperationID: "addPet",
+		Method:      "POST",
+		Path:        "/pets",
+	},
+	{
+		OperationID: "getPet",
+		Method:      "GET",
+		Path:        "/pets/{id}",
+	},
+}
+
+// OperationsByRoute indexes Operations by method and path, eg "GET /pets/{id}",
+// as runtime.RouteKey makes them.
+var OperationsByRoute = map[string]*runtime.OperationInfo{
+	"POST /pets":     &Operations[0],
+	"GET /pets/{id}": &Operations[1],
+}
+
+// OperationsByID indexes Operations by operationId. Operations without one,
+// which are named by x-go-name, are only in OperationsByRoute.
+var OperationsByID = map[string]*runtime.OperationInfo{
+	"addPet": &Operations[0],
+	"getPet": &Operations[1],
+}
@@ -0,0 +1,156 @@
+package instrumentation
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	"github.com/deepmap/oapi-codegen/pkg/testutil"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type server struct{}
+
+func (s *server) AddPet(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusCreated)
+}
+
+func (s *server) GetPet(ctx echo.Context, id string) error {
+	if id != "1" {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	// Handlers are given the context of the operation, for child spans.
+	if ctx.Request().Context().Value(traceKey{}) == nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "no trace in context")
+	}
+	return ctx.JSON(http.StatusOK, Pet{Name: "Fido"})
+}
+
+type traceKey struct{}
+
+// Propagates a trace from the client to the server in a header, as an
+// OpenTelemetry propagator would, recording the operations.
+type tracing struct {
+	*testutil.OperationRecorder
+}
+
+func (t tracing) StartOperation(ctx context.Context, kind runtime.OperationKind, op *runtime.OperationInfo,
+	req *http.Request) (context.Context, runtime.EndOperationFunc) {
+	if kind == runtime.ClientOperation {
+		req.Header.Set("Traceparent", "trace-"+op.OperationID)
+	}
+	ctx, end := t.OperationRecorder.StartOperation(ctx, kind, op, req)
+	return context.WithValue(ctx, traceKey{}, req.Header.Get("Traceparent")), end
+}
+
+func TestInstrumentation(t *testing.T) {
+	serverRecorder := tracing{testutil.NewOperationRecorder()}
+	e := echo.New()
+	require.NoError(t, RegisterInstrumentedHandlers(e, &server{}, "https://example.com/api", serverRecorder))
+	ts := httptest.NewServer(e)
+	defer ts.Close()
+
+	clientRecorder := tracing{testutil.NewOperationRecorder()}
+	client := &Client{Server: ts.URL + "/api", Instrumentation: clientRecorder}
+	ctx := context.Background()
+
+	rsp, err := client.GetPet(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rsp.StatusCode)
+	rsp, err = client.GetPet(ctx, "2")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rsp.StatusCode)
+	rsp, err = client.AddPetWithBody(ctx, "application/json", strings.NewReader(`{"name": "Rex"}`))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rsp.StatusCode)
+
+	// Failures to send are recorded too.
+	broken := &Client{Server: "http://127.0.0.1:0", Instrumentation: clientRecorder}
+	_, err = broken.GetPet(ctx, "1")
+	assert.Error(t, err)
+
+	clientOps := clientRecorder.Operations()
+	require.Len(t, clientOps, 4)
+	for i, expected := range []struct {
+		id, method, route string
+		status            int
+	}{
+		{"getPet", "GET", "/pets/{id}", http.StatusOK},
+		{"getPet", "GET", "/pets/{id}", http.StatusNotFound},
+		{"addPet", "POST", "/pets", http.StatusCreated},
+		{"getPet", "GET", "/pets/{id}", 0},
+	} {
+		op := clientOps[i]
+		assert.Equal(t, runtime.ClientOperation, op.Kind)
+		assert.Equal(t, expected.id, op.OperationID)
+		assert.Equal(t, expected.method, op.Method)
+		// The route is the path template, not the URL.
+		assert.Equal(t, expected.route, op.Route)
+		assert.Equal(t, expected.status, op.StatusCode)
+		assert.True(t, op.Ended)
+		assert.True(t, op.Duration > 0)
+	}
+	assert.Error(t, clientOps[3].Err)
+
+	serverOps := serverRecorder.Operations()
+	require.Len(t, serverOps, 3)
+	for i, expected := range []struct {
+		id, route string
+		status    int
+	}{
+		{"getPet", "/pets/{id}", http.StatusOK},
+		{"getPet", "/pets/{id}", http.StatusNotFound},
+		{"addPet", "/pets", http.StatusCreated},
+	} {
+		op := serverOps[i]
+		assert.Equal(t, runtime.ServerOperation, op.Kind)
+		assert.Equal(t, expected.id, op.OperationID)
+		assert.Equal(t, expected.route, op.Route)
+		assert.Equal(t, expected.status, op.StatusCode)
+		assert.Equal(t, "trace-"+expected.id, op.Header.Get("Traceparent"))
+		assert.True(t, op.Ended)
+	}
+	assert.Error(t, serverOps[1].Err)
+}
+
+// Responds to every request without going anywhere.
+type roundTripper struct{}
+
+func (roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader("")), Request: req}, nil
+}
+
+// Without instrumentation, the client costs no more than sending the request
+// it builds by hand.
+func TestInstrumentationDisabled(t *testing.T) {
+	client := &Client{Server: "https://example.com/api", Client: http.Client{Transport: roundTripper{}}}
+	ctx := context.Background()
+
+	generated := testing.AllocsPerRun(100, func() {
+		_, _ = client.GetPet(ctx, "1")
+	})
+	byHand := testing.AllocsPerRun(100, func() {
+		req, _ := NewGetPetRequest(client.Server, "1")
+		_, _ = client.Client.Do(req.WithContext(ctx))
+	})
+	assert.Equal(t, byHand, generated)
+
+	// Nor do the routes of the server.
+	e := echo.New()
+	RegisterHandlers(e, &server{})
+	plain := testing.AllocsPerRun(100, func() {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/pets", nil))
+	})
+	e = echo.New()
+	require.NoError(t, RegisterInstrumentedHandlers(e, &server{}, "", nil))
+	disabled := testing.AllocsPerRun(100, func() {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/pets", nil))
+	})
+	assert.Equal(t, plain, disabled)
+}
@@ -0,0 +1,3 @@
+package operations
+
+//go:generate go run github.com/deepmap/oapi-codegen/cmd/oapi-codegen --package=operations --generate=types,server -o operations.gen.go operations.yaml
@@ -0,0 +1,217 @@
+// Package operations provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version devel DO NOT EDIT.
+// Input hash: sha256:ac83c95488144cd2ad13cda4c648d2aacc994077388f701e3992609a737a2df7
+package operations
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// (DELETE /owners)
+	DeleteOwners(ctx echo.Context) error
+	// (GET /owners)
+	ListOwners(ctx echo.Context) error
+	// (GET /pets)
+	ListPets(ctx echo.Context) error
+	// (POST /pets)
+	AddPet(ctx echo.Context) error
+	// (GET /pets/{id})
+	//
+	// Deprecated: this is marked as deprecated in the spec.
+	GetPet(ctx echo.Context, id string) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// DeleteOwners converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteOwners(ctx echo.Context) error {
+	runtime.SetOperationInfo(ctx, &Operations[0])
+
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DeleteOwners(ctx)
+	return err
+}
+
+// ListOwners converts echo context to params.
+func (w *ServerInterfaceWrapper) ListOwners(ctx echo.Context) error {
+	runtime.SetOperationInfo(ctx, &Operations[1])
+
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListOwners(ctx)
+	return err
+}
+
+// ListPets converts echo context to params.
+func (w *ServerInterfaceWrapper) ListPets(ctx echo.Context) error {
+	runtime.SetOperationInfo(ctx, &Operations[2])
+
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ListPets(ctx)
+	return err
+}
+
+// AddPet converts echo context to params.
+func (w *ServerInterfaceWrapper) AddPet(ctx echo.Context) error {
+	runtime.SetOperationInfo(ctx, &Operations[3])
+
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.AddPet(ctx)
+	return err
+}
+
+// GetPet converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPet(ctx echo.Context) error {
+	runtime.SetOperationInfo(ctx, &Operations[4])
+
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	{
+		value, err := runtime.StyledParameterValue("simple", false, "id", runtime.PathParam(ctx, "id"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		}
+		id = value
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetPet(ctx, id)
+	return err
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router runtime.EchoRouter, si ServerInterface) {
+	registerHandlers(router, si, "", nil)
+}
+
+// RegisterHandlersWithBaseURL adds each server route to the EchoRouter, under
+// the path of baseURL, which is typically the URL of one of the servers in the
+// spec. The routes of https://example.com/v2 are under /v2, for example.
+func RegisterHandlersWithBaseURL(router runtime.EchoRouter, si ServerInterface, baseURL string) error {
+	basePath, err := runtime.BaseURLPath(baseURL)
+	if err != nil {
+		return err
+	}
+	registerHandlers(router, si, basePath, nil)
+	return nil
+}
+
+// RegisterInstrumentedHandlers adds each server route to the EchoRouter under
+// the path of baseURL, as RegisterHandlersWithBaseURL does, and tells
+// instrumentation of each request which the routes handle, for tracing and
+// metrics.
+func RegisterInstrumentedHandlers(router runtime.EchoRouter, si ServerInterface, baseURL string, instrumentation runtime.Instrumentation) error {
+	basePath, err := runtime.BaseURLPath(baseURL)
+	if err != nil {
+		return err
+	}
+	registerHandlers(router, si, basePath, instrumentation)
+	return nil
+}
+
+func registerHandlers(router runtime.EchoRouter, si ServerInterface, basePath string, instrumentation runtime.Instrumentation) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.DELETE(basePath+"/owners", runtime.InstrumentHandler(wrapper.DeleteOwners, &Operations[0], instrumentation))
+	router.GET(basePath+"/owners", runtime.InstrumentHandler(wrapper.ListOwners, &Operations[1], instrumentation))
+	router.GET(basePath+"/pets", runtime.InstrumentHandler(wrapper.ListPets, &Operations[2], instrumentation))
+	router.POST(basePath+"/pets", runtime.InstrumentHandler(wrapper.AddPet, &Operations[3], instrumentation))
+	router.GET(basePath+"/pets/:id", runtime.InstrumentHandler(wrapper.GetPet, &Operations[4], instrumentation))
+
+}
+
+// Operations describes each of the operations of the API, as the spec does, in
+// the order of their paths and methods.
+var Operations = []runtime.OperationInfo{
+	{
+		OperationID: "",
+		Method:      "DELETE",
+		Path:        "/owners",
+		Security: []runtime.SecurityRequirement{
+			{"apiKey": {}},
+		},
+		Extensions: map[string]json.RawMessage{
+			"x-go-name": json.RawMessage("\"deleteOwners\""),
+		},
+	},
+	{
+		OperationID: "",
+		Method:      "GET",
+		Path:        "/owners",
+		Security: []runtime.SecurityRequirement{
+			{"apiKey": {}},
+		},
+		Extensions: map[string]json.RawMessage{
+			"x-go-name": json.RawMessage("\"listOwners\""),
+		},
+	},
+	{
+		OperationID: "listPets",
+		Method:      "GET",
+		Path:        "/pets",
+		Tags:        []string{"pets"},
+		Security: []runtime.SecurityRequirement{
+			{"apiKey": {}},
+		},
+		Extensions: map[string]json.RawMessage{
+			"x-owner":      json.RawMessage("{\"team\":\"pets\"}"),
+			"x-rate-limit": json.RawMessage("100"),
+		},
+	},
+	{
+		OperationID: "addPet",
+		Method:      "POST",
+		Path:        "/pets",
+		Tags:        []string{"pets", "admin"},
+		Security: []runtime.SecurityRequirement{
+			{"apiKey": {}, "oauth": {"pets:write"}},
+			{"basic": {}},
+		},
+	},
+	{
+		OperationID: "getPet",
+		Method:      "GET",
+		Path:        "/pets/{id}",
+		Deprecated:  true,
+	},
+}
+
+// OperationsByRoute indexes Operations by method and path, eg "GET /pets/{id}",
+// as runtime.RouteKey makes them.
+var OperationsByRoute = map[string]*runtime.OperationInfo{
+	"DELETE /owners": &Operations[0],
+	"GET /owners":    &Operations[1],
+	"GET /pets":      &Operations[2],
+	"POST /pets":     &Operations[3],
+	"GET /pets/{id}": &Operations[4],
+}
+
+// OperationsByID indexes Operations by operationId. Operations without one,
+// which are named by x-go-name, are only in OperationsByRoute.
+var OperationsByID = map[string]*runtime.OperationInfo{
+	"listPets": &Operations[2],
+	"addPet":   &Operations[3],
+	"getPet":   &Operations[4],
+}
@@ -0,0 +1,126 @@
+package operations
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+)
+
+func TestOperations(t *testing.T) {
+	listPets := OperationsByID["listPets"]
+	assert.Equal(t, listPets, OperationsByRoute[runtime.RouteKey("GET", "/pets")])
+	assert.Equal(t, []string{"pets"}, listPets.Tags)
+	// The spec's security applies, since the operation has none of its own.
+	assert.Equal(t, []runtime.SecurityRequirement{{"apiKey": {}}}, listPets.Security)
+	assert.False(t, listPets.Deprecated)
+
+	var limit int
+	found, err := listPets.Extension("x-rate-limit", &limit)
+	assert.True(t, found)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, limit)
+	var owner struct{ Team string }
+	found, err = listPets.Extension("x-owner", &owner)
+	assert.True(t, found)
+	assert.NoError(t, err)
+	assert.Equal(t, "pets", owner.Team)
+	found, err = listPets.Extension("x-missing", &owner)
+	assert.False(t, found)
+	assert.NoError(t, err)
+
+	addPet := OperationsByRoute["POST /pets"]
+	assert.Equal(t, "addPet", addPet.OperationID)
+	assert.Equal(t, []string{"pets", "admin"}, addPet.Tags)
+	assert.Equal(t, []runtime.SecurityRequirement{
+		{"apiKey": {}, "oauth": {"pets:write"}},
+		{"basic": {}},
+	}, addPet.Security)
+
+	// security: [] turns the spec's security off.
+	getPet := OperationsByID["getPet"]
+	assert.Equal(t, "/pets/{id}", getPet.Path)
+	assert.Empty(t, getPet.Security)
+	assert.True(t, getPet.Deprecated)
+
+	// Operations without an operationId can only be found by route.
+	listOwners := OperationsByRoute["GET /owners"]
+	assert.Equal(t, "", listOwners.OperationID)
+	assert.Equal(t, "/owners", listOwners.Path)
+	assert.NotNil(t, OperationsByRoute["DELETE /owners"])
+	assert.Len(t, OperationsByID, 3)
+	assert.NotContains(t, OperationsByID, "")
+}
+
+type server struct{}
+
+func (s server) ListPets(ctx echo.Context) error {
+	return ctx.String(http.StatusOK, runtime.GetOperationInfo(ctx).OperationID)
+}
+
+func (s server) AddPet(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (s server) GetPet(ctx echo.Context, id string) error {
+	return ctx.String(http.StatusOK, runtime.GetOperationInfo(ctx).OperationID)
+}
+
+func (s server) ListOwners(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (s server) DeleteOwners(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func TestOperationInfoInContext(t *testing.T) {
+	e := echo.New()
+	var before, after []string
+	e.Use(runtime.OperationInfoMiddleware(Operations, "/v1"))
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			if op := runtime.GetOperationInfo(ctx); op != nil {
+				before = append(before, op.OperationID)
+			}
+			err := next(ctx)
+			after = append(after, runtime.GetOperationInfo(ctx).OperationID)
+			return err
+		}
+	})
+	assert.NoError(t, RegisterHandlersWithBaseURL(e, server{}, "https://example.com/v1"))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/v1/pets/rex", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "getPet", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("POST", "/v1/pets", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	assert.Equal(t, []string{"getPet", "addPet"}, before)
+	assert.Equal(t, []string{"getPet", "addPet"}, after)
+}
+
+func TestOperationInfoWithoutMiddleware(t *testing.T) {
+	e := echo.New()
+	var before []*runtime.OperationInfo
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			before = append(before, runtime.GetOperationInfo(ctx))
+			return next(ctx)
+		}
+	})
+	RegisterHandlers(e, server{})
+
+	// The wrapper stashes the operation once it's called.
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/pets", nil))
+	assert.Equal(t, "listPets", rec.Body.String())
+	assert.Equal(t, []*runtime.OperationInfo{nil}, before)
+}
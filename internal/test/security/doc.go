@@ -0,0 +1,3 @@
+package security
+
+//go:generate go run github.com/deepmap/oapi-codegen/cmd/oapi-codegen --package=security --generate=types,client -o security.gen.go security.yaml
@@ -0,0 +1,714 @@
+// Package security provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version devel DO NOT EDIT.
+// Input hash: sha256:216d6108e91668e0fbe34c5fefe85fd63850894f84d7f0f867c2fa792b1b518d
+package security
+
+import (
+	"context"
+	"fmt"
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	"io/ioutil"
+	"net/http"
+)
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(req *http.Request, ctx context.Context) error
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example.
+	Server string
+
+	// HTTP client with any customized settings, such as certificate chains.
+	Client http.Client
+
+	// A callback for modifying requests which are generated before sending over
+	// the network.
+	RequestEditor RequestEditorFn
+
+	// The providers of the credentials of the security schemes, by the names
+	// which the spec gives them, as the ...SecurityScheme constants do. Each
+	// request is authenticated with those which its operation's security
+	// requirements ask for, before the RequestEditor is called.
+	SecurityProviders map[string]runtime.SecurityProvider
+
+	// Optional instrumentation, which is told of each operation the client
+	// sends, for tracing and metrics.
+	Instrumentation runtime.Instrumentation
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetAdmin request
+	GetAdmin(ctx context.Context) (*http.Response, error)
+
+	// GetMe request
+	GetMe(ctx context.Context) (*http.Response, error)
+
+	// GetOptional request
+	GetOptional(ctx context.Context) (*http.Response, error)
+
+	// ListPets request
+	ListPets(ctx context.Context) (*http.Response, error)
+
+	// AddPet request
+	AddPet(ctx context.Context) (*http.Response, error)
+
+	// GetPet request
+	GetPet(ctx context.Context, id string) (*http.Response, error)
+}
+
+func (c *Client) GetAdmin(ctx context.Context) (*http.Response, error) {
+	req, err := NewGetAdminRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[0])
+}
+
+func (c *Client) GetMe(ctx context.Context) (*http.Response, error) {
+	req, err := NewGetMeRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[1])
+}
+
+func (c *Client) GetOptional(ctx context.Context) (*http.Response, error) {
+	req, err := NewGetOptionalRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[2])
+}
+
+func (c *Client) ListPets(ctx context.Context) (*http.Response, error) {
+	req, err := NewListPetsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[3])
+}
+
+func (c *Client) AddPet(ctx context.Context) (*http.Response, error) {
+	req, err := NewAddPetRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[4])
+}
+
+func (c *Client) GetPet(ctx context.Context, id string) (*http.Response, error) {
+	req, err := NewGetPetRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[5])
+}
+
+// Sends the request for the operation, as the instrumentation observes it.
+func (c *Client) do(ctx context.Context, req *http.Request, op *runtime.OperationInfo) (*http.Response, error) {
+	if c.Instrumentation == nil {
+		return c.send(ctx, req, op)
+	}
+	return runtime.InstrumentRequest(ctx, req, op, c.Instrumentation, c.send)
+}
+
+// Authenticates and edits the request, then sends it.
+func (c *Client) send(ctx context.Context, req *http.Request, op *runtime.OperationInfo) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	err := runtime.ApplySecurity(req, op.Security, c.SecurityProviders)
+	if err != nil {
+		return nil, err
+	}
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(req, ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetAdminRequest generates requests for GetAdmin
+func NewGetAdminRequest(server string) (*http.Request, error) {
+	var err error
+
+	operationPath := "/admin"
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetMeRequest generates requests for GetMe
+func NewGetMeRequest(server string) (*http.Request, error) {
+	var err error
+
+	operationPath := "/me"
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetOptionalRequest generates requests for GetOptional
+func NewGetOptionalRequest(server string) (*http.Request, error) {
+	var err error
+
+	operationPath := "/optional"
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListPetsRequest generates requests for ListPets
+func NewListPetsRequest(server string) (*http.Request, error) {
+	var err error
+
+	operationPath := "/pets"
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddPetRequest generates requests for AddPet
+func NewAddPetRequest(server string) (*http.Request, error) {
+	var err error
+
+	operationPath := "/pets"
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPetRequest generates requests for GetPet
+func NewGetPetRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{Location: runtime.ParamLocationPath})
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/pets/%s", pathParam0)
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses returns a ClientWithResponses with a default Client:
+func NewClientWithResponses(server string) *ClientWithResponses {
+	return &ClientWithResponses{
+		ClientInterface: &Client{
+			Client: http.Client{},
+			Server: server,
+		},
+	}
+}
+
+// NewClientWithResponsesAndRequestEditorFunc takes in a RequestEditorFn callback function and returns a ClientWithResponses with a default Client:
+func NewClientWithResponsesAndRequestEditorFunc(server string, reqEditorFn RequestEditorFn) *ClientWithResponses {
+	return &ClientWithResponses{
+		ClientInterface: &Client{
+			Client:        http.Client{},
+			Server:        server,
+			RequestEditor: reqEditorFn,
+		},
+	}
+}
+
+// NewClientWithResponsesAndSecurityProviders returns a ClientWithResponses with a default Client, which authenticates requests with the given providers of security schemes, by name:
+func NewClientWithResponsesAndSecurityProviders(server string, providers map[string]runtime.SecurityProvider) *ClientWithResponses {
+	return &ClientWithResponses{
+		ClientInterface: &Client{
+			Client:            http.Client{},
+			Server:            server,
+			SecurityProviders: providers,
+		},
+	}
+}
+
+type getAdminResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r getAdminResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r getAdminResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type getMeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r getMeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r getMeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type getOptionalResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r getOptionalResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r getOptionalResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type listPetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r listPetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r listPetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type addPetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r addPetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r addPetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type getPetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r getPetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r getPetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetAdminWithResponse request returning *GetAdminResponse
+func (c *ClientWithResponses) GetAdminWithResponse(ctx context.Context) (*getAdminResponse, error) {
+	rsp, err := c.GetAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ParsegetAdminResponse(rsp)
+}
+
+// GetMeWithResponse request returning *GetMeResponse
+func (c *ClientWithResponses) GetMeWithResponse(ctx context.Context) (*getMeResponse, error) {
+	rsp, err := c.GetMe(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ParsegetMeResponse(rsp)
+}
+
+// GetOptionalWithResponse request returning *GetOptionalResponse
+func (c *ClientWithResponses) GetOptionalWithResponse(ctx context.Context) (*getOptionalResponse, error) {
+	rsp, err := c.GetOptional(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ParsegetOptionalResponse(rsp)
+}
+
+// ListPetsWithResponse request returning *ListPetsResponse
+func (c *ClientWithResponses) ListPetsWithResponse(ctx context.Context) (*listPetsResponse, error) {
+	rsp, err := c.ListPets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ParselistPetsResponse(rsp)
+}
+
+// AddPetWithResponse request returning *AddPetResponse
+func (c *ClientWithResponses) AddPetWithResponse(ctx context.Context) (*addPetResponse, error) {
+	rsp, err := c.AddPet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ParseaddPetResponse(rsp)
+}
+
+// GetPetWithResponse request returning *GetPetResponse
+func (c *ClientWithResponses) GetPetWithResponse(ctx context.Context, id string) (*getPetResponse, error) {
+	rsp, err := c.GetPet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return ParsegetPetResponse(rsp)
+}
+
+// ParsegetAdminResponse parses an HTTP response from a GetAdminWithResponse call
+func ParsegetAdminResponse(rsp *http.Response) (*getAdminResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &getAdminResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case rsp.StatusCode == 204:
+		break // No content-type
+	}
+
+	return response, nil
+}
+
+// ParsegetMeResponse parses an HTTP response from a GetMeWithResponse call
+func ParsegetMeResponse(rsp *http.Response) (*getMeResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &getMeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case rsp.StatusCode == 204:
+		break // No content-type
+	}
+
+	return response, nil
+}
+
+// ParsegetOptionalResponse parses an HTTP response from a GetOptionalWithResponse call
+func ParsegetOptionalResponse(rsp *http.Response) (*getOptionalResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &getOptionalResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case rsp.StatusCode == 204:
+		break // No content-type
+	}
+
+	return response, nil
+}
+
+// ParselistPetsResponse parses an HTTP response from a ListPetsWithResponse call
+func ParselistPetsResponse(rsp *http.Response) (*listPetsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &listPetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case rsp.StatusCode == 204:
+		break // No content-type
+	}
+
+	return response, nil
+}
+
+// ParseaddPetResponse parses an HTTP response from a AddPetWithResponse call
+func ParseaddPetResponse(rsp *http.Response) (*addPetResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &addPetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case rsp.StatusCode == 204:
+		break // No content-type
+	}
+
+	return response, nil
+}
+
+// ParsegetPetResponse parses an HTTP response from a GetPetWithResponse call
+func ParsegetPetResponse(rsp *http.Response) (*getPetResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &getPetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case rsp.StatusCode == 204:
+		break // No content-type
+	}
+
+	return response, nil
+}
+
+// The names of the security schemes of the spec, under which their providers
+// go in Client.SecurityProviders.
+const (
+	ApiKeySecurityScheme    = "apiKey"
+	BasicSecurityScheme     = "basic"
+	BearerSecurityScheme    = "bearer"
+	CookieKeySecurityScheme = "cookieKey"
+	OauthSecurityScheme     = "oauth" // OAuth2, for machines
+	OidcSecurityScheme      = "oidc"
+	QueryKeySecurityScheme  = "queryKey"
+)
+
+// NewApiKeySecurityProvider returns a provider for the apiKey security
+// scheme, which sends the API key in the X-API-Key header.
+func NewApiKeySecurityProvider(key string) *runtime.APIKeyProvider {
+	return &runtime.APIKeyProvider{In: "header", Name: "X-API-Key", Key: key}
+}
+
+// NewBasicSecurityProvider returns a provider for the basic security
+// scheme, which authenticates with HTTP basic authentication.
+func NewBasicSecurityProvider(username, password string) *runtime.BasicAuthProvider {
+	return runtime.NewBasicAuthProvider(username, password)
+}
+
+// NewBearerSecurityProvider returns a provider for the bearer security
+// scheme, which authenticates with a bearer token.
+func NewBearerSecurityProvider(token string) *runtime.BearerTokenProvider {
+	return runtime.NewBearerTokenProvider(token)
+}
+
+// NewCookieKeySecurityProvider returns a provider for the cookieKey security
+// scheme, which sends the API key in the session cookie.
+func NewCookieKeySecurityProvider(key string) *runtime.APIKeyProvider {
+	return &runtime.APIKeyProvider{In: "cookie", Name: "session", Key: key}
+}
+
+// NewOauthSecurityProvider returns a provider for the oauth security
+// scheme, which obtains access tokens with the client credentials flow from
+// https://auth.example.com/token. Its TokenURL may be changed, for other servers.
+func NewOauthSecurityProvider(clientID, clientSecret string) *runtime.OAuth2ClientCredentials {
+	return runtime.NewOAuth2ClientCredentials("https://auth.example.com/token", clientID, clientSecret)
+}
+
+// NewQueryKeySecurityProvider returns a provider for the queryKey security
+// scheme, which sends the API key in the api_key query parameter.
+func NewQueryKeySecurityProvider(key string) *runtime.APIKeyProvider {
+	return &runtime.APIKeyProvider{In: "query", Name: "api_key", Key: key}
+}
+
+// Operations describes each of the operations of the API, as the spec does, in
+// the order of their paths and methods.
+var Operations = []runtime.OperationInfo{
+	{
+		OperationID: "getAdmin",
+		Method:      "GET",
+		Path:        "/admin",
+		Security: []runtime.SecurityRequirement{
+			{"cookieKey": {}, "queryKey": {}},
+		},
+	},
+	{
+		OperationID: "getMe",
+		Method:      "GET",
+		Path:        "/me",
+		Security: []runtime.SecurityRequirement{
+			{"bearer": {}},
+		},
+	},
+	{
+		OperationID: "getOptional",
+		Method:      "GET",
+		Path:        "/optional",
+		Security: []runtime.SecurityRequirement{
+			{"oidc": {}},
+			{},
+		},
+	},
+	{
+		OperationID: "listPets",
+		Method:      "GET",
+		Path:        "/pets",
+		Security: []runtime.SecurityRequirement{
+			{"apiKey": {}},
+		},
+	},
+	{
+		OperationID: "addPet",
+		Method:      "POST",
+		Path:        "/pets",
+		Security: []runtime.SecurityRequirement{
+			{"oauth": {"pets:write"}},
+			{"basic": {}},
+		},
+	},
+	{
+		OperationID: "getPet",
+		Method:      "GET",
+		Path:        "/pets/{id}",
+	},
+}
+
+// OperationsByRoute indexes Operations by method and path, eg "GET /pets/{id}",
+// as runtime.RouteKey makes them.
+var OperationsByRoute = map[string]*runtime.OperationInfo{
+	"GET /admin":     &Operations[0],
+	"GET /me":        &Operations[1],
+	"GET /optional":  &Operations[2],
+	"GET /pets":      &Operations[3],
+	"POST /pets":     &Operations[4],
+	"GET /pets/{id}": &Operations[5],
+}
+
+// OperationsByID indexes Operations by operationId. Operations without one,
+// which are named by x-go-name, are only in OperationsByRoute.
+var OperationsByID = map[string]*runtime.OperationInfo{
+	"getAdmin":    &Operations[0],
+	"getMe":       &Operations[1],
+	"getOptional": &Operations[2],
+	"listPets":    &Operations[3],
+	"addPet":      &Operations[4],
+	"getPet":      &Operations[5],
+}
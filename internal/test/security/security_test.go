@@ -0,0 +1,141 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Records the requests to the API, and issues tokens at /token.
+func testServer(t *testing.T, requests *[]*http.Request) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			id, secret, _ := r.BasicAuth()
+			assert.Equal(t, "client", id)
+			assert.Equal(t, "secret", secret)
+			assert.Equal(t, "pets:write", r.PostFormValue("scope"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": "machine-token",
+				"token_type":   "Bearer",
+				"expires_in":   3600,
+			})
+			return
+		}
+		*requests = append(*requests, r)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+}
+
+func TestSecurityProviders(t *testing.T) {
+	var requests []*http.Request
+	server := testServer(t, &requests)
+	defer server.Close()
+
+	oauth := NewOauthSecurityProvider("client", "secret")
+	assert.Equal(t, "https://auth.example.com/token", oauth.TokenURL)
+	oauth.TokenURL = server.URL + "/token"
+
+	client := &Client{
+		Server: server.URL,
+		SecurityProviders: map[string]runtime.SecurityProvider{
+			ApiKeySecurityScheme:    NewApiKeySecurityProvider("header-key"),
+			QueryKeySecurityScheme:  NewQueryKeySecurityProvider("query-key"),
+			CookieKeySecurityScheme: NewCookieKeySecurityProvider("cookie-key"),
+			BearerSecurityScheme:    NewBearerSecurityProvider("user-token"),
+			OauthSecurityScheme:     oauth,
+		},
+	}
+	ctx := context.Background()
+
+	// The spec's own requirement
+	_, err := client.ListPets(ctx)
+	require.NoError(t, err)
+	// The operation's own requirement
+	_, err = client.GetMe(ctx)
+	require.NoError(t, err)
+	// Both of the schemes of a requirement
+	_, err = client.GetAdmin(ctx)
+	require.NoError(t, err)
+	// The first of the requirements there are providers for
+	_, err = client.AddPet(ctx)
+	require.NoError(t, err)
+	// No security at all
+	_, err = client.GetPet(ctx, "1")
+	require.NoError(t, err)
+	// Optional security
+	_, err = client.GetOptional(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, requests, 6)
+
+	listPets := requests[0]
+	assert.Equal(t, "header-key", listPets.Header.Get("X-API-Key"))
+	assert.Empty(t, listPets.Header.Get("Authorization"))
+	assert.Empty(t, listPets.URL.RawQuery)
+	assert.Empty(t, listPets.Cookies())
+
+	getMe := requests[1]
+	assert.Equal(t, "Bearer user-token", getMe.Header.Get("Authorization"))
+	assert.Empty(t, getMe.Header.Get("X-API-Key"))
+
+	getAdmin := requests[2]
+	assert.Equal(t, "api_key=query-key", getAdmin.URL.RawQuery)
+	cookie, err := getAdmin.Cookie("session")
+	require.NoError(t, err)
+	assert.Equal(t, "cookie-key", cookie.Value)
+	assert.Empty(t, getAdmin.Header.Get("X-API-Key"))
+
+	addPet := requests[3]
+	assert.Equal(t, "Bearer machine-token", addPet.Header.Get("Authorization"))
+
+	for _, r := range requests[4:] {
+		assert.Empty(t, r.Header.Get("Authorization"), r.URL.Path)
+		assert.Empty(t, r.Header.Get("X-API-Key"), r.URL.Path)
+		assert.Empty(t, r.URL.RawQuery, r.URL.Path)
+	}
+}
+
+func TestSecurityProvidersAlternatives(t *testing.T) {
+	var requests []*http.Request
+	server := testServer(t, &requests)
+	defer server.Close()
+
+	// Without an OAuth2 provider, addPet falls back to basic authentication.
+	client := NewClientWithResponsesAndSecurityProviders(server.URL, map[string]runtime.SecurityProvider{
+		BasicSecurityScheme: NewBasicSecurityProvider("user", "password"),
+	})
+	rsp, err := client.AddPetWithResponse(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rsp.StatusCode())
+	require.Len(t, requests, 1)
+	username, password, ok := requests[0].BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "user", username)
+	assert.Equal(t, "password", password)
+
+	// Operations which need a scheme there's no provider for fail, rather
+	// than being sent without credentials.
+	_, err = client.GetMeWithResponse(context.Background())
+	assert.Error(t, err)
+	assert.Len(t, requests, 1)
+
+	// Without any providers, requests are sent as they are, for the
+	// RequestEditor to authenticate.
+	var edited []string
+	plain := &Client{
+		Server: server.URL,
+		RequestEditor: func(req *http.Request, ctx context.Context) error {
+			edited = append(edited, req.URL.Path)
+			return nil
+		},
+	}
+	_, err = plain.GetMe(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/me"}, edited)
+}
@@ -0,0 +1,3 @@
+package servers
+
+//go:generate go run github.com/deepmap/oapi-codegen/cmd/oapi-codegen --package=servers --generate=types,server,spec -o servers.gen.go servers.yaml
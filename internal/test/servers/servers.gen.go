@@ -0,0 +1,229 @@
+// Package servers provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version devel DO NOT EDIT.
+// Input hash: sha256:504727fedb9071b7f5b4e9c4a6ea416448d5dc3981b3fa2a85ac43475837b4b2
+package servers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Server1Region is the region variable of the URL of Server1.
+// Where the data is kept
+type Server1Region string
+
+// The values which Server1Region may take.
+const (
+	Server1RegionEu      Server1Region = "eu"
+	Server1RegionUs      Server1Region = "us"
+	Server1RegionApSouth Server1Region = "ap-south"
+)
+
+// Valid returns whether v is one of the values which the spec allows.
+func (v Server1Region) Valid() bool {
+	switch v {
+	case Server1RegionEu, Server1RegionUs, Server1RegionApSouth:
+		return true
+	}
+	return false
+}
+
+// Server1DefaultURL is the URL of Production, https://{region}.api.example.com/{version},
+// with the default values of its variables.
+const Server1DefaultURL = "https://eu.api.example.com/v1"
+
+// NewServer1URL returns the URL of Production, https://{region}.api.example.com/{version},
+// with the given values of its variables.
+func NewServer1URL(region Server1Region, version string) (string, error) {
+	if !region.Valid() {
+		return "", fmt.Errorf("invalid value for server variable region: %q", region)
+	}
+	return "https://" + string(region) + ".api.example.com/" + version, nil
+}
+
+// Server2URL is the URL of Local development.
+const Server2URL = "http://localhost:8080/api/"
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// (GET /pets/{id})
+	GetPet(ctx echo.Context, id string) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// GetPet converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPet(ctx echo.Context) error {
+	runtime.SetOperationInfo(ctx, &Operations[0])
+
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	{
+		value, err := runtime.StyledParameterValue("simple", false, "id", runtime.PathParam(ctx, "id"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		}
+		id = value
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetPet(ctx, id)
+	return err
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router runtime.EchoRouter, si ServerInterface) {
+	registerHandlers(router, si, "", nil)
+}
+
+// RegisterHandlersWithBaseURL adds each server route to the EchoRouter, under
+// the path of baseURL, which is typically the URL of one of the servers in the
+// spec. The routes of https://example.com/v2 are under /v2, for example.
+func RegisterHandlersWithBaseURL(router runtime.EchoRouter, si ServerInterface, baseURL string) error {
+	basePath, err := runtime.BaseURLPath(baseURL)
+	if err != nil {
+		return err
+	}
+	registerHandlers(router, si, basePath, nil)
+	return nil
+}
+
+// RegisterInstrumentedHandlers adds each server route to the EchoRouter under
+// the path of baseURL, as RegisterHandlersWithBaseURL does, and tells
+// instrumentation of each request which the routes handle, for tracing and
+// metrics.
+func RegisterInstrumentedHandlers(router runtime.EchoRouter, si ServerInterface, baseURL string, instrumentation runtime.Instrumentation) error {
+	basePath, err := runtime.BaseURLPath(baseURL)
+	if err != nil {
+		return err
+	}
+	registerHandlers(router, si, basePath, instrumentation)
+	return nil
+}
+
+func registerHandlers(router runtime.EchoRouter, si ServerInterface, basePath string, instrumentation runtime.Instrumentation) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET(basePath+"/pets/:id", runtime.InstrumentHandler(wrapper.GetPet, &Operations[0], instrumentation))
+
+}
+
+// Operations describes each of the operations of the API, as the spec does, in
+// the order of their paths and methods.
+var Operations = []runtime.OperationInfo{
+	{
+		OperationID: "getPet",
+		Method:      "GET",
+		Path:        "/pets/{id}",
+	},
+}
+
+// OperationsByRoute indexes Operations by method and path, eg "GET /pets/{id}",
+// as runtime.RouteKey makes them.
+var OperationsByRoute = map[string]*runtime.OperationInfo{
+	"GET /pets/{id}": &Operations[0],
+}
+
+// OperationsByID indexes Operations by operationId. Operations without one,
+// which are named by x-go-name, are only in OperationsByRoute.
+var OperationsByID = map[string]*runtime.OperationInfo{
+	"getPet": &Operations[0],
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/2RRzY7UMAx+leo7h6YLHFZ5AyQOK3HgsJpDaL3TaNvEJM4IFOXdkdsVGthcHDvO92M3",
+	"zGnnFClKgWvdIMSXBNcgQTaCwzfKN8oFBhpCinB4GKdxQjdITNFzgMOno2TAXlZFgmWSYltYumZXEg2J",
+	"KXsJKX5Z4LT4RHJ8yn4nURr33BCUQ4FgEP2uKsICg0w/a8i0wEmuZFDmlXZ/iP3N2lUkh3hF7xdtLpxi",
+	"oUPMx+mzhoXKnAPL6SK9ousxKG8elbzmDQ6rCBdnbct0DSn20XMY6ZffeaNxTrttb9PoMP/BPuW01PlI",
+	"DG4+B/9jO1WcWHqjWHe4Z1CFQdXhev5QUpUVF8V78XUTuPP9X/jvK2UaZKVh8eKHUIZXYtFl/N1Pu0O4",
+	"PahJc+/LWbul2W9rKuIep8fJeg72HdFX7RkWutGWeKco6Jf+ZwDmk4mBMAIAAA==",
+}
+
+// The spec is decoded once, by the first call to GetSwagger or GetSwaggerCopy.
+var (
+	swaggerOnce  sync.Once
+	swaggerJSON  []byte
+	swaggerCache *openapi3.Swagger
+	swaggerErr   error
+)
+
+func loadSwagger() {
+	swaggerJSON, swaggerErr = decompressSwagger()
+	if swaggerErr == nil {
+		swaggerCache, swaggerErr = decodeSwagger(swaggerJSON)
+	}
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The spec is decoded on the first call, and the same one is
+// returned from then on, so it must not be modified. Use GetSwaggerCopy for a
+// spec which may be.
+func GetSwagger() (*openapi3.Swagger, error) {
+	swaggerOnce.Do(loadSwagger)
+	return swaggerCache, swaggerErr
+}
+
+// GetSwaggerCopy returns a copy of the spec which GetSwagger returns, which is
+// the caller's own, so may be modified.
+func GetSwaggerCopy() (*openapi3.Swagger, error) {
+	swaggerOnce.Do(loadSwagger)
+	if swaggerErr != nil {
+		return nil, swaggerErr
+	}
+	return decodeSwagger(swaggerJSON)
+}
+
+func decodeSwagger(data []byte) (*openapi3.Swagger, error) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("error loading Swagger: %s", err)
+	}
+	return swagger, nil
+}
+
+func decompressSwagger() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %s", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %s", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %s", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// RegisterSpecHandlers adds routes to the router which serve the spec as JSON
+// and YAML, and as an HTML reference page, at the paths given by opts, such as
+// runtime.DefaultSpecHandlerOptions().
+func RegisterSpecHandlers(router runtime.EchoRouter, opts runtime.SpecHandlerOptions) error {
+	swagger, err := GetSwagger()
+	if err != nil {
+		return err
+	}
+	return runtime.RegisterSpecHandlers(router, swagger, opts)
+}
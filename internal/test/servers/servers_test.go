@@ -0,0 +1,85 @@
+package servers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+)
+
+func TestServerURLs(t *testing.T) {
+	url, err := NewServer1URL(Server1RegionApSouth, "v2")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://ap-south.api.example.com/v2", url)
+
+	_, err = NewServer1URL("mars", "v2")
+	assert.Error(t, err)
+
+	assert.Equal(t, "https://eu.api.example.com/v1", Server1DefaultURL)
+	assert.Equal(t, "http://localhost:8080/api/", Server2URL)
+}
+
+type server struct{}
+
+func (s server) GetPet(ctx echo.Context, id string) error {
+	return ctx.String(http.StatusOK, id)
+}
+
+func TestRegisterHandlersWithBaseURL(t *testing.T) {
+	e := echo.New()
+	url, err := NewServer1URL(Server1RegionEu, "v2")
+	assert.NoError(t, err)
+	assert.NoError(t, RegisterHandlersWithBaseURL(e, server{}, url))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/v2/pets/rex", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "rex", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/pets/rex", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// A trailing slash on the server URL doesn't double up.
+	e = echo.New()
+	assert.NoError(t, RegisterHandlersWithBaseURL(e, server{}, Server2URL))
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/api/pets/rex", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRegisterSpecHandlers(t *testing.T) {
+	first, err := GetSwagger()
+	assert.NoError(t, err)
+	second, err := GetSwagger()
+	assert.NoError(t, err)
+	// The spec is only decoded once.
+	assert.True(t, first == second)
+	// Copies are the caller's own, so changing one doesn't change what
+	// GetSwagger, or the spec handlers, see.
+	copied, err := GetSwaggerCopy()
+	assert.NoError(t, err)
+	assert.True(t, copied != first)
+	assert.NotEmpty(t, copied.Servers)
+	copied.Servers = nil
+	assert.NotEmpty(t, first.Servers)
+
+	e := echo.New()
+	assert.NoError(t, RegisterSpecHandlers(e, runtime.DefaultSpecHandlerOptions()))
+
+	for path, contentType := range map[string]string{
+		"/openapi.json": "application/json",
+		"/openapi.yaml": "application/yaml",
+		"/docs":         "text/html",
+	} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+		assert.Contains(t, rec.Header().Get("Content-Type"), contentType, path)
+		assert.Contains(t, rec.Body.String(), "/pets/{id}", path)
+	}
+}
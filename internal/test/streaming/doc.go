@@ -0,0 +1,3 @@
+package streaming
+
+//go:generate go run github.com/deepmap/oapi-codegen/cmd/oapi-codegen --package=streaming --generate=types,client -o streaming.gen.go streaming.yaml
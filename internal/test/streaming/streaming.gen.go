@@ -0,0 +1,569 @@
+// Package streaming provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version devel DO NOT EDIT.
+// Input hash: sha256:5cc6023c2bba88037712bad72d92821501e869a3cb2bfe19da426f2efd6ceb43
+package streaming
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Error defines model for Error.
+type Error struct {
+	Message string `json:"message" validate:"required"`
+}
+
+// Event defines model for Event.
+type Event struct {
+	Count *int   `json:"count,omitempty" validate:"numeric"`
+	Kind  string `json:"kind" validate:"required"`
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(req *http.Request, ctx context.Context) error
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example.
+	Server string
+
+	// HTTP client with any customized settings, such as certificate chains.
+	Client http.Client
+
+	// A callback for modifying requests which are generated before sending over
+	// the network.
+	RequestEditor RequestEditorFn
+
+	// The providers of the credentials of the security schemes, by the names
+	// which the spec gives them, as the ...SecurityScheme constants do. Each
+	// request is authenticated with those which its operation's security
+	// requirements ask for, before the RequestEditor is called.
+	SecurityProviders map[string]runtime.SecurityProvider
+
+	// Optional instrumentation, which is told of each operation the client
+	// sends, for tracing and metrics.
+	Instrumentation runtime.Instrumentation
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// Download request
+	Download(ctx context.Context) (*http.Response, error)
+
+	// WatchEvents request
+	WatchEvents(ctx context.Context) (*http.Response, error)
+
+	// TailLogs request
+	TailLogs(ctx context.Context) (*http.Response, error)
+}
+
+func (c *Client) Download(ctx context.Context) (*http.Response, error) {
+	req, err := NewDownloadRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[0])
+}
+
+func (c *Client) WatchEvents(ctx context.Context) (*http.Response, error) {
+	req, err := NewWatchEventsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[1])
+}
+
+func (c *Client) TailLogs(ctx context.Context) (*http.Response, error) {
+	req, err := NewTailLogsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, &Operations[2])
+}
+
+// Sends the request for the operation, as the instrumentation observes it.
+func (c *Client) do(ctx context.Context, req *http.Request, op *runtime.OperationInfo) (*http.Response, error) {
+	if c.Instrumentation == nil {
+		return c.send(ctx, req, op)
+	}
+	return runtime.InstrumentRequest(ctx, req, op, c.Instrumentation, c.send)
+}
+
+// Authenticates and edits the request, then sends it.
+func (c *Client) send(ctx context.Context, req *http.Request, op *runtime.OperationInfo) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	err := runtime.ApplySecurity(req, op.Security, c.SecurityProviders)
+	if err != nil {
+		return nil, err
+	}
+	if c.RequestEditor != nil {
+		err = c.RequestEditor(req, ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.Do(req)
+}
+
+// NewDownloadRequest generates requests for Download
+func NewDownloadRequest(server string) (*http.Request, error) {
+	var err error
+
+	operationPath := "/download"
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewWatchEventsRequest generates requests for WatchEvents
+func NewWatchEventsRequest(server string) (*http.Request, error) {
+	var err error
+
+	operationPath := "/events"
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTailLogsRequest generates requests for TailLogs
+func NewTailLogsRequest(server string) (*http.Request, error) {
+	var err error
+
+	operationPath := "/logs"
+
+	queryURL, err := runtime.OperationURL(server, operationPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses returns a ClientWithResponses with a default Client:
+func NewClientWithResponses(server string) *ClientWithResponses {
+	return &ClientWithResponses{
+		ClientInterface: &Client{
+			Client: http.Client{},
+			Server: server,
+		},
+	}
+}
+
+// NewClientWithResponsesAndRequestEditorFunc takes in a RequestEditorFn callback function and returns a ClientWithResponses with a default Client:
+func NewClientWithResponsesAndRequestEditorFunc(server string, reqEditorFn RequestEditorFn) *ClientWithResponses {
+	return &ClientWithResponses{
+		ClientInterface: &Client{
+			Client:        http.Client{},
+			Server:        server,
+			RequestEditor: reqEditorFn,
+		},
+	}
+}
+
+// NewClientWithResponsesAndSecurityProviders returns a ClientWithResponses with a default Client, which authenticates requests with the given providers of security schemes, by name:
+func NewClientWithResponsesAndSecurityProviders(server string, providers map[string]runtime.SecurityProvider) *ClientWithResponses {
+	return &ClientWithResponses{
+		ClientInterface: &Client{
+			Client:            http.Client{},
+			Server:            server,
+			SecurityProviders: providers,
+		},
+	}
+}
+
+type downloadResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	Binary200    io.ReadCloser
+}
+
+// Status returns HTTPResponse.Status
+func (r downloadResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r downloadResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type watchEventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+	SSE200       *WatchEventsSSE200Stream
+}
+
+// Status returns HTTPResponse.Status
+func (r watchEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r watchEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type tailLogsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	NDJSON200    *TailLogsNDJSON200Stream
+	SSE200       *TailLogsSSE200Stream
+}
+
+// Status returns HTTPResponse.Status
+func (r tailLogsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r tailLogsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// DownloadWithResponse request returning *DownloadResponse
+func (c *ClientWithResponses) DownloadWithResponse(ctx context.Context) (*downloadResponse, error) {
+	rsp, err := c.Download(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ParsedownloadResponse(rsp)
+}
+
+// WatchEventsWithResponse request returning *WatchEventsResponse
+func (c *ClientWithResponses) WatchEventsWithResponse(ctx context.Context) (*watchEventsResponse, error) {
+	rsp, err := c.WatchEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ParsewatchEventsResponse(rsp)
+}
+
+// TailLogsWithResponse request returning *TailLogsResponse
+func (c *ClientWithResponses) TailLogsWithResponse(ctx context.Context) (*tailLogsResponse, error) {
+	rsp, err := c.TailLogs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ParsetailLogsResponse(rsp)
+}
+
+// ParsedownloadResponse parses an HTTP response from a DownloadWithResponse call
+func ParsedownloadResponse(rsp *http.Response) (*downloadResponse, error) {
+	// Streams are handed over unread, and closing them is up to the caller.
+	if strings.Contains(rsp.Header.Get("Content-Type"), "application/octet-stream") && rsp.StatusCode == 200 {
+		response := &downloadResponse{
+			HTTPResponse: rsp,
+			Binary200:    rsp.Body,
+		}
+		return response, nil
+	}
+
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &downloadResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	}
+
+	return response, nil
+}
+
+// ParsewatchEventsResponse parses an HTTP response from a WatchEventsWithResponse call
+func ParsewatchEventsResponse(rsp *http.Response) (*watchEventsResponse, error) {
+	// Streams are handed over unread, and closing them is up to the caller.
+	if strings.Contains(rsp.Header.Get("Content-Type"), "text/event-stream") && rsp.StatusCode == 200 {
+		response := &watchEventsResponse{
+			HTTPResponse: rsp,
+			SSE200:       &WatchEventsSSE200Stream{reader: runtime.NewSSEReader(rsp.Body)},
+		}
+		return response, nil
+	}
+
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &watchEventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json"):
+		response.JSONDefault = &Error{}
+		if err := json.Unmarshal(bodyBytes, response.JSONDefault); err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+// ParsetailLogsResponse parses an HTTP response from a TailLogsWithResponse call
+func ParsetailLogsResponse(rsp *http.Response) (*tailLogsResponse, error) {
+	// Streams are handed over unread, and closing them is up to the caller.
+	if strings.Contains(rsp.Header.Get("Content-Type"), "application/x-ndjson") && rsp.StatusCode == 200 {
+		response := &tailLogsResponse{
+			HTTPResponse: rsp,
+			NDJSON200:    &TailLogsNDJSON200Stream{reader: runtime.NewLineReader(rsp.Body)},
+		}
+		return response, nil
+	}
+
+	if strings.Contains(rsp.Header.Get("Content-Type"), "text/event-stream") && rsp.StatusCode == 200 {
+		response := &tailLogsResponse{
+			HTTPResponse: rsp,
+			SSE200:       &TailLogsSSE200Stream{reader: runtime.NewSSEReader(rsp.Body)},
+		}
+		return response, nil
+	}
+
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &tailLogsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	}
+
+	return response, nil
+}
+
+// WatchEventsSSE200Stream iterates over the events of the text/event-stream response to WatchEvents,
+// as they arrive.
+type WatchEventsSSE200Stream struct {
+	reader *runtime.SSEReader
+	event  Event
+	err    error
+}
+
+// Next reads the next event, which Event then returns. It returns false at the end
+// of the stream, or on an error, which Err then returns.
+func (s *WatchEventsSSE200Stream) Next() bool {
+	if s.err != nil || !s.reader.Next() {
+		return false
+	}
+	var event Event
+	if err := json.Unmarshal(s.reader.Event().Data, &event); err != nil {
+		s.err = err
+		return false
+	}
+	s.event = event
+	return true
+}
+
+// Event returns the data of the event which Next read.
+func (s *WatchEventsSSE200Stream) Event() Event {
+	return s.event
+}
+
+// SSE returns the event which Next read, as it was sent, with its ID and type.
+func (s *WatchEventsSSE200Stream) SSE() runtime.SSEEvent {
+	return s.reader.Event()
+}
+
+// Err returns the error which stopped Next, if any.
+func (s *WatchEventsSSE200Stream) Err() error {
+	if s.err != nil {
+		return s.err
+	}
+	return s.reader.Err()
+}
+
+// Close closes the response body.
+func (s *WatchEventsSSE200Stream) Close() error {
+	return s.reader.Close()
+}
+
+// TailLogsNDJSON200Stream iterates over the lines of the application/x-ndjson response to TailLogs,
+// as they arrive.
+type TailLogsNDJSON200Stream struct {
+	reader *runtime.LineReader
+	event  struct {
+		Level   string `json:"level" validate:"required"`
+		Message string `json:"message" validate:"required"`
+	}
+	err error
+}
+
+// Next reads the next line, which Event then returns. It returns false at the end
+// of the stream, or on an error, which Err then returns.
+func (s *TailLogsNDJSON200Stream) Next() bool {
+	if s.err != nil || !s.reader.Next() {
+		return false
+	}
+	var event struct {
+		Level   string `json:"level" validate:"required"`
+		Message string `json:"message" validate:"required"`
+	}
+	if err := json.Unmarshal(s.reader.Line(), &event); err != nil {
+		s.err = err
+		return false
+	}
+	s.event = event
+	return true
+}
+
+// Event returns the line which Next read.
+func (s *TailLogsNDJSON200Stream) Event() struct {
+	Level   string `json:"level" validate:"required"`
+	Message string `json:"message" validate:"required"`
+} {
+	return s.event
+}
+
+// Err returns the error which stopped Next, if any.
+func (s *TailLogsNDJSON200Stream) Err() error {
+	if s.err != nil {
+		return s.err
+	}
+	return s.reader.Err()
+}
+
+// Close closes the response body.
+func (s *TailLogsNDJSON200Stream) Close() error {
+	return s.reader.Close()
+}
+
+// TailLogsSSE200Stream iterates over the events of the text/event-stream response to TailLogs,
+// as they arrive.
+type TailLogsSSE200Stream struct {
+	reader *runtime.SSEReader
+	event  string
+	err    error
+}
+
+// Next reads the next event, which Event then returns. It returns false at the end
+// of the stream, or on an error, which Err then returns.
+func (s *TailLogsSSE200Stream) Next() bool {
+	if s.err != nil || !s.reader.Next() {
+		return false
+	}
+	s.event = string(s.reader.Event().Data)
+	return true
+}
+
+// Event returns the data of the event which Next read.
+func (s *TailLogsSSE200Stream) Event() string {
+	return s.event
+}
+
+// SSE returns the event which Next read, as it was sent, with its ID and type.
+func (s *TailLogsSSE200Stream) SSE() runtime.SSEEvent {
+	return s.reader.Event()
+}
+
+// Err returns the error which stopped Next, if any.
+func (s *TailLogsSSE200Stream) Err() error {
+	if s.err != nil {
+		return s.err
+	}
+	return s.reader.Err()
+}
+
+// Close closes the response body.
+func (s *TailLogsSSE200Stream) Close() error {
+	return s.reader.Close()
+}
+
+// Operations describes each of the operations of the API, as the spec does, in
+// the order of their paths and methods.
+var Operations = []runtime.OperationInfo{
+	{
+		OperationID: "download",
+		Method:      "GET",
+		Path:        "/download",
+	},
+	{
+		OperationID: "watchEvents",
+		Method:      "GET",
+		Path:        "/events",
+	},
+	{
+		OperationID: "tailLogs",
+		Method:      "GET",
+		Path:        "/logs",
+	},
+}
+
+// OperationsByRoute indexes Operations by method and path, eg "GET /pets/{id}",
+// as runtime.RouteKey makes them.
+var OperationsByRoute = map[string]*runtime.OperationInfo{
+	"GET /download": &Operations[0],
+	"GET /events":   &Operations[1],
+	"GET /logs":     &Operations[2],
+}
+
+// OperationsByID indexes Operations by operationId. Operations without one,
+// which are named by x-go-name, are only in OperationsByRoute.
+var OperationsByID = map[string]*runtime.OperationInfo{
+	"download":    &Operations[0],
+	"watchEvents": &Operations[1],
+	"tailLogs":    &Operations[2],
+}
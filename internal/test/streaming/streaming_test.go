@@ -0,0 +1,146 @@
+package streaming
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newServer starts a server which writes each of the chunks to the response,
+// waiting for a value on next before each chunk after the first, so that the
+// tests can tell whether the client reads the response as it arrives.
+func newServer(contentType string, chunks []string, next chan struct{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(http.StatusOK)
+		for i, chunk := range chunks {
+			if i > 0 {
+				<-next
+			}
+			fmt.Fprint(w, chunk)
+			w.(http.Flusher).Flush()
+		}
+	}))
+}
+
+func TestServerSentEvents(t *testing.T) {
+	next := make(chan struct{})
+	server := newServer("text/event-stream", []string{
+		"event: created\nid: 1\ndata: {\"kind\": \"created\", \"count\": 1}\n\n",
+		": keepalive\n\ndata: {\"kind\": \"deleted\"}\n\n",
+	}, next)
+	defer server.Close()
+
+	client := NewClientWithResponses(server.URL)
+	rsp, err := client.WatchEventsWithResponse(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, rsp.SSE200)
+	defer rsp.SSE200.Close()
+	assert.Nil(t, rsp.Body)
+
+	// The first event arrives while the server is still waiting to send the
+	// second.
+	require.True(t, rsp.SSE200.Next())
+	assert.Equal(t, "created", rsp.SSE200.Event().Kind)
+	assert.Equal(t, 1, *rsp.SSE200.Event().Count)
+	assert.Equal(t, "1", rsp.SSE200.SSE().ID)
+	assert.Equal(t, "created", rsp.SSE200.SSE().Event)
+
+	close(next)
+	require.True(t, rsp.SSE200.Next())
+	assert.Equal(t, "deleted", rsp.SSE200.Event().Kind)
+	assert.Nil(t, rsp.SSE200.Event().Count)
+
+	assert.False(t, rsp.SSE200.Next())
+	assert.NoError(t, rsp.SSE200.Err())
+}
+
+func TestNDJSON(t *testing.T) {
+	next := make(chan struct{})
+	server := newServer("application/x-ndjson", []string{
+		"{\"level\": \"info\", \"message\": \"started\"}\n",
+		"{\"level\": \"warn\", \"message\": \"slow\"}\nnot json\n",
+	}, next)
+	defer server.Close()
+
+	client := NewClientWithResponses(server.URL)
+	rsp, err := client.TailLogsWithResponse(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, rsp.NDJSON200)
+	assert.Nil(t, rsp.SSE200)
+	defer rsp.NDJSON200.Close()
+
+	require.True(t, rsp.NDJSON200.Next())
+	assert.Equal(t, "started", rsp.NDJSON200.Event().Message)
+
+	close(next)
+	require.True(t, rsp.NDJSON200.Next())
+	assert.Equal(t, "warn", rsp.NDJSON200.Event().Level)
+
+	// A line which doesn't decode stops the stream.
+	assert.False(t, rsp.NDJSON200.Next())
+	assert.Error(t, rsp.NDJSON200.Err())
+}
+
+func TestStringEvents(t *testing.T) {
+	server := newServer("text/event-stream", []string{"data: plain text\n\n"}, nil)
+	defer server.Close()
+
+	client := NewClientWithResponses(server.URL)
+	rsp, err := client.TailLogsWithResponse(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, rsp.SSE200)
+	assert.Nil(t, rsp.NDJSON200)
+	defer rsp.SSE200.Close()
+
+	require.True(t, rsp.SSE200.Next())
+	assert.Equal(t, "plain text", rsp.SSE200.Event())
+	assert.False(t, rsp.SSE200.Next())
+	assert.NoError(t, rsp.SSE200.Err())
+}
+
+func TestBinary(t *testing.T) {
+	next := make(chan struct{})
+	server := newServer("application/octet-stream", []string{"first", "second"}, next)
+	defer server.Close()
+
+	client := NewClientWithResponses(server.URL)
+	rsp, err := client.DownloadWithResponse(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, rsp.Binary200)
+	defer rsp.Binary200.Close()
+	assert.Nil(t, rsp.Body)
+
+	// The response is handed over before the server has finished it.
+	buf := make([]byte, 5)
+	n, err := rsp.Binary200.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(buf[:n]))
+
+	close(next)
+	rest, err := ioutil.ReadAll(rsp.Binary200)
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(rest))
+}
+
+func TestNonStreamingResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"message": "down"}`)
+	}))
+	defer server.Close()
+
+	client := NewClientWithResponses(server.URL)
+	rsp, err := client.WatchEventsWithResponse(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, rsp.SSE200)
+	require.NotNil(t, rsp.JSONDefault)
+	assert.Equal(t, "down", rsp.JSONDefault.Message)
+}
@@ -0,0 +1,432 @@
+// Package validation provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version devel DO NOT EDIT.
+// Input hash: sha256:d48e42e40bfef1eea2237b3adcd338529efe12962920b391dc3da802060c6c68
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"net/http"
+	"unicode/utf8"
+)
+
+// Grid defines model for Grid.
+type Grid [][]int
+
+// Labels defines model for Labels.
+type Labels struct {
+	AdditionalProperties map[string]Tag `json:"-"`
+}
+
+// Pet defines model for Pet.
+type Pet struct {
+	Blob      []byte  `json:"blob,omitempty"`
+	Kind      *string `json:"kind,omitempty" validate:"oneof=cat dog"`
+	Lookahead *string `json:"lookahead,omitempty"`
+	Name      string  `json:"name" validate:"required,min=1,max=20"`
+	Owner     *struct {
+		Age *int `json:"age,omitempty" validate:"numeric,lte=150"`
+	} `json:"owner,omitempty"`
+	Tags   *[]Tag  `json:"tags,omitempty" validate:"min=1"`
+	Weight float64 `json:"weight" validate:"required,numeric,gt=0.5"`
+}
+
+// Tag defines model for Tag.
+type Tag string
+
+// addPetJSONBody defines parameters for AddPet.
+type addPetJSONBody struct {
+	Note *string `json:"note,omitempty" validate:"max=10"`
+	Pet  Pet     `json:"pet" validate:"required"`
+}
+
+// FindPetsParams defines parameters for FindPets.
+type FindPetsParams struct {
+	Limit *json.Number `schema:"limit,omitempty" validate:"omitempty,numeric"`
+	Tags  *[]string    `schema:"tags,omitempty" validate:"omitempty,max=3"`
+}
+
+// AddPetRequestBody defines body for AddPet for application/json ContentType.
+type AddPetJSONRequestBody addPetJSONBody
+
+// Validate checks AddPetJSONRequestBody against the constraints of its schema.
+func (v AddPetJSONRequestBody) Validate() error {
+	return openapi_types.ValidateValue(addPetJSONBody(v))
+}
+
+// Getter for additional properties for Labels. Returns the specified
+// element and whether it was found
+func (a Labels) Get(fieldName string) (value Tag, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for Labels
+func (a *Labels) Set(fieldName string, value Tag) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]Tag)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for Labels to handle AdditionalProperties
+func (a *Labels) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]Tag)
+		for fieldName, fieldBuf := range object {
+			var fieldVal Tag
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("error unmarshaling field %s", fieldName))
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for Labels to handle AdditionalProperties
+func (a Labels) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("error marshaling '%s'", fieldName))
+		}
+	}
+	return json.Marshal(object)
+}
+
+// Validate checks Grid against the constraints of its schema, and
+// returns every violation as openapi_types.ValidationErrors.
+func (v Grid) Validate() error {
+	var errs openapi_types.ValidationErrors
+	for i0 := range v {
+		for i1 := range v[i0] {
+			switch float64(v[i0][i1]) {
+			case 1, 2, 3:
+			default:
+				errs.Add(fmt.Sprintf("%s/%d", fmt.Sprintf("/%d", i0), i1), "must be one of %s", "1, 2, 3")
+			}
+		}
+	}
+
+	return errs.Err()
+}
+
+// Validate checks Labels against the constraints of its schema, and
+// returns every violation as openapi_types.ValidationErrors.
+func (v Labels) Validate() error {
+	var errs openapi_types.ValidationErrors
+	for k0 := range v.AdditionalProperties {
+		errs.Nest("/"+k0, openapi_types.ValidateValue(v.AdditionalProperties[k0]))
+	}
+	n1 := 0 + len(v.AdditionalProperties)
+	if n1 > 2 {
+		errs.Add("", "must have at most %d properties", 2)
+	}
+
+	return errs.Err()
+}
+
+// Validate checks Pet against the constraints of its schema, and
+// returns every violation as openapi_types.ValidationErrors.
+func (v Pet) Validate() error {
+	var errs openapi_types.ValidationErrors
+	if v.Kind != nil {
+		switch string(*v.Kind) {
+		case "cat", "dog":
+		default:
+			errs.Add("/kind", "must be one of %s", "\"cat\", \"dog\"")
+		}
+	}
+	// The pattern "^(?!x)" isn't supported by Go, so it isn't checked.
+	if utf8.RuneCountInString(string(v.Name)) < 1 {
+		errs.Add("/name", "must be at least %d characters long", 1)
+	}
+	if utf8.RuneCountInString(string(v.Name)) > 20 {
+		errs.Add("/name", "must be at most %d characters long", 20)
+	}
+	if !openapi_types.MatchPattern("^[A-Z]", string(v.Name)) {
+		errs.Add("/name", "must match the pattern %s", "^[A-Z]")
+	}
+	if v.Owner != nil {
+		if (*v.Owner).Age != nil {
+			if float64(*(*v.Owner).Age) > 150 {
+				errs.Add("/owner/age", "must be at most %s", "150")
+			}
+		}
+	}
+	if v.Tags != nil {
+		if len(*v.Tags) < 1 {
+			errs.Add("/tags", "must have at least %d items", 1)
+		}
+		if !openapi_types.UniqueItems(*v.Tags) {
+			errs.Add("/tags", "must not contain duplicate items")
+		}
+		for i0 := range *v.Tags {
+			errs.Nest(fmt.Sprintf("/tags/%d", i0), openapi_types.ValidateValue((*v.Tags)[i0]))
+		}
+	}
+	if float64(v.Weight) <= 0.5 {
+		errs.Add("/weight", "must be greater than %s", "0.5")
+	}
+	if !openapi_types.IsMultipleOf(float64(v.Weight), 0.25) {
+		errs.Add("/weight", "must be a multiple of %s", "0.25")
+	}
+	n1 := 2
+	if len(v.Blob) != 0 {
+		n1++
+	}
+	if v.Kind != nil {
+		n1++
+	}
+	if v.Lookahead != nil {
+		n1++
+	}
+	if v.Owner != nil {
+		n1++
+	}
+	if v.Tags != nil {
+		n1++
+	}
+	if n1 < 3 {
+		errs.Add("", "must have at least %d properties", 3)
+	}
+
+	return errs.Err()
+}
+
+// Validate checks Tag against the constraints of its schema, and
+// returns every violation as openapi_types.ValidationErrors.
+func (v Tag) Validate() error {
+	var errs openapi_types.ValidationErrors
+	if utf8.RuneCountInString(string(v)) < 2 {
+		errs.Add("", "must be at least %d characters long", 2)
+	}
+
+	return errs.Err()
+}
+
+// Validate checks addPetJSONBody against the constraints of its schema, and
+// returns every violation as openapi_types.ValidationErrors.
+func (v addPetJSONBody) Validate() error {
+	var errs openapi_types.ValidationErrors
+	if v.Note != nil {
+		if utf8.RuneCountInString(string(*v.Note)) > 10 {
+			errs.Add("/note", "must be at most %d characters long", 10)
+		}
+	}
+	errs.Nest("/pet", openapi_types.ValidateValue(v.Pet))
+
+	return errs.Err()
+}
+
+// Validate checks FindPetsParams against the constraints of its schema, and
+// returns every violation as openapi_types.ValidationErrors.
+func (v FindPetsParams) Validate() error {
+	var errs openapi_types.ValidationErrors
+	if v.Limit != nil {
+		if f0, err := (*v.Limit).Float64(); err != nil {
+			errs.Add("/limit", "must be a number")
+		} else {
+			if float64(f0) < 1 {
+				errs.Add("/limit", "must be at least %s", "1")
+			}
+			if float64(f0) > 100 {
+				errs.Add("/limit", "must be at most %s", "100")
+			}
+		}
+	}
+	if v.Tags != nil {
+		if len(*v.Tags) > 3 {
+			errs.Add("/tags", "must have at most %d items", 3)
+		}
+		for i1 := range *v.Tags {
+			if utf8.RuneCountInString(string((*v.Tags)[i1])) < 2 {
+				errs.Add(fmt.Sprintf("/tags/%d", i1), "must be at least %d characters long", 2)
+			}
+		}
+	}
+
+	return errs.Err()
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// (POST /pets)
+	AddPet(ctx echo.Context) error
+	// (GET /pets/{name})
+	FindPets(ctx echo.Context, name string, params FindPetsParams) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// AddPet converts echo context to params.
+func (w *ServerInterfaceWrapper) AddPet(ctx echo.Context) error {
+	runtime.SetOperationInfo(ctx, &Operations[0])
+
+	var err error
+
+	var errs openapi_types.ValidationErrors
+
+	if err := errs.Err(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid parameters: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.AddPet(ctx)
+	return err
+}
+
+// FindPets converts echo context to params.
+func (w *ServerInterfaceWrapper) FindPets(ctx echo.Context) error {
+	runtime.SetOperationInfo(ctx, &Operations[1])
+
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	{
+		value, err := runtime.StyledParameterValue("simple", false, "name", runtime.PathParam(ctx, "name"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+		}
+		name = value
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params FindPetsParams
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := ctx.QueryParam("limit"); paramValue != "" {
+
+	}
+
+	{
+		if values, found := ctx.QueryParams()["limit"]; found {
+			if len(values) != 1 {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid format for parameter limit: multiple values for single value parameter")
+			}
+			value := json.Number(values[0])
+			params.Limit = &value
+		}
+	}
+
+	// ------------- Optional query parameter "tags" -------------
+	if paramValue := ctx.QueryParam("tags"); paramValue != "" {
+
+	}
+
+	{
+		if values, found := ctx.QueryParams()["tags"]; found {
+			array := make([]string, len(values))
+			for i, part := range values {
+				array[i] = part
+			}
+			value := array
+			params.Tags = &value
+		}
+	}
+
+	var errs openapi_types.ValidationErrors
+	if !openapi_types.MatchPattern("^[a-z]+$", string(name)) {
+		errs.Add("/name", "must match the pattern %s", "^[a-z]+$")
+	}
+	errs.Nest("", params.Validate())
+
+	if err := errs.Err(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid parameters: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.FindPets(ctx, name, params)
+	return err
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router runtime.EchoRouter, si ServerInterface) {
+	registerHandlers(router, si, "", nil)
+}
+
+// RegisterHandlersWithBaseURL adds each server route to the EchoRouter, under
+// the path of baseURL, which is typically the URL of one of the servers in the
+// spec. The routes of https://example.com/v2 are under /v2, for example.
+func RegisterHandlersWithBaseURL(router runtime.EchoRouter, si ServerInterface, baseURL string) error {
+	basePath, err := runtime.BaseURLPath(baseURL)
+	if err != nil {
+		return err
+	}
+	registerHandlers(router, si, basePath, nil)
+	return nil
+}
+
+// RegisterInstrumentedHandlers adds each server route to the EchoRouter under
+// the path of baseURL, as RegisterHandlersWithBaseURL does, and tells
+// instrumentation of each request which the routes handle, for tracing and
+// metrics.
+func RegisterInstrumentedHandlers(router runtime.EchoRouter, si ServerInterface, baseURL string, instrumentation runtime.Instrumentation) error {
+	basePath, err := runtime.BaseURLPath(baseURL)
+	if err != nil {
+		return err
+	}
+	registerHandlers(router, si, basePath, instrumentation)
+	return nil
+}
+
+func registerHandlers(router runtime.EchoRouter, si ServerInterface, basePath string, instrumentation runtime.Instrumentation) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.POST(basePath+"/pets", runtime.InstrumentHandler(wrapper.AddPet, &Operations[0], instrumentation))
+	router.GET(basePath+"/pets/:name", runtime.InstrumentHandler(wrapper.FindPets, &Operations[1], instrumentation))
+
+}
+
+// Operations describes each of the operations of the API, as the spec does, in
+// the order of their paths and methods.
+var Operations = []runtime.OperationInfo{
+	{
+		OperationID: "addPet",
+		Method:      "POST",
+		Path:        "/pets",
+	},
+	{
+		OperationID: "findPets",
+		Method:      "GET",
+		Path:        "/pets/{name}",
+	},
+}
+
+// OperationsByRoute indexes Operations by method and path, eg "GET /pets/{id}",
+// as runtime.RouteKey makes them.
+var OperationsByRoute = map[string]*runtime.OperationInfo{
+	"POST /pets":       &Operations[0],
+	"GET /pets/{name}": &Operations[1],
+}
+
+// OperationsByID indexes Operations by operationId. Operations without one,
+// which are named by x-go-name, are only in OperationsByRoute.
+var OperationsByID = map[string]*runtime.OperationInfo{
+	"addPet":   &Operations[0],
+	"findPets": &Operations[1],
+}
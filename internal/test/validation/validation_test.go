@@ -0,0 +1,110 @@
+package validation
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/deepmap/oapi-codegen/pkg/testutil"
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+)
+
+// Returns the paths of all the violations in err.
+func violationPaths(t *testing.T, err error) []string {
+	var paths []string
+	if err == nil {
+		return paths
+	}
+	errs, ok := err.(openapi_types.ValidationErrors)
+	if assert.True(t, ok, "expected ValidationErrors, got %T", err) {
+		for _, e := range errs {
+			paths = append(paths, e.Path)
+		}
+	}
+	return paths
+}
+
+func TestValidatePet(t *testing.T) {
+	var pet Pet
+	err := json.Unmarshal([]byte(`{"name": "Rex", "weight": 2.5, "kind": "dog", "tags": ["ab", "cd"]}`), &pet)
+	assert.NoError(t, err)
+	assert.NoError(t, pet.Validate())
+
+	err = json.Unmarshal([]byte(`{
+		"name": "rex",
+		"weight": 0.6,
+		"kind": "fish",
+		"tags": ["ab", "x", "ab"],
+		"owner": {"age": 200}
+	}`), &pet)
+	assert.NoError(t, err)
+	err = pet.Validate()
+	assert.Error(t, err)
+	assert.ElementsMatch(t, []string{
+		"/name",   // pattern
+		"/weight", // multipleOf
+		"/kind",   // enum
+		"/tags",   // uniqueItems
+		"/tags/1", // minLength, of a referenced type
+		"/owner/age",
+	}, violationPaths(t, err))
+
+	// Exclusive minimum, and minProperties
+	pet = Pet{Name: "Rex", Weight: 0.5}
+	assert.ElementsMatch(t, []string{"/weight", ""}, violationPaths(t, pet.Validate()))
+}
+
+func TestValidateCollections(t *testing.T) {
+	grid := Grid{{1, 2}, {3, 4}}
+	assert.Equal(t, []string{"/1/1"}, violationPaths(t, grid.Validate()))
+
+	labels := Labels{}
+	labels.Set("a", "aa")
+	labels.Set("b", "b")
+	assert.Equal(t, []string{"/b"}, violationPaths(t, labels.Validate()))
+	labels.Set("c", "cc")
+	assert.Len(t, violationPaths(t, labels.Validate()), 2)
+
+	// Request bodies validate the types they're defined as.
+	body := AddPetJSONRequestBody{Pet: Pet{Name: "Rex", Weight: 1}}
+	assert.Equal(t, []string{"/pet"}, violationPaths(t, body.Validate()))
+}
+
+type server struct {
+	called bool
+}
+
+func (s *server) AddPet(ctx echo.Context) error {
+	s.called = true
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (s *server) FindPets(ctx echo.Context, name string, params FindPetsParams) error {
+	s.called = true
+	return ctx.NoContent(http.StatusOK)
+}
+
+func TestServerValidatesParams(t *testing.T) {
+	e := echo.New()
+	s := &server{}
+	RegisterHandlers(e, s)
+
+	result := testutil.NewRequest().Get("/pets/rex?limit=10&tags=ab").Go(t, e)
+	assert.Equal(t, http.StatusOK, result.Code())
+	assert.True(t, s.called)
+
+	for _, url := range []string{
+		"/pets/Rex",
+		"/pets/rex?limit=500",
+		"/pets/rex?tags=a",
+		"/pets/rex?tags=ab&tags=cd&tags=ef&tags=gh",
+	} {
+		s.called = false
+		result = testutil.NewRequest().Get(url).Go(t, e)
+		assert.Equal(t, http.StatusBadRequest, result.Code(), url)
+		assert.False(t, s.called, url)
+	}
+}
@@ -0,0 +1,423 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// The server wrapper binds parameters with code which is generated for their
+// types, rather than with the runtime functions, which use reflection, and
+// are slow. The code binds what the runtime functions bind in the same way.
+// Parameters of other types, such as those given by x-go-type, and styles
+// other than the common ones, are still bound by the runtime functions.
+
+// primitiveParser describes how to parse a string as a value of a primitive
+// Go type. parse is a call which returns the value and an error, in which %s
+// is the string, and convert turns what it returns, %s, into the type. When
+// parse is empty, the string is converted directly.
+type primitiveParser struct {
+	parse   string
+	convert string
+}
+
+// The primitive types which parameters may have. Integers and numbers are
+// json.Number, which, as in the runtime, is taken as it is.
+var primitiveParsers = map[string]primitiveParser{
+	"string":      {convert: "%s"},
+	"json.Number": {convert: "json.Number(%s)"},
+	"bool":        {parse: "strconv.ParseBool(%s)", convert: "%s"},
+	"int":         {parse: "strconv.ParseInt(%s, 10, 0)", convert: "int(%s)"},
+	"int32":       {parse: "strconv.ParseInt(%s, 10, 32)", convert: "int32(%s)"},
+	"int64":       {parse: "strconv.ParseInt(%s, 10, 64)", convert: "%s"},
+	"float32":     {parse: "strconv.ParseFloat(%s, 32)", convert: "float32(%s)"},
+	"float64":     {parse: "strconv.ParseFloat(%s, 64)", convert: "%s"},
+}
+
+// The shapes of parameters which we generate binding code for.
+type bindingKind int
+
+const (
+	bindReflect bindingKind = iota // Left to the runtime
+	bindPrimitive
+	bindArray
+	bindObject
+)
+
+// Returns how a parameter with the schema s is bound.
+func paramBindingKind(s Schema) bindingKind {
+	switch {
+	case isPrimitiveSchema(s):
+		return bindPrimitive
+	case s.ArrayType != nil:
+		if isPrimitiveSchema(*s.ArrayType) {
+			return bindArray
+		}
+	case len(s.Properties) != 0 && !s.HasAdditionalProperties:
+		bound := 0
+		for _, p := range s.Properties {
+			if p.JsonIgnore {
+				continue
+			}
+			if !isPrimitiveSchema(p.Schema) {
+				return bindReflect
+			}
+			bound++
+		}
+		if bound != 0 {
+			return bindObject
+		}
+	}
+	return bindReflect
+}
+
+func isPrimitiveSchema(s Schema) bool {
+	_, found := primitiveParsers[s.GoType]
+	return found && s.ArrayType == nil && len(s.Properties) == 0
+}
+
+// Returns the schema of a parameter, as far as binding it is concerned. The
+// schema of the definition is of its type, which, for references to component
+// parameters, is only a name.
+func paramBindingSchema(pd ParameterDefinition) (Schema, bool) {
+	if pd.Spec.Schema == nil {
+		return Schema{}, false
+	}
+	s, err := pd.generator().bindingSchema(pd.Spec.Schema, ComponentParameters)
+	if err != nil {
+		return Schema{}, false
+	}
+	return s, true
+}
+
+// Parameters are bound from strings, so their integers and numbers are
+// json.Number, but the types of the components they refer to have ints and
+// floats. This function describes a schema as the type which is generated for
+// it, so describes references in the context of the component schemas.
+func (g *generator) bindingSchema(sref *openapi3.SchemaRef, componentType ComponentType) (Schema, error) {
+	if sref.Ref != "" {
+		componentType = ComponentSchemas
+	}
+	s, err := g.goSchema(sref, nil, &componentType)
+	if err != nil {
+		return Schema{}, err
+	}
+	if s.ArrayType != nil && sref.Value.Items != nil {
+		items, err := g.bindingSchema(sref.Value.Items, componentType)
+		if err != nil {
+			return Schema{}, err
+		}
+		s.ArrayType = &items
+	}
+	for i, p := range s.Properties {
+		// Properties merged from allOf aren't those of the schema.
+		pref, found := sref.Value.Properties[p.JsonFieldName]
+		if !found {
+			return Schema{}, fmt.Errorf("property '%s' isn't in the schema", p.JsonFieldName)
+		}
+		if s.Properties[i].Schema, err = g.bindingSchema(pref, componentType); err != nil {
+			return Schema{}, err
+		}
+	}
+	return s, nil
+}
+
+// bindGen accumulates the code which binds a parameter.
+type bindGen struct {
+	buf   *bytes.Buffer
+	param ParameterDefinition
+	dest  string // The variable which is bound, which mustn't be shadowed
+	depth int    // For naming variables in nested blocks uniquely
+}
+
+func newBindGen(pd ParameterDefinition, dest string) *bindGen {
+	return &bindGen{buf: &bytes.Buffer{}, param: pd, dest: dest}
+}
+
+func (g *bindGen) printf(format string, args ...interface{}) {
+	fmt.Fprintf(g.buf, format+"\n", args...)
+}
+
+// Returns from the handler with a 400 error, whose message is formatted from
+// format, in which %s is the name of the parameter, and args, which are Go
+// expressions.
+func (g *bindGen) fail(format string, args ...string) {
+	msg := strconv.Quote(fmt.Sprintf(format, g.param.ParamName))
+	if len(args) == 0 {
+		g.printf("return echo.NewHTTPError(http.StatusBadRequest, %s)", msg)
+		return
+	}
+	g.printf("return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf(%s, %s))", msg, strings.Join(args, ", "))
+}
+
+// Returns the name of a local variable, which is unique in the block being
+// generated, and isn't that of the variable being bound.
+func (g *bindGen) name(base string) string {
+	name := base
+	if g.depth != 0 {
+		name = fmt.Sprintf("%s%d", base, g.depth)
+	}
+	if name == g.dest {
+		name += "Value"
+	}
+	return name
+}
+
+// Generates code which parses the string src as a value of the primitive
+// schema s, and stores it with the code which assign returns for the value.
+func (g *bindGen) parse(s Schema, src string, assign func(value string) string) {
+	parser := primitiveParsers[s.GoType]
+	convert := func(value string) string {
+		value = fmt.Sprintf(parser.convert, value)
+		if s.TypeDecl() != s.GoType {
+			value = fmt.Sprintf("%s(%s)", s.TypeDecl(), value)
+		}
+		return value
+	}
+	if parser.parse == "" {
+		g.printf("%s", assign(convert(src)))
+		return
+	}
+	parsed := g.name("parsed")
+	g.printf("%s, err := %s", parsed, fmt.Sprintf(parser.parse, src))
+	g.printf("if err != nil {")
+	g.fail("Invalid format for parameter %s: %%s", "err")
+	g.printf("}")
+	g.printf("%s", assign(convert(parsed)))
+}
+
+// Returns code which assigns value to dest, which is a pointer when
+// optional is set, in which case the value is first stored in the variable
+// valueName.
+func assignTo(dest string, optional bool, valueName string) func(value string) string {
+	return func(value string) string {
+		if !optional {
+			return fmt.Sprintf("%s = %s", dest, value)
+		}
+		return fmt.Sprintf("%s := %s\n%s = &%s", valueName, value, dest, valueName)
+	}
+}
+
+// Generates code which binds the parts of an array, which is in the
+// variable parts, to dest, which has the type typeDecl.
+func (g *bindGen) array(s Schema, typeDecl string, parts string, dest func(value string) string) {
+	array, i, part := g.name("array"), g.name("i"), g.name("part")
+	g.printf("%s := make(%s, len(%s))", array, typeDecl, parts)
+	g.printf("for %s, %s := range %s {", i, part, parts)
+	g.depth++
+	g.parse(*s.ArrayType, part, assignTo(array+"["+i+"]", false, ""))
+	g.depth--
+	g.printf("}")
+	g.printf("%s", dest(array))
+}
+
+// Generates code which binds the properties of an object, whose names and
+// values are in the variable parts, either as name=value pairs, when
+// explode is set, or in turn, to dest, which has the type typeDecl.
+func (g *bindGen) object(s Schema, typeDecl string, explode bool, parts string, dest func(value string) string) {
+	object, property, value := g.name("object"), g.name("property"), g.name("value")
+	g.printf("var %s %s", object, typeDecl)
+	if explode {
+		part := g.name("part")
+		g.printf("for _, %s := range %s {", part, parts)
+		g.printf("%s, %s, err := runtime.SplitStyledProperty(%s, %s)", property, value, strconv.Quote(g.param.ParamName), part)
+		g.printf("if err != nil {")
+		g.fail("Invalid format for parameter %s: %%s", "err")
+		g.printf("}")
+	} else {
+		i := g.name("i")
+		g.printf("if len(%s)%%2 != 0 {", parts)
+		g.fail("Invalid format for parameter %s: property/values need to be pairs")
+		g.printf("}")
+		g.printf("for %s := 0; %s < len(%s); %s += 2 {", i, i, parts, i)
+		g.printf("%s, %s := %s[%s], %s[%s+1]", property, value, parts, i, parts, i)
+	}
+	g.printf("switch %s {", property)
+	g.depth++
+	for _, p := range s.Properties {
+		if p.JsonIgnore {
+			continue
+		}
+		g.printf("case %s:", strconv.Quote(p.JsonFieldName))
+		optional := !p.Required && !p.Schema.SkipOptionalPointer
+		g.parse(p.Schema, value, assignTo(object+"."+p.GoFieldName(), optional, g.name("field")))
+	}
+	g.depth--
+	g.printf("}")
+	g.printf("}")
+	g.printf("%s", dest(object))
+}
+
+// This function generates the code which binds a parameter which is given as
+// a single styled string, src, such as a path parameter, to dest, which is a
+// variable of the parameter's type. It returns from the handler when the
+// parameter can't be bound.
+func genBindStyledParam(style string, pd ParameterDefinition, src string, dest string) string {
+	s, ok := paramBindingSchema(pd)
+	kind := paramBindingKind(s)
+	g := newBindGen(pd, dest)
+	if !ok || kind == bindReflect {
+		g.printf("err = runtime.BindStyledParameter(%s, %t, %s, %s, &%s)",
+			strconv.Quote(style), pd.Explode(), strconv.Quote(pd.ParamName), src, dest)
+		g.printf("if err != nil {")
+		g.fail("Invalid format for parameter %s: %%s", "err")
+		g.printf("}")
+		return g.buf.String()
+	}
+
+	g.printf("{")
+	switch kind {
+	case bindPrimitive:
+		value := g.name("value")
+		g.printf("%s, err := runtime.StyledParameterValue(%s, %t, %s, %s)",
+			value, strconv.Quote(style), pd.Explode(), strconv.Quote(pd.ParamName), src)
+		g.printf("if err != nil {")
+		g.fail("Invalid format for parameter %s: %%s", "err")
+		g.printf("}")
+		s.RefType = pd.TypeDef()
+		g.parse(s, value, assignTo(dest, false, ""))
+	case bindArray, bindObject:
+		parts := g.name("parts")
+		g.printf("%s, err := runtime.StyledParameterParts(%s, %t, %t, %s, %s)",
+			parts, strconv.Quote(style), pd.Explode(), kind == bindObject, strconv.Quote(pd.ParamName), src)
+		g.printf("if err != nil {")
+		g.fail("Invalid format for parameter %s: %%s", "err")
+		g.printf("}")
+		if kind == bindArray {
+			g.array(s, pd.TypeDef(), parts, assignTo(dest, false, ""))
+		} else {
+			g.object(s, pd.TypeDef(), pd.Explode(), parts, assignTo(dest, false, ""))
+		}
+	}
+	g.printf("}")
+	return g.buf.String()
+}
+
+// This function generates the code which binds a query parameter from the
+// echo context, ctx, to its field of the parameters object, params, which is
+// a pointer when it's optional. The form style, which is the default, and the
+// deepObject style, for objects, are generated, and the code checks the number
+// of values as the runtime does. The spaceDelimited and pipeDelimited styles
+// are left to the runtime, which doesn't support them. It returns from the
+// handler when the parameter can't be bound.
+func genBindQueryParam(pd ParameterDefinition) string {
+	s, ok := paramBindingSchema(pd)
+	kind := paramBindingKind(s)
+	dest := "params." + pd.GoName()
+	deepObject := pd.Style() == "deepObject" && kind == bindObject
+	if !ok || kind == bindReflect || (pd.Style() != "form" && !deepObject) {
+		g := newBindGen(pd, "params")
+		g.printf("err = runtime.BindQueryParameter(%s, %t, %t, %s, ctx.QueryParams(), &%s)",
+			strconv.Quote(pd.Style()), pd.Explode(), pd.Required, strconv.Quote(pd.ParamName), dest)
+		g.printf("if err != nil {")
+		g.fail("Invalid format for parameter %s: %%s", "err")
+		g.printf("}")
+		return g.buf.String()
+	}
+
+	optional := !pd.Required
+	assign := assignTo(dest, optional, "value")
+	name := strconv.Quote(pd.ParamName)
+	g := newBindGen(pd, "params")
+	g.printf("{")
+	switch {
+	case deepObject:
+		// The properties are query parameters named like name[property], and
+		// as in the runtime, the object is set even when none of them are
+		// present.
+		g.printf("var object %s", pd.TypeDef())
+		g.printf("for key, values := range ctx.QueryParams() {")
+		g.printf("if !strings.HasPrefix(key, %s) {", strconv.Quote(pd.ParamName+"["))
+		g.printf("continue")
+		g.printf("}")
+		g.printf("split := strings.Split(key, \"[\")")
+		g.printf("if len(split) != 2 {")
+		g.fail("Invalid format for parameter %s: '%%s' does not match deepObject style", "key")
+		g.printf("}")
+		g.printf("switch strings.TrimSuffix(split[1], \"]\") {")
+		g.depth++
+		for _, p := range s.Properties {
+			if p.JsonIgnore {
+				continue
+			}
+			g.printf("case %s:", strconv.Quote(p.JsonFieldName))
+			optional := !p.Required && !p.Schema.SkipOptionalPointer
+			g.parse(p.Schema, "values[0]", assignTo("object."+p.GoFieldName(), optional, g.name("field")))
+		}
+		g.depth--
+		g.printf("}")
+		g.printf("}")
+		g.printf("%s", assign("object"))
+	case kind == bindObject && pd.Explode():
+		// The properties of exploded objects are query parameters of their
+		// own, and the object is set even when none of them are present.
+		g.printf("var object %s", pd.TypeDef())
+		g.depth++
+		for _, p := range s.Properties {
+			if p.JsonIgnore {
+				continue
+			}
+			g.printf("if values, found := ctx.QueryParams()[%s]; found {", strconv.Quote(p.JsonFieldName))
+			g.printf("if len(values) != 1 {")
+			g.fail(fmt.Sprintf("Invalid format for parameter %%s: field '%s' is specified multiple times", p.JsonFieldName))
+			g.printf("}")
+			optional := !p.Required && !p.Schema.SkipOptionalPointer
+			g.parse(p.Schema, "values[0]", assignTo("object."+p.GoFieldName(), optional, "field"))
+			g.printf("}")
+		}
+		g.depth--
+		g.printf("%s", assign("object"))
+	case pd.Explode():
+		// Each value is a separate query parameter.
+		g.printf("if values, found := ctx.QueryParams()[%s]; found {", name)
+		if kind == bindArray {
+			g.array(s, pd.TypeDef(), "values", assign)
+		} else {
+			g.printf("if len(values) != 1 {")
+			g.fail("Invalid format for parameter %s: multiple values for single value parameter")
+			g.printf("}")
+			s.RefType = pd.TypeDef()
+			g.parse(s, "values[0]", assign)
+		}
+		g.printf("}")
+	default:
+		// There's a single query parameter, whose parts are comma separated.
+		g.printf("if values, found := ctx.QueryParams()[%s]; found {", name)
+		g.printf("if len(values) != 1 {")
+		g.fail("Invalid format for parameter %s: parameter is not exploded, but is specified multiple times")
+		g.printf("}")
+		switch kind {
+		case bindPrimitive:
+			g.printf("if strings.Contains(values[0], \",\") {")
+			g.fail("Invalid format for parameter %s: multiple values for single value parameter")
+			g.printf("}")
+			s.RefType = pd.TypeDef()
+			g.parse(s, "values[0]", assign)
+		case bindArray:
+			g.printf("parts := strings.Split(values[0], \",\")")
+			g.array(s, pd.TypeDef(), "parts", assign)
+		case bindObject:
+			g.printf("parts := strings.Split(values[0], \",\")")
+			g.object(s, pd.TypeDef(), false, "parts", assign)
+		}
+		g.printf("}")
+	}
+	g.printf("}")
+	return g.buf.String()
+}
@@ -0,0 +1,524 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+)
+
+// CallbackDefinition describes where the operations of a callback, or of a
+// webhook, are sent. Callbacks are sent to a URL which comes from the request
+// that triggered them, while webhooks are sent to wherever their subscribers
+// have asked for them.
+type CallbackDefinition struct {
+	Name       string // The name of the callback or webhook in the spec, eg onEvent
+	Expression string // The URL of a callback, with runtime expressions, eg {$request.body#/callbackUrl}
+	Trigger    string // The OperationId of the operation which sends a callback
+}
+
+// Returns whether this is a webhook, rather than a callback.
+func (c CallbackDefinition) IsWebhook() bool {
+	return c.Trigger == ""
+}
+
+// An operation of a callback or webhook, before we've described it.
+type callbackOperation struct {
+	callback CallbackDefinition
+	method   string
+	pathItem *openapi3.PathItem
+	op       *openapi3.Operation
+	goName   string
+}
+
+// This function finds the operations of all the callbacks of the operations
+// in the spec, followed by the operations of the webhooks in x-webhooks, and
+// names them. An operation is named by its operationId or x-go-name, if it
+// has one, and otherwise after the operation which triggers it and the
+// callback's name, such as SubscribeOnEvent, or, for a webhook, just its
+// name. Where that isn't unique, the method is added to the name.
+func callbackOperations(swagger *openapi3.Swagger) ([]callbackOperation, error) {
+	var cbOps []callbackOperation
+
+	for _, requestPath := range SortedPathsKeys(swagger.Paths) {
+		pathOps := swagger.Paths[requestPath].Operations()
+		for _, method := range SortedOperationsKeys(pathOps) {
+			op := pathOps[method]
+			if len(op.Callbacks) == 0 {
+				continue
+			}
+			trigger, err := operationGoName(op)
+			if err != nil {
+				return nil, err
+			}
+			if trigger == "" {
+				return nil, fmt.Errorf("OperationId is missing on path '%s %s'", method, requestPath)
+			}
+			trigger = ToCamelCase(trigger)
+
+			for _, name := range sortedCallbackKeys(op.Callbacks) {
+				callback, err := resolveCallback(swagger, op.Callbacks[name])
+				if err != nil {
+					return nil, errors.Wrap(err, fmt.Sprintf("error in callback %s of %s", name, trigger))
+				}
+				named, err := nameCallbackOperations(CallbackDefinition{Name: name, Trigger: trigger},
+					openapi3.Paths(callback), trigger+ToCamelCase(name))
+				if err != nil {
+					return nil, err
+				}
+				cbOps = append(cbOps, named...)
+			}
+		}
+	}
+
+	webhooks, err := extWebhookPaths(swagger)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range SortedPathsKeys(webhooks) {
+		named, err := nameCallbackOperations(CallbackDefinition{Name: name},
+			openapi3.Paths{"": webhooks[name]}, ToCamelCase(name))
+		if err != nil {
+			return nil, err
+		}
+		cbOps = append(cbOps, named...)
+	}
+	return cbOps, nil
+}
+
+// Names the operations of a single callback or webhook, see callbackOperations.
+func nameCallbackOperations(callback CallbackDefinition, paths openapi3.Paths, baseName string) ([]callbackOperation, error) {
+	var cbOps []callbackOperation
+	for _, expression := range SortedPathsKeys(paths) {
+		pathItem := paths[expression]
+		pathOps := pathItem.Operations()
+		for _, method := range SortedOperationsKeys(pathOps) {
+			cb := callback
+			cb.Expression = expression
+			cbOps = append(cbOps, callbackOperation{
+				callback: cb,
+				method:   method,
+				pathItem: pathItem,
+				op:       pathOps[method],
+			})
+		}
+	}
+
+	taken := make(map[string]bool)
+	for i := range cbOps {
+		cbOp := &cbOps[i]
+		goName, err := operationGoName(cbOp.op)
+		if err != nil {
+			return nil, err
+		}
+		if goName != "" {
+			cbOp.goName = ToCamelCase(goName)
+			continue
+		}
+		name := baseName
+		if len(cbOps) > 1 {
+			name += UppercaseFirstCharacter(strings.ToLower(cbOp.method))
+		}
+		cbOp.goName = name
+		for n := 2; taken[cbOp.goName]; n++ {
+			cbOp.goName = fmt.Sprintf("%s%d", name, n)
+		}
+		taken[cbOp.goName] = true
+	}
+	return cbOps, nil
+}
+
+// The loader doesn't resolve the references in callbacks, neither to the
+// callbacks themselves, under #/components/callbacks, nor to what they use,
+// so this function does it. The callback is resolved in a copy, since the
+// spec is the caller's, which we mustn't change.
+func resolveCallback(swagger *openapi3.Swagger, cbRef *openapi3.CallbackRef) (openapi3.Callback, error) {
+	value := cbRef.Value
+	if cbRef.Ref != "" {
+		const prefix = "#/components/callbacks/"
+		if !strings.HasPrefix(cbRef.Ref, prefix) {
+			return nil, fmt.Errorf("unsupported callback reference '%s'", cbRef.Ref)
+		}
+		resolved, found := swagger.Components.Callbacks[strings.TrimPrefix(cbRef.Ref, prefix)]
+		if !found || resolved.Value == nil {
+			return nil, fmt.Errorf("callback reference '%s' not found", cbRef.Ref)
+		}
+		value = resolved.Value
+	}
+	if value == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "error copying callback")
+	}
+	var callback openapi3.Callback
+	if err := json.Unmarshal(raw, &callback); err != nil {
+		return nil, errors.Wrap(err, "error copying callback")
+	}
+	if err := resolvePathRefs(swagger, openapi3.Paths(callback)); err != nil {
+		return nil, err
+	}
+	return callback, nil
+}
+
+// Returns a copy of the spec in which the callbacks of the operations are
+// resolved, see resolveCallback.
+func resolveCallbacks(swagger *openapi3.Swagger) (*openapi3.Swagger, error) {
+	resolved := *swagger
+	resolved.Paths = make(openapi3.Paths, len(swagger.Paths))
+	for _, requestPath := range SortedPathsKeys(swagger.Paths) {
+		pathItem := *swagger.Paths[requestPath]
+		pathOps := pathItem.Operations()
+		for _, method := range SortedOperationsKeys(pathOps) {
+			op := pathOps[method]
+			if len(op.Callbacks) == 0 {
+				continue
+			}
+			newOp := *op
+			newOp.Callbacks = make(map[string]*openapi3.CallbackRef, len(op.Callbacks))
+			for _, name := range sortedCallbackKeys(op.Callbacks) {
+				cbRef := op.Callbacks[name]
+				callback, err := resolveCallback(swagger, cbRef)
+				if err != nil {
+					return nil, errors.Wrap(err, fmt.Sprintf("error in callback %s of %s %s", name, method, requestPath))
+				}
+				if callback != nil {
+					cbRef = &openapi3.CallbackRef{Ref: cbRef.Ref, Value: &callback}
+				}
+				newOp.Callbacks[name] = cbRef
+			}
+			pathItem.SetOperation(method, &newOp)
+		}
+		resolved.Paths[requestPath] = &pathItem
+	}
+	return &resolved, nil
+}
+
+// Resolves the references in paths, which must be our own, rather than the
+// spec's, to the components of the spec. The loader's ResolveRefsIn would
+// resolve the components again, too, so this leaves them alone, and uses
+// them as they are.
+func resolvePathRefs(swagger *openapi3.Swagger, paths openapi3.Paths) error {
+	r := refResolver{components: swagger.Components}
+	for _, expression := range SortedPathsKeys(paths) {
+		pathItem := paths[expression]
+		if pathItem == nil {
+			continue
+		}
+		if err := r.parameters(pathItem.Parameters); err != nil {
+			return err
+		}
+		pathOps := pathItem.Operations()
+		for _, method := range SortedOperationsKeys(pathOps) {
+			op := pathOps[method]
+			if err := r.parameters(op.Parameters); err != nil {
+				return err
+			}
+			if err := r.requestBody(op.RequestBody); err != nil {
+				return err
+			}
+			for _, name := range SortedResponsesKeys(op.Responses) {
+				if err := r.response(op.Responses[name]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// refResolver resolves references to the components of a spec, see
+// resolvePathRefs.
+type refResolver struct {
+	components openapi3.Components
+}
+
+// Returns the name of the component in the given section which a reference
+// refers to.
+func (r refResolver) componentName(ref string, section string) (string, error) {
+	prefix := "#/components/" + section + "/"
+	if !strings.HasPrefix(ref, prefix) {
+		return "", fmt.Errorf("unsupported reference '%s'", ref)
+	}
+	return strings.TrimPrefix(ref, prefix), nil
+}
+
+func refNotFound(ref string) error {
+	return fmt.Errorf("reference '%s' not found", ref)
+}
+
+func (r refResolver) schema(sref *openapi3.SchemaRef) error {
+	if sref == nil {
+		return nil
+	}
+	if sref.Ref != "" {
+		name, err := r.componentName(sref.Ref, "schemas")
+		if err != nil {
+			return err
+		}
+		component, found := r.components.Schemas[name]
+		if !found || component.Value == nil {
+			return refNotFound(sref.Ref)
+		}
+		sref.Value = component.Value
+		return nil
+	}
+	schema := sref.Value
+	if schema == nil {
+		return nil
+	}
+	refs := []*openapi3.SchemaRef{schema.Items, schema.Not, schema.AdditionalProperties}
+	refs = append(refs, schema.AllOf...)
+	refs = append(refs, schema.AnyOf...)
+	refs = append(refs, schema.OneOf...)
+	for _, name := range SortedSchemaKeys(schema.Properties) {
+		refs = append(refs, schema.Properties[name])
+	}
+	for _, ref := range refs {
+		if err := r.schema(ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r refResolver) content(content openapi3.Content) error {
+	for _, contentType := range SortedContentKeys(content) {
+		if err := r.schema(content[contentType].Schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r refResolver) parameters(params openapi3.Parameters) error {
+	for _, pref := range params {
+		if pref.Ref != "" {
+			name, err := r.componentName(pref.Ref, "parameters")
+			if err != nil {
+				return err
+			}
+			component, found := r.components.Parameters[name]
+			if !found || component.Value == nil {
+				return refNotFound(pref.Ref)
+			}
+			pref.Value = component.Value
+			continue
+		}
+		if pref.Value == nil {
+			continue
+		}
+		if err := r.schema(pref.Value.Schema); err != nil {
+			return err
+		}
+		if err := r.content(pref.Value.Content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r refResolver) requestBody(bref *openapi3.RequestBodyRef) error {
+	if bref == nil {
+		return nil
+	}
+	if bref.Ref != "" {
+		name, err := r.componentName(bref.Ref, "requestBodies")
+		if err != nil {
+			return err
+		}
+		component, found := r.components.RequestBodies[name]
+		if !found || component.Value == nil {
+			return refNotFound(bref.Ref)
+		}
+		bref.Value = component.Value
+		return nil
+	}
+	if bref.Value == nil {
+		return nil
+	}
+	return r.content(bref.Value.Content)
+}
+
+func (r refResolver) response(rref *openapi3.ResponseRef) error {
+	if rref == nil {
+		return nil
+	}
+	if rref.Ref != "" {
+		name, err := r.componentName(rref.Ref, "responses")
+		if err != nil {
+			return err
+		}
+		component, found := r.components.Responses[name]
+		if !found || component.Value == nil {
+			return refNotFound(rref.Ref)
+		}
+		rref.Value = component.Value
+		return nil
+	}
+	if rref.Value == nil {
+		return nil
+	}
+	for _, name := range sortedHeaderKeys(rref.Value.Headers) {
+		if err := r.header(rref.Value.Headers[name]); err != nil {
+			return err
+		}
+	}
+	return r.content(rref.Value.Content)
+}
+
+func (r refResolver) header(href *openapi3.HeaderRef) error {
+	if href == nil {
+		return nil
+	}
+	if href.Ref != "" {
+		name, err := r.componentName(href.Ref, "headers")
+		if err != nil {
+			return err
+		}
+		component, found := r.components.Headers[name]
+		if !found || component.Value == nil {
+			return refNotFound(href.Ref)
+		}
+		href.Value = component.Value
+		return nil
+	}
+	if href.Value == nil {
+		return nil
+	}
+	return r.schema(href.Value.Schema)
+}
+
+// Reads the webhooks in the x-webhooks extension, and resolves the references
+// in them.
+func extWebhookPaths(swagger *openapi3.Swagger) (openapi3.Paths, error) {
+	value, found := swagger.Extensions[extWebhooks]
+	if !found {
+		return nil, nil
+	}
+	var webhooks openapi3.Paths
+	if err := extDecode(value, &webhooks); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %s", extWebhooks, err)
+	}
+	if err := resolvePathRefs(swagger, webhooks); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("error resolving references in %s", extWebhooks))
+	}
+	return webhooks, nil
+}
+
+func sortedCallbackKeys(dict map[string]*openapi3.CallbackRef) []string {
+	keys := make([]string, 0, len(dict))
+	for key := range dict {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// CallbackOperationDefinitions describes the operations of the callbacks and
+// webhooks in the spec, in the same way as OperationDefinitions describes the
+// operations under its paths. Since their URLs aren't known until they're
+// sent, these have no Path, and so can't have path parameters.
+func CallbackOperationDefinitions(swagger *openapi3.Swagger) ([]OperationDefinition, error) {
+	return defaultGenerator.callbackOperationDefinitions(swagger)
+}
+
+func (g *generator) callbackOperationDefinitions(swagger *openapi3.Swagger) ([]OperationDefinition, error) {
+	cbOps, err := callbackOperations(swagger)
+	if err != nil {
+		return nil, err
+	}
+
+	var operations []OperationDefinition
+	for i := range cbOps {
+		cbOp := cbOps[i]
+		globalParams, err := g.describeParameters(cbOp.pathItem.Parameters, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error describing global parameters for %s: %s", cbOp.goName, err)
+		}
+		localParams, err := g.describeParameters(cbOp.op.Parameters, []string{cbOp.goName + "Params"})
+		if err != nil {
+			return nil, fmt.Errorf("error describing parameters for %s: %s", cbOp.goName, err)
+		}
+		allParams := append(globalParams, localParams...)
+		if len(FilterParameterDefinitionByType(allParams, "path")) != 0 {
+			return nil, fmt.Errorf("%s has path parameters, which callbacks and webhooks can't have", cbOp.goName)
+		}
+
+		bodyDefinitions, typeDefinitions, err := g.bodyDefinitions(cbOp.goName, cbOp.op.RequestBody)
+		if err != nil {
+			return nil, errors.Wrap(err, "error generating body definitions")
+		}
+
+		opDef := OperationDefinition{
+			HeaderParams:    FilterParameterDefinitionByType(allParams, "header"),
+			QueryParams:     FilterParameterDefinitionByType(allParams, "query"),
+			CookieParams:    FilterParameterDefinitionByType(allParams, "cookie"),
+			OperationId:     cbOp.goName,
+			Summary:         cbOp.op.Summary,
+			Method:          cbOp.method,
+			Spec:            cbOp.op,
+			Bodies:          bodyDefinitions,
+			TypeDefinitions: typeDefinitions,
+			Callback:        &cbOps[i].callback,
+			gen:             g,
+		}
+		if cbOp.op.RequestBody != nil {
+			opDef.BodyRequired = cbOp.op.RequestBody.Value.Required
+		}
+		opDef.TypeDefinitions = append(opDef.TypeDefinitions, GenerateTypeDefsForOperation(opDef)...)
+
+		operations = append(operations, opDef)
+	}
+	return operations, nil
+}
+
+// GenerateCallbackClient generates the client which sends callbacks and
+// webhooks, for the server side of the API.
+func GenerateCallbackClient(t *template.Template, ops []OperationDefinition) (string, error) {
+	return executeCallbackTemplate(t, "callback-client.tmpl", ops)
+}
+
+// GenerateCallbackServer generates the interface for receiving callbacks and
+// webhooks, and the code to register it, for the client side of the API.
+func GenerateCallbackServer(t *template.Template, ops []OperationDefinition) (string, error) {
+	return executeCallbackTemplate(t, "callback-server.tmpl", ops)
+}
+
+func executeCallbackTemplate(t *template.Template, name string, ops []OperationDefinition) (string, error) {
+	if len(ops) == 0 {
+		return "", nil
+	}
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+
+	err := t.ExecuteTemplate(w, name, ops)
+	if err != nil {
+		return "", fmt.Errorf("error generating %s: %s", name, err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for %s: %s", name, err)
+	}
+	return buf.String(), nil
+}
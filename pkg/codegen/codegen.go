@@ -19,7 +19,10 @@ import (
 	"bytes"
 	"fmt"
 	"go/format"
+	"path"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -35,31 +38,174 @@ type Options struct {
 	GenerateClient bool // GenerateClient specifies whether to generate client boilerplate
 	GenerateTypes  bool // GenerateTypes specifies whether to generate type definitions
 	EmbedSpec      bool // Whether to embed the swagger spec in the generated code
+
+	// SplitReadWriteTypes generates a FooRequest and a FooResponse type for
+	// each schema Foo with readOnly or writeOnly properties, and uses them
+	// for request bodies and responses respectively.
+	SplitReadWriteTypes bool
+
+	// FormatMappings maps schema formats, such as "money", to Go types. These
+	// apply to schemas of any primitive type, and take precedence over the
+	// built in mappings, such as "uuid" to uuid.UUID.
+	FormatMappings map[string]FormatMapping
+	// Selects the operations to generate code for, see OperationFilter
+	Filter OperationFilter
+	// Makes the server wrapper call Validate on parameters once they've been
+	// bound, and reject the request if they're invalid.
+	ValidateParams bool
+	// Components whose type names collide with other types are renamed, see
+	// ResolveTypeNames. With StrictTypeNames, such collisions are an error.
+	StrictTypeNames bool
+	// Gives operations without an operationId a name derived from their
+	// method and path, see SynthesizeOperationIDs, rather than failing.
+	SynthesizeOperationIDs bool
+}
+
+// FormatMapping describes the Go type used for an OpenAPI format.
+type FormatMapping struct {
+	Type   string // The Go type, eg "decimal.Decimal"
+	Import string // The package which provides Type, if any, eg "github.com/shopspring/decimal"
+	// The name to import the package as, if it's given one, eg "dec"
+	Name string `json:",omitempty"`
+}
+
+// ImportSpec returns the import declaration for the mapping, which needs an
+// explicit package name when it's been given one, or when the last element of
+// the import path isn't the one used in Type.
+func (m FormatMapping) ImportSpec() string {
+	if m.Import == "" {
+		return ""
+	}
+	if m.Name != "" {
+		return m.Name + " " + strconv.Quote(m.Import)
+	}
+	qualifier := m.qualifier()
+	if qualifier == "" || qualifier == path.Base(m.Import) {
+		return strconv.Quote(m.Import)
+	}
+	return qualifier + " " + strconv.Quote(m.Import)
+}
+
+// Returns the package name qualifying Type, so "decimal" for "*decimal.Decimal".
+func (m FormatMapping) qualifier() string {
+	match := qualifiedTypeRE.FindStringSubmatch(m.Type)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// Returns whether any of the mappings imports a package which its type is
+// qualified with the given name.
+func importsPackage(mappings []FormatMapping, name string) bool {
+	for _, m := range mappings {
+		if m.Import != "" && m.qualifier() == name {
+			return true
+		}
+	}
+	return false
+}
+
+var qualifiedTypeRE = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_]*)\.[A-Za-z_]`)
+
+// generator holds what generating code for a spec depends on, besides the
+// spec itself, so that deeply nested helpers, such as GenerateGoSchema, can
+// consult it, and so that runs, such as of Generate and Lint, don't share
+// any state.
+type generator struct {
+	options Options
+	// The Go names of components, see ResolveTypeNames, keyed by reference
+	// path, eg #/components/schemas/Foo
+	componentGoNames map[string]string
+}
+
+// defaultGenerator is used by the exported helpers, such as GenerateGoSchema,
+// which have no options, and which name components as the spec does.
+var defaultGenerator = &generator{}
+
+// newGenerator returns the generator for a prepared spec, along with the
+// components which had to be renamed, see ResolveTypeNames.
+func newGenerator(swagger *openapi3.Swagger, opts Options) (*generator, []TypeRename, error) {
+	componentNames, renames, err := ResolveTypeNames(swagger, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &generator{options: opts, componentGoNames: componentNames}, renames, nil
 }
 
 // Uses the Go templating engine to generate all of our server wrappers from
 // the descriptions we've built up above from the schema objects.
 // opts defines
 func Generate(swagger *openapi3.Swagger, packageName string, opts Options) (string, error) {
+	code, _, err := GenerateWithRenames(swagger, packageName, opts)
+	return code, err
+}
+
+// GenerateWithRenames is Generate, but also returns the components which had
+// to be renamed so that their Go names don't collide, see ResolveTypeNames.
+func GenerateWithRenames(swagger *openapi3.Swagger, packageName string, opts Options) (string, []TypeRename, error) {
+	// The hash is of the spec as we're given it, before we prepare it.
+	inputHash, err := InputHash(swagger, packageName, opts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Only the operations we've been asked for, and what they need
+	swagger, err = PrepareSwagger(swagger, opts)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "error preparing swagger spec")
+	}
+
+	g, renames, err := newGenerator(swagger, opts)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "error resolving type names")
+	}
+
+	// Types provided with x-go-type may need importing, the same as types
+	// from format mappings.
+	typeImports, err := goTypeImports(swagger)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "error reading x-go-type extensions")
+	}
+	for _, mapping := range opts.FormatMappings {
+		typeImports = append(typeImports, mapping)
+	}
+
 	// This creates the golang templates text package
-	t := template.New("oapi-codegen").Funcs(TemplateFunctions)
+	t := template.New("oapi-codegen").Funcs(TemplateFunctions).Funcs(g.templateFunctions())
 	// This parses all of our own template files into the template object
 	// above
-	t, err := templates.Parse(t)
+	t, err = templates.Parse(t)
 	if err != nil {
-		return "", errors.Wrap(err, "error parsing oapi-codegen templates")
+		return "", nil, errors.Wrap(err, "error parsing oapi-codegen templates")
 	}
 
-	ops, err := OperationDefinitions(swagger)
+	ops, err := g.operationDefinitions(swagger)
 	if err != nil {
-		return "", errors.Wrap(err, "error creating operation definitions")
+		return "", nil, errors.Wrap(err, "error creating operation definitions")
+	}
+
+	callbackOps, err := g.callbackOperationDefinitions(swagger)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "error creating callback operation definitions")
+	}
+
+	if opts.SplitReadWriteTypes {
+		schemaTypes, err := g.typesForSchemas(swagger.Components.Schemas)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "error generating Go types for component schemas")
+		}
+		variants := FindReadWriteVariants(schemaTypes)
+		ApplyReadWriteVariants(ops, variants)
+		ApplyReadWriteVariants(callbackOps, variants)
 	}
 
 	var typeDefinitions string
 	if opts.GenerateTypes {
-		typeDefinitions, err = GenerateTypeDefinitions(t, swagger, ops)
+		allOps := append(append([]OperationDefinition{}, ops...), callbackOps...)
+		typeDefinitions, err = g.typeDefinitions(t, swagger, allOps)
 		if err != nil {
-			return "", errors.Wrap(err, "error generating type definitions")
+			return "", nil, errors.Wrap(err, "error generating type definitions")
 		}
 	}
 
@@ -67,23 +213,55 @@ func Generate(swagger *openapi3.Swagger, packageName string, opts Options) (stri
 	if opts.GenerateServer {
 		serverOut, err = GenerateServer(t, ops)
 		if err != nil {
-			return "", errors.Wrap(err, "error generating Go handlers for Paths")
+			return "", nil, errors.Wrap(err, "error generating Go handlers for Paths")
 		}
+		// The server sends the callbacks
+		callbackClientOut, err := GenerateCallbackClient(t, callbackOps)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "error generating callback client")
+		}
+		serverOut += callbackClientOut
 	}
 
 	var clientOut string
 	if opts.GenerateClient {
 		clientOut, err = GenerateClient(t, ops)
 		if err != nil {
-			return "", errors.Wrap(err, "error generating client")
+			return "", nil, errors.Wrap(err, "error generating client")
+		}
+		// and the client receives them.
+		callbackServerOut, err := GenerateCallbackServer(t, callbackOps)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "error generating callback handlers")
 		}
+		clientOut += callbackServerOut
 	}
 
 	var clientWithResponsesOut string
 	if opts.GenerateClient {
 		clientWithResponsesOut, err = GenerateClientWithResponses(t, ops)
 		if err != nil {
-			return "", errors.Wrap(err, "error generating client with responses")
+			return "", nil, errors.Wrap(err, "error generating client with responses")
+		}
+		// The client authenticates requests as the security schemes say.
+		schemes, err := DescribeSecuritySchemes(swagger.Components.SecuritySchemes)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "error describing security schemes")
+		}
+		securityOut, err := GenerateSecurityProviders(t, schemes)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "error generating security providers")
+		}
+		clientWithResponsesOut += securityOut
+	}
+
+	// Both the client and the server look operations up in the table of
+	// their metadata.
+	var operationsOut string
+	if opts.GenerateClient || opts.GenerateServer {
+		operationsOut, err = GenerateOperationInfos(t, ops)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "error generating operation metadata")
 		}
 	}
 
@@ -91,7 +269,7 @@ func Generate(swagger *openapi3.Swagger, packageName string, opts Options) (stri
 	if opts.EmbedSpec {
 		inlinedSpec, err = GenerateInlinedSpec(t, swagger)
 		if err != nil {
-			return "", errors.Wrap(err, "error generating Go handlers for Paths")
+			return "", nil, errors.Wrap(err, "error generating Go handlers for Paths")
 		}
 	}
 
@@ -103,7 +281,7 @@ func Generate(swagger *openapi3.Swagger, packageName string, opts Options) (stri
 
 	// Based on module prefixes, figure out which optional imports are required.
 	// TODO: this is error prone, use tighter matches
-	for _, str := range []string{typeDefinitions, serverOut, clientOut, clientWithResponsesOut, inlinedSpec} {
+	for _, str := range []string{typeDefinitions, serverOut, clientOut, clientWithResponsesOut, operationsOut, inlinedSpec} {
 		if strings.Contains(str, "time.Time") {
 			imports = append(imports, "time")
 		}
@@ -149,6 +327,9 @@ func Generate(swagger *openapi3.Swagger, packageName string, opts Options) (stri
 		if strings.Contains(str, "strings.") {
 			imports = append(imports, "strings")
 		}
+		if strings.Contains(str, "strconv.") {
+			imports = append(imports, "strconv")
+		}
 		if strings.Contains(str, "fmt.") {
 			imports = append(imports, "fmt")
 		}
@@ -161,52 +342,77 @@ func Generate(swagger *openapi3.Swagger, packageName string, opts Options) (stri
 		if strings.Contains(str, "errors.") {
 			imports = append(imports, "github.com/pkg/errors")
 		}
+		if strings.Contains(str, "openapi_types.") {
+			imports = append(imports, `openapi_types "github.com/deepmap/oapi-codegen/pkg/types"`)
+		}
+		if strings.Contains(str, "sync.") {
+			imports = append(imports, "sync")
+		}
+		if strings.Contains(str, "utf8.") {
+			imports = append(imports, "unicode/utf8")
+		}
+		// The built in mapping of the uuid format is to github.com/google/uuid,
+		// but the user's own mappings, or x-go-type, may provide another uuid
+		// package, which must win, since the two can't both be imported.
+		if strings.Contains(str, "uuid.UUID") && !importsPackage(typeImports, "uuid") {
+			imports = append(imports, "github.com/google/uuid")
+		}
+		for _, mapping := range typeImports {
+			if mapping.Import != "" && strings.Contains(str, mapping.qualifier()+".") {
+				imports = append(imports, mapping.ImportSpec())
+			}
+		}
 	}
 
-	importsOut, err := GenerateImports(t, imports, packageName)
+	importsOut, err := GenerateImports(t, imports, packageName, inputHash)
 	if err != nil {
-		return "", errors.Wrap(err, "error generating imports")
+		return "", nil, errors.Wrap(err, "error generating imports")
 	}
 
 	_, err = w.WriteString(importsOut)
 	if err != nil {
-		return "", errors.Wrap(err, "error writing imports")
+		return "", nil, errors.Wrap(err, "error writing imports")
 	}
 
 	_, err = w.WriteString(typeDefinitions)
 	if err != nil {
-		return "", errors.Wrap(err, "error writing type definitions")
+		return "", nil, errors.Wrap(err, "error writing type definitions")
 
 	}
 
 	if opts.GenerateClient {
 		_, err = w.WriteString(clientOut)
 		if err != nil {
-			return "", errors.Wrap(err, "error writing client")
+			return "", nil, errors.Wrap(err, "error writing client")
 		}
 		_, err = w.WriteString(clientWithResponsesOut)
 		if err != nil {
-			return "", errors.Wrap(err, "error writing client")
+			return "", nil, errors.Wrap(err, "error writing client")
 		}
 	}
 
 	if opts.GenerateServer {
 		_, err = w.WriteString(serverOut)
 		if err != nil {
-			return "", errors.Wrap(err, "error writing server path handlers")
+			return "", nil, errors.Wrap(err, "error writing server path handlers")
 		}
 	}
 
+	_, err = w.WriteString(operationsOut)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "error writing operation metadata")
+	}
+
 	if opts.EmbedSpec {
 		_, err = w.WriteString(inlinedSpec)
 		if err != nil {
-			return "", errors.Wrap(err, "error writing inlined spec")
+			return "", nil, errors.Wrap(err, "error writing inlined spec")
 		}
 	}
 
 	err = w.Flush()
 	if err != nil {
-		return "", errors.Wrap(err, "error flushing output buffer")
+		return "", nil, errors.Wrap(err, "error flushing output buffer")
 	}
 
 	goCode := buf.String()
@@ -216,34 +422,38 @@ func Generate(swagger *openapi3.Swagger, packageName string, opts Options) (stri
 	outBytes, err := format.Source([]byte(goCode))
 	if err != nil {
 		fmt.Println(goCode)
-		return "", errors.Wrap(err, "error formatting Go code")
+		return "", nil, errors.Wrap(err, "error formatting Go code")
 	}
-	return string(outBytes), nil
+	return string(outBytes), renames, nil
 }
 
 func GenerateTypeDefinitions(t *template.Template, swagger *openapi3.Swagger, ops []OperationDefinition) (string, error) {
-	schemaTypes, err := GenerateTypesForSchemas(t, swagger.Components.Schemas)
-	if err != nil {
-		return "", errors.Wrap(err, "error generating Go types for component schemas")
-	}
+	return GenerateTypeDefinitionsWithOptions(t, swagger, ops, Options{})
+}
 
-	paramTypes, err := GenerateTypesForParameters(t, swagger.Components.Parameters)
+// GenerateTypeDefinitionsWithOptions is GenerateTypeDefinitions, but names
+// and maps types according to the given options.
+func GenerateTypeDefinitionsWithOptions(t *template.Template, swagger *openapi3.Swagger, ops []OperationDefinition, opts Options) (string, error) {
+	g, _, err := newGenerator(swagger, opts)
 	if err != nil {
-		return "", errors.Wrap(err, "error generating Go types for component parameters")
+		return "", errors.Wrap(err, "error resolving type names")
 	}
-	allTypes := append(schemaTypes, paramTypes...)
+	return g.typeDefinitions(t, swagger, ops)
+}
 
-	responseTypes, err := GenerateTypesForResponses(t, swagger.Components.Responses)
+func (g *generator) typeDefinitions(t *template.Template, swagger *openapi3.Swagger, ops []OperationDefinition) (string, error) {
+	allTypes, err := g.componentTypeDefinitions(swagger)
 	if err != nil {
-		return "", errors.Wrap(err, "error generating Go types for component responses")
+		return "", err
 	}
-	allTypes = append(allTypes, responseTypes...)
 
-	bodyTypes, err := GenerateTypesForRequestBodies(t, swagger.Components.RequestBodies)
-	if err != nil {
-		return "", errors.Wrap(err, "error generating Go types for component request bodies")
+	var opTypes []TypeDefinition
+	for _, op := range ops {
+		opTypes = append(opTypes, op.TypeDefinitions...)
+	}
+	if err := checkDuplicateTypeNames(append(allTypes, opTypes...)); err != nil {
+		return "", err
 	}
-	allTypes = append(allTypes, bodyTypes...)
 
 	paramTypesOut, err := GenerateTypesForOperations(t, ops)
 	if err != nil {
@@ -260,13 +470,64 @@ func GenerateTypeDefinitions(t *template.Template, swagger *openapi3.Swagger, op
 		return "", errors.Wrap(err, "error generating allOf boilerplate")
 	}
 
-	typeDefinitions := strings.Join([]string{typesOut, paramTypesOut, allOfBoilerplate}, "")
+	validateOut, err := GenerateValidateMethods(t, append(allTypes, opTypes...))
+	if err != nil {
+		return "", errors.Wrap(err, "error generating Validate methods")
+	}
+
+	servers, err := DescribeServers(swagger.Servers)
+	if err != nil {
+		return "", errors.Wrap(err, "error describing servers")
+	}
+	serversOut, err := GenerateServerURLs(t, servers)
+	if err != nil {
+		return "", errors.Wrap(err, "error generating server URLs")
+	}
+
+	typeDefinitions := strings.Join([]string{serversOut, typesOut, paramTypesOut, allOfBoilerplate, validateOut}, "")
 	return typeDefinitions, nil
 }
 
+// Returns the definitions of the types which we generate for the components
+// of the spec, in the order they're declared.
+func (g *generator) componentTypeDefinitions(swagger *openapi3.Swagger) ([]TypeDefinition, error) {
+	schemaTypes, err := g.typesForSchemas(swagger.Components.Schemas)
+	if err != nil {
+		return nil, errors.Wrap(err, "error generating Go types for component schemas")
+	}
+
+	if g.options.SplitReadWriteTypes {
+		variants := FindReadWriteVariants(schemaTypes)
+		schemaTypes = append(schemaTypes, GenerateReadWriteVariants(schemaTypes, variants)...)
+	}
+
+	paramTypes, err := g.typesForParameters(swagger.Components.Parameters)
+	if err != nil {
+		return nil, errors.Wrap(err, "error generating Go types for component parameters")
+	}
+	allTypes := append(schemaTypes, paramTypes...)
+
+	responseTypes, err := g.typesForResponses(swagger.Components.Responses)
+	if err != nil {
+		return nil, errors.Wrap(err, "error generating Go types for component responses")
+	}
+	allTypes = append(allTypes, responseTypes...)
+
+	bodyTypes, err := g.typesForRequestBodies(swagger.Components.RequestBodies)
+	if err != nil {
+		return nil, errors.Wrap(err, "error generating Go types for component request bodies")
+	}
+	allTypes = append(allTypes, bodyTypes...)
+	return allTypes, nil
+}
+
 // Generates type definitions for any custom types defined in the
 // components/schemas section of the Swagger spec.
 func GenerateTypesForSchemas(t *template.Template, schemas map[string]*openapi3.SchemaRef) ([]TypeDefinition, error) {
+	return defaultGenerator.typesForSchemas(schemas)
+}
+
+func (g *generator) typesForSchemas(schemas map[string]*openapi3.SchemaRef) ([]TypeDefinition, error) {
 	types := make([]TypeDefinition, 0)
 	componentType := ComponentSchemas
 
@@ -274,15 +535,22 @@ func GenerateTypesForSchemas(t *template.Template, schemas map[string]*openapi3.
 	for _, schemaName := range SortedSchemaKeys(schemas) {
 		schemaRef := schemas[schemaName]
 
-		goSchema, err := GenerateGoSchema(schemaRef, []string{schemaName}, &componentType)
+		goSchema, err := g.goSchema(schemaRef, []string{schemaName}, &componentType)
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("error converting Schema %s to Go type", schemaName))
 		}
 
+		_, isAlias, err := extGoType(schemaRef.Value.Extensions)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("error in extensions for Schema %s", schemaName))
+		}
+
 		types = append(types, TypeDefinition{
 			JsonName: schemaName,
-			TypeName: ToCamelCase(schemaName),
+			TypeName: g.componentGoName("schemas", schemaName),
 			Schema:   goSchema,
+			IsAlias:  isAlias,
+			Doc:      schemaDocumentation(schemaRef),
 		})
 
 		types = append(types, goSchema.GetAdditionalTypeDefs()...)
@@ -293,11 +561,15 @@ func GenerateTypesForSchemas(t *template.Template, schemas map[string]*openapi3.
 // Generates type definitions for any custom types defined in the
 // components/parameters section of the Swagger spec.
 func GenerateTypesForParameters(t *template.Template, params map[string]*openapi3.ParameterRef) ([]TypeDefinition, error) {
+	return defaultGenerator.typesForParameters(params)
+}
+
+func (g *generator) typesForParameters(params map[string]*openapi3.ParameterRef) ([]TypeDefinition, error) {
 	var types []TypeDefinition
 	for _, paramName := range SortedParameterKeys(params) {
 		paramOrRef := params[paramName]
 
-		goType, err := paramToGoType(paramOrRef.Value, nil)
+		goType, err := g.paramToGoType(paramOrRef.Value, nil)
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("error generating Go type for schema in parameter %s", paramName))
 		}
@@ -305,12 +577,13 @@ func GenerateTypesForParameters(t *template.Template, params map[string]*openapi
 		typeDef := TypeDefinition{
 			JsonName: paramName,
 			Schema:   goType,
-			TypeName: ToCamelCase(paramName),
+			TypeName: g.componentGoName("parameters", paramName),
+			Doc:      parameterDocumentation(paramOrRef.Value),
 		}
 
 		if paramOrRef.Ref != "" {
 			// Generate a reference type for referenced parameters
-			refType, err := RefPathToGoType(paramOrRef.Ref)
+			refType, err := g.refPathToGoType(paramOrRef.Ref)
 			if err != nil {
 				return nil, errors.Wrap(err, fmt.Sprintf("error generating Go type for (%s) in parameter %s", paramOrRef.Ref, paramName))
 			}
@@ -325,6 +598,10 @@ func GenerateTypesForParameters(t *template.Template, params map[string]*openapi
 // Generates type definitions for any custom types defined in the
 // components/responses section of the Swagger spec.
 func GenerateTypesForResponses(t *template.Template, responses openapi3.Responses) ([]TypeDefinition, error) {
+	return defaultGenerator.typesForResponses(responses)
+}
+
+func (g *generator) typesForResponses(responses openapi3.Responses) ([]TypeDefinition, error) {
 	var types []TypeDefinition
 	componentType := ComponentResponses
 
@@ -337,7 +614,7 @@ func GenerateTypesForResponses(t *template.Template, responses openapi3.Response
 		response := responseOrRef.Value
 		jsonResponse, found := response.Content["application/json"]
 		if found {
-			goType, err := GenerateGoSchema(jsonResponse.Schema, []string{responseName}, &componentType)
+			goType, err := g.goSchema(jsonResponse.Schema, []string{responseName}, &componentType)
 			if err != nil {
 				return nil, errors.Wrap(err, fmt.Sprintf("error generating Go type for schema in response %s", responseName))
 			}
@@ -345,12 +622,13 @@ func GenerateTypesForResponses(t *template.Template, responses openapi3.Response
 			typeDef := TypeDefinition{
 				JsonName: responseName,
 				Schema:   goType,
-				TypeName: ToCamelCase(responseName),
+				TypeName: g.componentGoName("responses", responseName),
+				Doc:      Documentation{Description: response.Description},
 			}
 
 			if responseOrRef.Ref != "" {
 				// Generate a reference type for referenced parameters
-				refType, err := RefPathToGoType(responseOrRef.Ref)
+				refType, err := g.refPathToGoType(responseOrRef.Ref)
 				if err != nil {
 					return nil, errors.Wrap(err, fmt.Sprintf("error generating Go type for (%s) in parameter %s", responseOrRef.Ref, responseName))
 				}
@@ -365,6 +643,10 @@ func GenerateTypesForResponses(t *template.Template, responses openapi3.Response
 // Generates type definitions for any custom types defined in the
 // components/requestBodies section of the Swagger spec.
 func GenerateTypesForRequestBodies(t *template.Template, bodies map[string]*openapi3.RequestBodyRef) ([]TypeDefinition, error) {
+	return defaultGenerator.typesForRequestBodies(bodies)
+}
+
+func (g *generator) typesForRequestBodies(bodies map[string]*openapi3.RequestBodyRef) ([]TypeDefinition, error) {
 	var types []TypeDefinition
 	componentType := ComponentRequestBodies
 
@@ -376,7 +658,7 @@ func GenerateTypesForRequestBodies(t *template.Template, bodies map[string]*open
 		response := bodyOrRef.Value
 		jsonBody, found := response.Content["application/json"]
 		if found {
-			goType, err := GenerateGoSchema(jsonBody.Schema, []string{bodyName}, &componentType)
+			goType, err := g.goSchema(jsonBody.Schema, []string{bodyName}, &componentType)
 			if err != nil {
 				return nil, errors.Wrap(err, fmt.Sprintf("error generating Go type for schema in body %s", bodyName))
 			}
@@ -384,12 +666,13 @@ func GenerateTypesForRequestBodies(t *template.Template, bodies map[string]*open
 			typeDef := TypeDefinition{
 				JsonName: bodyName,
 				Schema:   goType,
-				TypeName: ToCamelCase(bodyName),
+				TypeName: g.componentGoName("requestBodies", bodyName),
+				Doc:      Documentation{Description: response.Description},
 			}
 
 			if bodyOrRef.Ref != "" {
 				// Generate a reference type for referenced bodies
-				refType, err := RefPathToGoType(bodyOrRef.Ref)
+				refType, err := g.refPathToGoType(bodyOrRef.Ref)
 				if err != nil {
 					return nil, errors.Wrap(err, fmt.Sprintf("error generating Go type for (%s) in body %s", bodyOrRef.Ref, bodyName))
 				}
@@ -425,7 +708,14 @@ func GenerateTypes(t *template.Template, types []TypeDefinition) (string, error)
 }
 
 // Generate our import statements and package definition.
-func GenerateImports(t *template.Template, imports []string, packageName string) (string, error) {
+func GenerateImports(t *template.Template, imports []string, packageName string, inputHash string) (string, error) {
+	// Imports are either plain paths, or complete import specs, which are
+	// already quoted, and may name the package.
+	for i, imp := range imports {
+		if !strings.Contains(imp, `"`) {
+			imports[i] = strconv.Quote(imp)
+		}
+	}
 	sort.Strings(imports)
 
 	var buf bytes.Buffer
@@ -433,9 +723,13 @@ func GenerateImports(t *template.Template, imports []string, packageName string)
 	context := struct {
 		Imports     []string
 		PackageName string
+		Version     string
+		InputHash   string
 	}{
 		Imports:     imports,
 		PackageName: packageName,
+		Version:     Version,
+		InputHash:   inputHash,
 	}
 	err := t.ExecuteTemplate(w, "imports.tmpl", context)
 	if err != nil {
@@ -2,9 +2,11 @@ package codegen
 
 import (
 	"bytes"
+	"encoding/json"
 	"go/format"
 	"io/ioutil"
 	"net/http"
+	"sync"
 	"testing"
 
 	examplePetstore "github.com/deepmap/oapi-codegen/examples/petstore-expanded/api"
@@ -240,3 +242,612 @@ components:
           type: string
           enum: [car, dog, oldage]
 `
+
+func TestSplitReadWriteTypes(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(testReadWriteDefinition))
+	assert.NoError(t, err)
+
+	opts := Options{
+		GenerateClient:      true,
+		GenerateTypes:       true,
+		SplitReadWriteTypes: true,
+	}
+	code, err := Generate(swagger, "readwrite", opts)
+	assert.NoError(t, err)
+
+	// The original type is untouched.
+	assert.Contains(t, code, "type User struct {\n\tId       *string `json:\"id,omitempty\"`\n\tName     string  `json:\"name\" validate:\"required\"`\n\tPassword *string `json:\"password,omitempty\"`\n}")
+
+	// The request variant has no readOnly fields, the response variant no
+	// writeOnly ones.
+	assert.Contains(t, code, "type UserRequest struct {\n\tName     string  `json:\"name\" validate:\"required\"`\n\tPassword *string `json:\"password,omitempty\"`\n}")
+	assert.Contains(t, code, "type UserResponse struct {\n\tId   *string `json:\"id,omitempty\"`\n\tName string  `json:\"name\" validate:\"required\"`\n}")
+
+	// Types referring to types with variants get variants too.
+	assert.Contains(t, code, "type TeamRequest struct {\n\tMembers *[]UserRequest `json:\"members,omitempty\"`\n}")
+	assert.Contains(t, code, "type TeamResponse struct {\n\tMembers *[]UserResponse `json:\"members,omitempty\"`\n}")
+	assert.NotContains(t, code, "TagsRequest")
+
+	// Bodies use the request variant, responses the response variant.
+	assert.Contains(t, code, "func (c *Client) CreateUser(ctx context.Context, body UserRequest) (*http.Response, error) {")
+	assert.Contains(t, code, "JSON201      *UserResponse")
+	assert.Contains(t, code, "response.JSON201 = &UserResponse{}")
+}
+
+func TestReadWriteVariantNameCollisions(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(testReadWriteCollisionsDefinition))
+	assert.NoError(t, err)
+
+	// The variants of Pet need PetRequest, so the schema of that name gives
+	// way, as it would to any other type.
+	opts := Options{GenerateTypes: true, SplitReadWriteTypes: true}
+	names, renames, err := ResolveTypeNames(swagger, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "Pet", names["#/components/schemas/Pet"])
+	assert.Equal(t, "PetRequestSchema", names["#/components/schemas/PetRequest"])
+	if assert.Len(t, renames, 1) {
+		assert.Equal(t, "the request variant of #/components/schemas/Pet", renames[0].Owner)
+	}
+
+	// Generation reports the same renames, so callers needn't resolve the
+	// names again to warn about them.
+	code, generated, err := GenerateWithRenames(swagger, "readwrite", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, renames, generated)
+	_, modelled, err := BuildModelWithRenames(swagger, "readwrite", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, renames, modelled)
+
+	assert.Contains(t, code, "type PetRequest struct {")
+	assert.Contains(t, code, "type PetRequestSchema struct {")
+	// Owner refers to Pet, so it has variants too.
+	assert.Contains(t, code, "type OwnerResponse struct {")
+
+	// Without variants, the name is free.
+	opts.SplitReadWriteTypes = false
+	names, renames, err = ResolveTypeNames(swagger, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "PetRequest", names["#/components/schemas/PetRequest"])
+	assert.Empty(t, renames)
+}
+
+const testReadWriteCollisionsDefinition = `
+openapi: 3.0.1
+info:
+  title: readOnly and writeOnly name collisions
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      properties:
+        id:
+          type: string
+          readOnly: true
+        name:
+          type: string
+    PetRequest:
+      properties:
+        pet:
+          type: string
+    Owner:
+      properties:
+        pets:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+`
+
+const testReadWriteDefinition = `
+openapi: 3.0.1
+info:
+  title: readOnly and writeOnly test
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      operationId: createUser
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/User'
+      responses:
+        201:
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+components:
+  schemas:
+    User:
+      required: [name]
+      properties:
+        id:
+          type: string
+          readOnly: true
+        name:
+          type: string
+        password:
+          type: string
+          writeOnly: true
+    Team:
+      properties:
+        members:
+          type: array
+          items:
+            $ref: '#/components/schemas/User'
+    Tags:
+      type: array
+      items:
+        type: string
+`
+
+func TestFormatMappings(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(testFormatsDefinition))
+	assert.NoError(t, err)
+
+	opts := Options{
+		GenerateTypes: true,
+		FormatMappings: map[string]FormatMapping{
+			"money": {Type: "dec.Decimal", Import: "github.com/shopspring/decimal"},
+		},
+	}
+	code, err := Generate(swagger, "formats", opts)
+	assert.NoError(t, err)
+
+	// Built in formats
+	assert.Contains(t, code, "Id    uuid.UUID ")
+	assert.Contains(t, code, "Born  *openapi_types.Date ")
+	assert.Contains(t, code, "At    *time.Time ")
+	assert.Contains(t, code, "Mail  *openapi_types.Email ")
+	assert.Contains(t, code, "Blob  []byte ")
+	assert.Contains(t, code, "File  *openapi_types.File ")
+	assert.Contains(t, code, "Site  *string ")
+
+	// User formats apply to any type, and bring their imports along.
+	assert.Contains(t, code, "Price *dec.Decimal ")
+	assert.Contains(t, code, "Ratio *dec.Decimal ")
+	assert.Contains(t, code, `dec "github.com/shopspring/decimal"`)
+	assert.Contains(t, code, `openapi_types "github.com/deepmap/oapi-codegen/pkg/types"`)
+	assert.Contains(t, code, `"github.com/google/uuid"`)
+}
+
+func TestUUIDImports(t *testing.T) {
+	// A type from another uuid package replaces the built in one.
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(testUUIDDefinition))
+	assert.NoError(t, err)
+	code, err := Generate(swagger, "uuids", Options{GenerateTypes: true})
+	assert.NoError(t, err)
+	assert.Contains(t, code, "Id uuid.UUID ")
+	assert.Contains(t, code, `"github.com/gofrs/uuid"`)
+	assert.NotContains(t, code, "github.com/google/uuid")
+
+	// As does mapping the uuid format to it.
+	swagger, err = openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(testFormatsDefinition))
+	assert.NoError(t, err)
+	code, err = Generate(swagger, "formats", Options{
+		GenerateTypes: true,
+		FormatMappings: map[string]FormatMapping{
+			"uuid":  {Type: "uuid.UUID", Import: "github.com/gofrs/uuid"},
+			"money": {Type: "dec.Decimal", Import: "github.com/shopspring/decimal"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, code, "Id    uuid.UUID ")
+	assert.Contains(t, code, `"github.com/gofrs/uuid"`)
+	assert.NotContains(t, code, "github.com/google/uuid")
+}
+
+const testUUIDDefinition = `
+openapi: 3.0.1
+info:
+  title: UUID test
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Thing:
+      required: [id]
+      properties:
+        id:
+          type: string
+          x-go-type: uuid.UUID
+          x-go-type-import: github.com/gofrs/uuid
+`
+
+func TestConcurrentGenerate(t *testing.T) {
+	mapped := Options{
+		GenerateTypes: true,
+		FormatMappings: map[string]FormatMapping{
+			"money": {Type: "dec.Decimal", Import: "github.com/shopspring/decimal"},
+		},
+	}
+	unmapped := Options{GenerateTypes: true}
+
+	// Each run has its own options, however runs interleave.
+	var wg sync.WaitGroup
+	codes := make([]string, 8)
+	errs := make([]error, len(codes))
+	for i := range codes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(testConcurrentDefinition))
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			opts := unmapped
+			if i%2 == 0 {
+				opts = mapped
+			}
+			codes[i], errs[i] = Generate(swagger, "formats", opts)
+		}(i)
+	}
+	wg.Wait()
+	for i, code := range codes {
+		assert.NoError(t, errs[i])
+		if i%2 == 0 {
+			assert.Contains(t, code, "Price *dec.Decimal ")
+		} else {
+			assert.Contains(t, code, "Price *string ")
+			assert.NotContains(t, code, "shopspring")
+		}
+	}
+
+	// Nor does a run leave its options behind for the exported helpers.
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(testConcurrentDefinition))
+	assert.NoError(t, err)
+	schema, err := GenerateGoSchema(swagger.Components.Schemas["Invoice"].Value.Properties["price"], nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "string", schema.GoType)
+}
+
+const testConcurrentDefinition = `
+openapi: 3.0.1
+info:
+  title: Concurrency test
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Invoice:
+      properties:
+        price: {type: string, format: money}
+`
+
+const testFormatsDefinition = `
+openapi: 3.0.1
+info:
+  title: Formats test
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Thing:
+      required: [id]
+      properties:
+        id: {type: string, format: uuid}
+        born: {type: string, format: date}
+        at: {type: string, format: date-time}
+        mail: {type: string, format: email}
+        blob: {type: string, format: byte}
+        file: {type: string, format: binary}
+        price: {type: string, format: money}
+        ratio: {type: number, format: money}
+        site: {type: string, format: uri}
+`
+
+func TestVendorExtensions(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(testExtensionsDefinition))
+	assert.NoError(t, err)
+
+	code, err := Generate(swagger, "extensions", Options{GenerateTypes: true, GenerateClient: true})
+	assert.NoError(t, err)
+
+	// x-go-name renames types, references to them, fields and operations
+	assert.Contains(t, code, "type Widget struct {")
+	assert.Contains(t, code, "type PutThingJSONRequestBody Widget")
+	assert.Contains(t, code, "FetchThing(ctx context.Context, id string)")
+	assert.NotContains(t, code, "GetThing(")
+
+	// Field tags
+	assert.Contains(t, code, "Label  string   `json:\"name\" validate:\"required\" db:\"name\"`")
+	assert.Contains(t, code, "Secret *string  `json:\"-\"`")
+	assert.Contains(t, code, "Count  *int     `json:\"count\" validate:\"numeric\"`")
+
+	// x-go-type, with its import
+	assert.Contains(t, code, "type Money = decimal.Decimal")
+	assert.Contains(t, code, "Big    *big.Int `json:\"big,omitempty\"`")
+	assert.Contains(t, code, "Price  *Money   `json:\"price,omitempty\"`")
+	assert.Contains(t, code, `"github.com/shopspring/decimal"`)
+	assert.Contains(t, code, `"math/big"`)
+	assert.Contains(t, code, "type Fraction = bigmath.Rat")
+	assert.Contains(t, code, `bigmath "math/big"`)
+	// Outside of component schemas too
+	assert.Contains(t, code, "type Since civil.Date")
+	assert.Contains(t, code, `"cloud.google.com/go/civil"`)
+
+	// The generated code must compile, which at least means it has to parse.
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	// Bad extension values are reported
+	swagger.Components.Schemas["thing"].Value.Properties["count"].Value.Extensions["x-omitempty"] = json.RawMessage(`"yes"`)
+	_, err = Generate(swagger, "extensions", Options{GenerateTypes: true})
+	assert.Error(t, err)
+}
+
+func TestOperationsWithoutIDs(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(testOperationsWithoutIDsDefinition))
+	assert.NoError(t, err)
+
+	// The operations table comes with the client, too.
+	code, err := Generate(swagger, "noids", Options{GenerateTypes: true, GenerateClient: true})
+	assert.NoError(t, err)
+	assert.Contains(t, code, `"DELETE /owners": &Operations[0],`)
+	assert.Contains(t, code, `"GET /owners":    &Operations[1],`)
+	assert.NotContains(t, code, `"": &Operations`)
+	assert.Contains(t, code, "var OperationsByID = map[string]*runtime.OperationInfo{}")
+}
+
+const testOperationsWithoutIDsDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Operations without operationIds
+paths:
+  /owners:
+    get:
+      x-go-name: listOwners
+      responses:
+        '204':
+          description: ok
+    delete:
+      x-go-name: deleteOwners
+      responses:
+        '204':
+          description: ok
+`
+
+const testExtensionsDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Extensions
+paths:
+  /things/{id}:
+    get:
+      operationId: getThing
+      x-go-name: FetchThing
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/thing'
+    put:
+      operationId: putThing
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/thing'
+      responses:
+        '204':
+          description: ok
+components:
+  schemas:
+    thing:
+      x-go-name: Widget
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+          x-go-name: Label
+          x-oapi-codegen-extra-tags:
+            db: name
+        secret:
+          type: string
+          x-go-json-ignore: true
+        count:
+          type: integer
+          x-omitempty: false
+        big:
+          type: string
+          x-go-type: big.Int
+          x-go-type-import:
+            path: math/big
+        price:
+          $ref: '#/components/schemas/Money'
+    Money:
+      type: string
+      x-go-type: decimal.Decimal
+      x-go-type-import: github.com/shopspring/decimal
+    Fraction:
+      type: string
+      x-go-type: bigmath.Rat
+      x-go-type-import:
+        path: math/big
+        name: bigmath
+  parameters:
+    since:
+      name: since
+      in: query
+      schema:
+        type: string
+        x-go-type: civil.Date
+        x-go-type-import: cloud.google.com/go/civil
+`
+
+func TestTypeNameCollisions(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(testCollisionsDefinition))
+	assert.NoError(t, err)
+
+	opts := Options{GenerateTypes: true, GenerateClient: true}
+	names, renames, err := ResolveTypeNames(swagger, opts)
+	assert.NoError(t, err)
+
+	// Schemas keep their names, unless an operation needs them, and other
+	// components get a suffix.
+	assert.Equal(t, "Pet", names["#/components/schemas/Pet"])
+	assert.Equal(t, "FindPetsParamsSchema", names["#/components/schemas/FindPetsParams"])
+	assert.Equal(t, "PetParameter", names["#/components/parameters/Pet"])
+	assert.Equal(t, "PetRequestBody", names["#/components/requestBodies/Pet"])
+	// PetResponse is already a schema
+	assert.Equal(t, "PetResponse2", names["#/components/responses/Pet"])
+	assert.Len(t, renames, 4)
+
+	code, err := Generate(swagger, "collisions", opts)
+	assert.NoError(t, err)
+	assert.Contains(t, code, "type FindPetsParamsSchema struct {")
+	assert.Contains(t, code, "type FindPetsParams struct {")
+	assert.Contains(t, code, "type AddPetJSONRequestBody PetRequestBody")
+	assert.Contains(t, code, "Params *FindPetsParamsSchema `json:\"params,omitempty\"`")
+
+	// The generated code must compile, which at least means it has to parse.
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+
+	opts.StrictTypeNames = true
+	_, err = Generate(swagger, "collisions", opts)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Pet is used by both #/components/schemas/Pet and #/components/parameters/Pet")
+}
+
+const testCollisionsDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Collisions
+paths:
+  /pets:
+    get:
+      operationId: findPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          $ref: '#/components/responses/Pet'
+    post:
+      operationId: addPet
+      requestBody:
+        $ref: '#/components/requestBodies/Pet'
+      responses:
+        '204':
+          description: ok
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+    FindPetsParams:
+      type: object
+      properties:
+        x:
+          type: string
+    PetResponse:
+      type: string
+  parameters:
+    Pet:
+      name: pet
+      in: query
+      schema:
+        type: string
+  requestBodies:
+    Pet:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Pet'
+  responses:
+    Pet:
+      description: a pet
+      content:
+        application/json:
+          schema:
+            type: object
+            properties:
+              pet:
+                $ref: '#/components/schemas/Pet'
+              params:
+                $ref: '#/components/schemas/FindPetsParams'
+`
+
+func TestValidateMethods(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(testValidateDefinition))
+	assert.NoError(t, err)
+	code, err := Generate(swagger, "validate", Options{GenerateTypes: true, GenerateServer: true, ValidateParams: true})
+	assert.NoError(t, err)
+
+	assert.Contains(t, code, "func (v Bounded) Validate() error {")
+	// Types without constraints have nothing to check, and so no method,
+	// which the server wrapper mustn't call.
+	assert.NotContains(t, code, "func (v Plain) Validate() error {")
+	assert.NotContains(t, code, "func (v ListThingsParams) Validate() error {")
+	assert.NotContains(t, code, "params.Validate()")
+	_, err = format.Source([]byte(code))
+	assert.NoError(t, err)
+}
+
+const testValidateDefinition = `
+openapi: 3.0.1
+info:
+  title: Validate test
+  version: 1.0.0
+paths:
+  /things:
+    get:
+      operationId: listThings
+      parameters:
+        - name: q
+          in: query
+          schema:
+            type: string
+      responses:
+        '204':
+          description: ok
+components:
+  schemas:
+    Plain:
+      properties:
+        name: {type: string}
+    Bounded:
+      properties:
+        count: {type: integer, minimum: 1}
+`
+
+func TestGenerateValidationRules(t *testing.T) {
+	min, max := 0.5, 10.25
+	maxLength := uint64(5)
+	number := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: "number", Min: &min, Max: &max, ExclusiveMax: true}}
+	str := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: "string", Format: "uuid", MinLength: 2, MaxLength: &maxLength}}
+
+	// Bounds on numbers aren't confused with lengths, and keep their decimals.
+	assert.Equal(t, "required,numeric,gte=0.5,lt=10.25", GenerateValidationRules(number, true, "float64"))
+	assert.Equal(t, "min=2,max=5,uuid", GenerateValidationRules(str, false, "string"))
+	// Formats are only validated on strings
+	assert.Equal(t, "min=2,max=5", GenerateValidationRules(str, false, "uuid.UUID"))
+	assert.Equal(t, "", GenerateValidationRules(nil, true, "string"))
+}
@@ -0,0 +1,467 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+)
+
+// Change is a difference between two versions of a spec, which DiffSwaggers
+// finds, and whether it breaks what was built on the old one.
+type Change struct {
+	// The JSON pointer of what changed, in the new spec, or in the old one
+	// for what was removed, eg /components/schemas/Pet/properties/name
+	Pointer string `json:"pointer"`
+	Message string `json:"message"`
+	// Clients and servers of the old spec may not work with those of the new
+	// one, such as when a parameter becomes required.
+	BreaksWire bool `json:"breaksWire"`
+	// Code which uses what was generated from the old spec may not compile
+	// against what's generated from the new one, such as when a type is
+	// renamed.
+	BreaksGo bool `json:"breaksGo"`
+}
+
+// Breaking says whether the change breaks anything, on the wire or in Go.
+func (c Change) Breaking() bool {
+	return c.BreaksWire || c.BreaksGo
+}
+
+func (c Change) String() string {
+	var kind string
+	switch {
+	case c.BreaksWire && c.BreaksGo:
+		kind = "breaking (wire, go)"
+	case c.BreaksWire:
+		kind = "breaking (wire)"
+	case c.BreaksGo:
+		kind = "breaking (go)"
+	default:
+		kind = "compatible"
+	}
+	return fmt.Sprintf("%s: #%s: %s", kind, c.Pointer, c.Message)
+}
+
+// DiffSwaggers compares two versions of a spec, as they'd be generated with
+// opts, and returns what changed in their operations and component schemas,
+// in the order of their JSON pointers. It compares the Go types which the
+// generator makes of them, so it finds changes to the generated API, such as
+// renamed types, as well as changes on the wire.
+func DiffSwaggers(oldSwagger *openapi3.Swagger, newSwagger *openapi3.Swagger, opts Options) ([]Change, error) {
+	oldAPI, err := describeAPI(oldSwagger, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "error in old spec")
+	}
+	newAPI, err := describeAPI(newSwagger, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "error in new spec")
+	}
+	d := differ{old: oldAPI, new: newAPI}
+	d.operations()
+	d.components()
+	sort.SliceStable(d.changes, func(i, j int) bool {
+		return d.changes[i].Pointer < d.changes[j].Pointer
+	})
+	return d.changes, nil
+}
+
+// What the generator makes of a spec, for comparison with another version.
+type apiDescription struct {
+	operations map[string]operationDescription // By method and path, eg GET /pets/{}
+	schemas    map[string]schemaDescription    // By reference, eg #/components/schemas/Pet
+	refs       map[string]string               // The reference of each component, by Go type name
+}
+
+type operationDescription struct {
+	def     OperationDefinition
+	pointer string
+	// The types of the responses, by status and content type, eg
+	// "200 application/json"
+	responses map[string]Schema
+}
+
+type schemaDescription struct {
+	goName string
+	schema Schema
+}
+
+func describeAPI(swagger *openapi3.Swagger, opts Options) (*apiDescription, error) {
+	swagger, err := PrepareSwagger(swagger, opts)
+	if err != nil {
+		return nil, err
+	}
+	g, _, err := newGenerator(swagger, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	api := &apiDescription{
+		operations: make(map[string]operationDescription),
+		schemas:    make(map[string]schemaDescription),
+		refs:       make(map[string]string),
+	}
+	for ref, goName := range g.componentGoNames {
+		api.refs[goName] = ref
+	}
+
+	componentType := ComponentSchemas
+	for _, name := range SortedSchemaKeys(swagger.Components.Schemas) {
+		schema, err := g.goSchema(swagger.Components.Schemas[name], []string{name}, &componentType)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("error converting Schema %s to Go type", name))
+		}
+		api.schemas["#/components/schemas/"+name] = schemaDescription{
+			goName: g.componentGoName("schemas", name),
+			schema: schema,
+		}
+	}
+
+	ops, err := g.operationDefinitions(swagger)
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range ops {
+		desc := operationDescription{
+			def:       op,
+			pointer:   jsonPointer("paths", op.Path, strings.ToLower(op.Method)),
+			responses: make(map[string]Schema),
+		}
+		for _, status := range SortedResponsesKeys(op.Spec.Responses) {
+			response := op.Spec.Responses[status].Value
+			if response == nil {
+				continue
+			}
+			for _, contentType := range SortedContentKeys(response.Content) {
+				if response.Content[contentType].Schema == nil {
+					continue
+				}
+				schema, err := g.goSchema(response.Content[contentType].Schema, []string{status}, nil)
+				if err != nil {
+					return nil, errors.Wrap(err, fmt.Sprintf("Unable to determine Go type for %s.%s", op.OperationId, contentType))
+				}
+				desc.responses[status+" "+contentType] = op.responseSchema(schema)
+			}
+		}
+		// Path parameters may be renamed without changing the route.
+		api.operations[op.Method+" "+pathParamRE.ReplaceAllString(op.Path, "{}")] = desc
+	}
+	return api, nil
+}
+
+// Where a schema is used, which decides whether changing it breaks the wire.
+type schemaUse int
+
+const (
+	usedInRequests schemaUse = 1 << iota
+	usedInResponses
+	usedAnywhere = usedInRequests | usedInResponses
+)
+
+type differ struct {
+	old, new *apiDescription
+	changes  []Change
+}
+
+func (d *differ) add(pointer string, breaksWire bool, breaksGo bool, format string, args ...interface{}) {
+	d.changes = append(d.changes, Change{
+		Pointer:    pointer,
+		Message:    fmt.Sprintf(format, args...),
+		BreaksWire: breaksWire,
+		BreaksGo:   breaksGo,
+	})
+}
+
+func responseKeys(responses map[string]Schema) map[string]bool {
+	keys := make(map[string]bool)
+	for key := range responses {
+		keys[key] = true
+	}
+	return keys
+}
+
+func sortedKeys(m map[string]bool) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (d *differ) operations() {
+	keys := make(map[string]bool)
+	for key := range d.old.operations {
+		keys[key] = true
+	}
+	for key := range d.new.operations {
+		keys[key] = true
+	}
+	for _, key := range sortedKeys(keys) {
+		oldOp, inOld := d.old.operations[key]
+		newOp, inNew := d.new.operations[key]
+		switch {
+		case !inNew:
+			d.add(oldOp.pointer, true, true, "The operation %s was removed", oldOp.def.OperationId)
+		case !inOld:
+			d.add(newOp.pointer, false, false, "The operation %s was added", newOp.def.OperationId)
+		default:
+			d.operation(oldOp, newOp)
+		}
+	}
+}
+
+func (d *differ) operation(oldOp operationDescription, newOp operationDescription) {
+	pointer := newOp.pointer
+	if oldOp.def.OperationId != newOp.def.OperationId {
+		d.add(pointer, false, true, "The operation %s is generated as %s", oldOp.def.OperationId, newOp.def.OperationId)
+	}
+	d.parameters(oldOp.def.AllParams(), newOp.def.AllParams(), oldOp.def.PathParams, newOp.def.PathParams, pointer)
+
+	oldBodies := make(map[string]RequestBodyDefinition)
+	for _, body := range oldOp.def.Bodies {
+		oldBodies[body.ContentType] = body
+	}
+	for _, body := range newOp.def.Bodies {
+		bodyPointer := pointer + jsonPointer("requestBody", "content", body.ContentType, "schema")
+		oldBody, found := oldBodies[body.ContentType]
+		delete(oldBodies, body.ContentType)
+		if !found {
+			d.add(bodyPointer, false, false, "The %s request body was added", body.ContentType)
+			continue
+		}
+		d.schemas(oldBody.Schema, body.Schema, bodyPointer, usedInRequests)
+	}
+	for contentType := range oldBodies {
+		d.add(oldOp.pointer+jsonPointer("requestBody", "content", contentType), true, true,
+			"The %s request body was removed", contentType)
+	}
+	if !oldOp.def.BodyRequired && newOp.def.BodyRequired && len(newOp.def.Bodies) != 0 {
+		d.add(pointer+"/requestBody", true, false, "The request body became required")
+	}
+
+	for _, key := range sortedKeys(responseKeys(oldOp.responses)) {
+		oldSchema := oldOp.responses[key]
+		parts := strings.SplitN(key, " ", 2)
+		responsePointer := jsonPointer("responses", parts[0], "content", parts[1], "schema")
+		newSchema, found := newOp.responses[key]
+		if !found {
+			d.add(oldOp.pointer+responsePointer, true, true, "The %s response with status %s was removed", parts[1], parts[0])
+			continue
+		}
+		d.schemas(oldSchema, newSchema, pointer+responsePointer, usedInResponses)
+	}
+	for _, key := range sortedKeys(responseKeys(newOp.responses)) {
+		if _, found := oldOp.responses[key]; !found {
+			parts := strings.SplitN(key, " ", 2)
+			d.add(pointer+jsonPointer("responses", parts[0], "content", parts[1]), false, false,
+				"The %s response with status %s was added", parts[1], parts[0])
+		}
+	}
+}
+
+// Compares the parameters of two versions of an operation. Path parameters
+// are arguments of the generated functions, in the order of the path, which
+// may rename them without breaking anything, so they're compared in order.
+// The others are fields of the Params types, and compared by name.
+func (d *differ) parameters(oldParams, newParams, oldPathParams, newPathParams []ParameterDefinition, pointer string) {
+	for i, param := range newPathParams {
+		if i < len(oldPathParams) {
+			what := fmt.Sprintf("The path parameter %s", param.ParamName)
+			d.schemasDescribed(oldPathParams[i].Schema, param.Schema, pointer, usedInRequests, what)
+		}
+	}
+
+	key := func(param ParameterDefinition) string {
+		return param.In + " " + param.ParamName
+	}
+	oldByKey := make(map[string]ParameterDefinition)
+	for _, param := range oldParams {
+		if param.In != "path" {
+			oldByKey[key(param)] = param
+		}
+	}
+	for _, param := range newParams {
+		if param.In == "path" {
+			continue
+		}
+		oldParam, found := oldByKey[key(param)]
+		delete(oldByKey, key(param))
+		what := fmt.Sprintf("The %s parameter %s", param.In, param.ParamName)
+		switch {
+		case !found && param.Required:
+			d.add(pointer, true, true, "%s was added, and is required", what)
+		case !found:
+			d.add(pointer, false, false, "%s was added", what)
+		default:
+			if !oldParam.Required && param.Required {
+				d.add(pointer, true, !param.Schema.SkipOptionalPointer, "%s became required", what)
+			} else if oldParam.Required && !param.Required {
+				d.add(pointer, false, !param.Schema.SkipOptionalPointer, "%s became optional", what)
+			}
+			d.schemasDescribed(oldParam.Schema, param.Schema, pointer, usedInRequests, what)
+		}
+	}
+	var removed []string
+	for k := range oldByKey {
+		removed = append(removed, k)
+	}
+	sort.Strings(removed)
+	for _, k := range removed {
+		param := oldByKey[k]
+		d.add(pointer, false, true, "The %s parameter %s was removed", param.In, param.ParamName)
+	}
+}
+
+func (d *differ) components() {
+	keys := make(map[string]bool)
+	for ref := range d.old.schemas {
+		keys[ref] = true
+	}
+	for ref := range d.new.schemas {
+		keys[ref] = true
+	}
+	for _, ref := range sortedKeys(keys) {
+		oldSchema, inOld := d.old.schemas[ref]
+		newSchema, inNew := d.new.schemas[ref]
+		pointer := strings.TrimPrefix(ref, "#")
+		switch {
+		case !inNew:
+			d.add(pointer, false, true, "The type %s was removed", oldSchema.goName)
+		case !inOld:
+			d.add(pointer, false, false, "The type %s was added", newSchema.goName)
+		default:
+			if oldSchema.goName != newSchema.goName {
+				d.add(pointer, false, true, "The type %s was renamed %s", oldSchema.goName, newSchema.goName)
+			}
+			d.schemas(oldSchema.schema, newSchema.schema, pointer, usedAnywhere)
+		}
+	}
+}
+
+func (d *differ) schemas(oldSchema Schema, newSchema Schema, pointer string, use schemaUse) {
+	d.schemasDescribed(oldSchema, newSchema, pointer, use, "The type")
+}
+
+// Compares two versions of a schema, where what describes it in the changes,
+// eg "The query parameter limit".
+func (d *differ) schemasDescribed(oldSchema Schema, newSchema Schema, pointer string, use schemaUse, what string) {
+	// References are compared where they lead, unless they lead elsewhere.
+	if oldSchema.IsRef() || newSchema.IsRef() {
+		oldRef, newRef := d.old.refs[oldSchema.RefType], d.new.refs[newSchema.RefType]
+		if !oldSchema.IsRef() || !newSchema.IsRef() || oldRef != newRef || oldRef == "" {
+			if oldSchema.TypeDecl() != newSchema.TypeDecl() {
+				d.add(pointer, true, true, "%s changed from %s to %s", what, oldSchema.TypeDecl(), newSchema.TypeDecl())
+			}
+		}
+		return
+	}
+
+	d.enums(oldSchema, newSchema, pointer, use, what)
+
+	oldIsStruct := strings.HasPrefix(oldSchema.GoType, "struct")
+	newIsStruct := strings.HasPrefix(newSchema.GoType, "struct")
+	switch {
+	case oldSchema.ArrayType != nil && newSchema.ArrayType != nil:
+		d.schemasDescribed(*oldSchema.ArrayType, *newSchema.ArrayType, pointer+"/items", use, "The type of the items")
+	case oldIsStruct && newIsStruct:
+		d.properties(oldSchema, newSchema, pointer, use)
+	case oldSchema.GoType != newSchema.GoType:
+		d.add(pointer, true, true, "%s changed from %s to %s", what, shortType(oldSchema), shortType(newSchema))
+	}
+}
+
+// Returns the type of a schema, with structs abbreviated.
+func shortType(s Schema) string {
+	if strings.HasPrefix(s.GoType, "struct") {
+		return "an object"
+	}
+	return s.TypeDecl()
+}
+
+func (d *differ) properties(oldSchema Schema, newSchema Schema, pointer string, use schemaUse) {
+	oldProps := make(map[string]Property)
+	for _, p := range oldSchema.Properties {
+		oldProps[p.JsonFieldName] = p
+	}
+	for _, p := range newSchema.Properties {
+		propPointer := pointer + jsonPointer("properties", p.JsonFieldName)
+		oldProp, found := oldProps[p.JsonFieldName]
+		delete(oldProps, p.JsonFieldName)
+		what := fmt.Sprintf("The property %s", p.JsonFieldName)
+		if !found {
+			// Clients must send new required properties.
+			d.add(propPointer, p.Required && use&usedInRequests != 0, false, "%s was added%s", what, requiredNote(p.Required))
+			continue
+		}
+		if oldProp.GoFieldName() != p.GoFieldName() {
+			d.add(propPointer, false, true, "%s is generated as the field %s rather than %s", what, p.GoFieldName(), oldProp.GoFieldName())
+		}
+		pointerChanged := !p.Schema.SkipOptionalPointer
+		if !oldProp.Required && p.Required {
+			d.add(propPointer, use&usedInRequests != 0, pointerChanged, "%s became required", what)
+		} else if oldProp.Required && !p.Required {
+			d.add(propPointer, use&usedInResponses != 0, pointerChanged, "%s became optional", what)
+		}
+		d.schemasDescribed(oldProp.Schema, p.Schema, propPointer, use, what)
+	}
+	var removed []string
+	for name := range oldProps {
+		removed = append(removed, name)
+	}
+	sort.Strings(removed)
+	for _, name := range removed {
+		// Clients may rely on what servers used to send.
+		d.add(pointer+jsonPointer("properties", name), use&usedInResponses != 0, true, "The property %s was removed", name)
+	}
+}
+
+func requiredNote(required bool) string {
+	if required {
+		return ", and is required"
+	}
+	return ""
+}
+
+// Compares the enum values of two versions of a schema. Removing a value
+// breaks clients which send it, and servers which do, and the generated
+// constant goes with it. Adding one is compatible.
+func (d *differ) enums(oldSchema Schema, newSchema Schema, pointer string, use schemaUse, what string) {
+	if oldSchema.OAPISchema == nil || newSchema.OAPISchema == nil {
+		return
+	}
+	newValues := make(map[string]bool)
+	for _, value := range newSchema.OAPISchema.Enum {
+		newValues[fmt.Sprint(value)] = true
+	}
+	oldValues := make(map[string]bool)
+	for _, value := range oldSchema.OAPISchema.Enum {
+		oldValues[fmt.Sprint(value)] = true
+		if len(newValues) != 0 && !newValues[fmt.Sprint(value)] {
+			d.add(pointer, true, true, "%s no longer allows the value %v", what, value)
+		}
+	}
+	for _, value := range newSchema.OAPISchema.Enum {
+		if len(oldValues) != 0 && !oldValues[fmt.Sprint(value)] {
+			d.add(pointer, false, false, "%s now allows the value %v", what, value)
+		}
+	}
+	if len(oldValues) == 0 && len(newValues) != 0 {
+		d.add(pointer, use&usedInRequests != 0, false, "%s is now limited to %d values", what, len(newValues))
+	}
+}
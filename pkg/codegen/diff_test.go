@@ -0,0 +1,204 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const diffOldSpec = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Pets
+paths:
+  /pets:
+    get:
+      operationId: findPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: kind
+          in: query
+          schema:
+            type: string
+            enum: [cat, dog, bird]
+      responses:
+        '200':
+          description: The pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: The pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+    delete:
+      operationId: deletePet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '204':
+          description: Deleted
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        age:
+          type: integer
+        color:
+          type: string
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+const diffNewSpec = `
+openapi: "3.0.0"
+info:
+  version: 2.0.0
+  title: Pets
+paths:
+  /pets:
+    get:
+      operationId: findPets
+      parameters:
+        - name: limit
+          in: query
+          required: true
+          schema:
+            type: integer
+        - name: kind
+          in: query
+          schema:
+            type: string
+            enum: [cat, dog, fish]
+        - name: sort
+          in: query
+          schema:
+            type: string
+      responses:
+        '200':
+          description: The pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+  /pets/{petId}:
+    get:
+      operationId: fetchPet
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: The pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        age:
+          type: string
+        nickname:
+          type: string
+    Person:
+      x-go-name: Owner
+      type: object
+      properties:
+        name:
+          type: string
+    Owner:
+      x-go-name: Keeper
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+func TestDiffSwaggers(t *testing.T) {
+	oldSwagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(diffOldSpec))
+	require.NoError(t, err)
+	newSwagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(diffNewSpec))
+	require.NoError(t, err)
+
+	changes, err := DiffSwaggers(oldSwagger, newSwagger, Options{})
+	require.NoError(t, err)
+
+	var found []string
+	for _, change := range changes {
+		found = append(found, change.String())
+	}
+	assert.Equal(t, []string{
+		"breaking (go): #/components/schemas/Owner: The type Owner was renamed Keeper",
+		"compatible: #/components/schemas/Person: The type Owner was added",
+		"breaking (wire, go): #/components/schemas/Pet/properties/age: The property age changed from int to string",
+		"breaking (wire, go): #/components/schemas/Pet/properties/color: The property color was removed",
+		"compatible: #/components/schemas/Pet/properties/nickname: The property nickname was added",
+		"breaking (wire, go): #/paths/~1pets/get: The query parameter limit became required",
+		"breaking (wire, go): #/paths/~1pets/get: The query parameter kind no longer allows the value bird",
+		"compatible: #/paths/~1pets/get: The query parameter kind now allows the value fish",
+		"compatible: #/paths/~1pets/get: The query parameter sort was added",
+		"breaking (wire, go): #/paths/~1pets~1{id}/delete: The operation DeletePet was removed",
+		"breaking (go): #/paths/~1pets~1{petId}/get: The operation GetPet is generated as FetchPet",
+	}, found)
+
+	// Comparing a spec with itself finds nothing.
+	changes, err = DiffSwaggers(oldSwagger, oldSwagger, Options{})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
@@ -0,0 +1,137 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// The paragraph which marks deprecated items, in the form which godoc and
+// staticcheck recognize.
+const deprecatedParagraph = "Deprecated: this is marked as deprecated in the spec."
+
+// Documentation is what the spec says about a type, field or operation, which
+// we turn into its doc comment.
+type Documentation struct {
+	Description string
+	Deprecated  bool
+	Example     interface{} // Any example value, or nil
+}
+
+// Returns the paragraphs of the documentation, in the order they appear in
+// the comment: the description, then the example, then any notes, then the
+// deprecation notice, which must be the last paragraph for tools to see it.
+func (d Documentation) paragraphs(notes ...string) []string {
+	var paragraphs []string
+	if description := strings.TrimSpace(d.Description); description != "" {
+		paragraphs = append(paragraphs, description)
+	}
+	if d.Example != nil {
+		if example, err := json.Marshal(d.Example); err == nil {
+			paragraphs = append(paragraphs, "Example: "+string(example))
+		}
+	}
+	paragraphs = append(paragraphs, notes...)
+	if d.Deprecated {
+		paragraphs = append(paragraphs, deprecatedParagraph)
+	}
+	return paragraphs
+}
+
+// Comment returns the documentation as comment lines, without a trailing
+// newline, or "" if there's nothing to say.
+func (d Documentation) Comment() string {
+	return commentLines(d.paragraphs()...)
+}
+
+// Turns paragraphs of text, which may span several lines, into comment
+// lines, with an empty comment line between paragraphs.
+func commentLines(paragraphs ...string) string {
+	var lines []string
+	for i, paragraph := range paragraphs {
+		if i > 0 {
+			lines = append(lines, "//")
+		}
+		for _, line := range strings.Split(paragraph, "\n") {
+			line = strings.TrimRight(line, " \t\r")
+			if line == "" {
+				lines = append(lines, "//")
+			} else {
+				lines = append(lines, "// "+line)
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Returns the documentation of a schema. Documentation next to a $ref is
+// ignored, as with any other sibling of a reference, otherwise we'd describe
+// a field with what the referenced type says about itself. kin-openapi doesn't
+// know about deprecated schemas, but it keeps the flag with the extensions.
+func schemaDocumentation(sref *openapi3.SchemaRef) Documentation {
+	if sref == nil || sref.Ref != "" || sref.Value == nil {
+		return Documentation{}
+	}
+	deprecated, _, _ := extBool(sref.Value.Extensions, "deprecated")
+	return Documentation{
+		Description: sref.Value.Description,
+		Deprecated:  deprecated,
+		Example:     sref.Value.Example,
+	}
+}
+
+// Returns the documentation of a parameter, falling back on its schema for
+// the example.
+func parameterDocumentation(param *openapi3.Parameter) Documentation {
+	doc := Documentation{
+		Description: param.Description,
+		Deprecated:  param.Deprecated,
+		Example:     param.Example,
+	}
+	if doc.Example == nil && param.Schema != nil && param.Schema.Value != nil {
+		doc.Example = param.Schema.Value.Example
+	}
+	return doc
+}
+
+// Doc returns the documentation of the operation, other than its summary.
+func (o *OperationDefinition) Doc() Documentation {
+	return Documentation{
+		Description: o.Spec.Description,
+		Deprecated:  o.Spec.Deprecated,
+	}
+}
+
+// DocComment returns the summary, description and deprecation notice of the
+// operation as comment lines, with any notes before the deprecation notice,
+// or "" if there's nothing to say.
+func (o *OperationDefinition) DocComment(notes ...string) string {
+	var paragraphs []string
+	if summary := strings.TrimSpace(o.Summary); summary != "" {
+		paragraphs = append(paragraphs, summary)
+	}
+	return commentLines(append(paragraphs, o.Doc().paragraphs(notes...)...)...)
+}
+
+// DeprecatedComment returns the deprecation notice of the operation, for the
+// functions generated for it which don't otherwise describe it, or "".
+func (o *OperationDefinition) DeprecatedComment() string {
+	if !o.Spec.Deprecated {
+		return ""
+	}
+	return commentLines(deprecatedParagraph)
+}
@@ -0,0 +1,199 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentationComment(t *testing.T) {
+	assert.Equal(t, "", Documentation{}.Comment())
+
+	doc := Documentation{
+		Description: "The first line.\nThe second line.\n\nAnother paragraph.\n",
+		Example:     map[string]interface{}{"name": "Rex"},
+		Deprecated:  true,
+	}
+	assert.Equal(t, `// The first line.
+// The second line.
+//
+// Another paragraph.
+//
+// Example: {"name":"Rex"}
+//
+// Deprecated: this is marked as deprecated in the spec.`, doc.Comment())
+}
+
+func TestDocComments(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(testDocsDefinition))
+	assert.NoError(t, err)
+
+	code, err := Generate(swagger, "docs", Options{GenerateTypes: true, GenerateClient: true, GenerateServer: true})
+	assert.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), "", code, parser.ParseComments)
+	assert.NoError(t, err)
+
+	// Types and fields
+	assert.Contains(t, code, `// Pet defines model for Pet.
+//
+// A pet, which
+// may be a dog.
+//
+// Deprecated: this is marked as deprecated in the spec.
+type Pet struct {`)
+	assert.Contains(t, code, `	// The name of the pet.
+	//
+	// Example: "Rex"
+	Name string`)
+	assert.Contains(t, code, `	// Use name instead.
+	//
+	// Deprecated: this is marked as deprecated in the spec.
+	Nickname *string`)
+	// A reference doesn't bring along the documentation of what it refers to.
+	assert.Contains(t, code, "\tOwner    *Owner  `json:\"owner,omitempty\"`")
+
+	// Parameters
+	assert.Contains(t, code, `	// How many pets to return.
+	//
+	// Example: 20
+	Limit *json.Number`)
+
+	// Operations
+	assert.Contains(t, code, `	// List pets
+	//
+	// Lists the pets,
+	// a page at a time.
+	//
+	// (GET /pets)
+	//
+	// Deprecated: this is marked as deprecated in the spec.
+	ListPets(ctx echo.Context, params ListPetsParams) error`)
+	assert.Contains(t, code, `	// ListPets request
+	//
+	// List pets
+	//
+	// Lists the pets,
+	// a page at a time.
+	//
+	// Deprecated: this is marked as deprecated in the spec.
+	ListPets(ctx context.Context, params *ListPetsParams) (*http.Response, error)`)
+	assert.Contains(t, code, `// List pets
+//
+// Lists the pets,
+// a page at a time.
+//
+// Deprecated: this is marked as deprecated in the spec.
+func (c *Client) ListPets(`)
+	assert.Contains(t, code, `// NewListPetsRequest generates requests for ListPets
+//
+// Deprecated: this is marked as deprecated in the spec.
+func NewListPetsRequest(`)
+	assert.Contains(t, code, `// List pets
+//
+// Lists the pets,
+// a page at a time.
+//
+// ListPetsWithResponse request returning *ListPetsResponse
+//
+// Deprecated: this is marked as deprecated in the spec.
+func (c *ClientWithResponses) ListPetsWithResponse(`)
+
+	// Every client method for an operation with a body is documented.
+	assert.Contains(t, code, `	// Add a pet
+	AddPet(ctx context.Context, body Pet) (*http.Response, error)`)
+	assert.Contains(t, code, `// Add a pet
+func (c *Client) AddPetWithBody(`)
+	assert.Contains(t, code, `// Add a pet
+func (c *Client) AddPet(`)
+	assert.Contains(t, code, `// Add a pet
+//
+// AddPetWithBodyWithResponse request with arbitrary body returning *AddPetResponse
+func (c *ClientWithResponses) AddPetWithBodyWithResponse(`)
+	assert.Contains(t, code, `// Add a pet
+func (c *ClientWithResponses) AddPetWithResponse(`)
+}
+
+const testDocsDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Docs
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List pets
+      description: |
+        Lists the pets,
+        a page at a time.
+      deprecated: true
+      parameters:
+        - name: limit
+          in: query
+          description: How many pets to return.
+          example: 20
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      operationId: addPet
+      summary: Add a pet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '204':
+          description: added
+components:
+  schemas:
+    Pet:
+      description: |
+        A pet, which
+        may be a dog.
+      deprecated: true
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+          description: The name of the pet.
+          example: Rex
+        nickname:
+          type: string
+          description: Use name instead.
+          deprecated: true
+        owner:
+          $ref: '#/components/schemas/Owner'
+    Owner:
+      description: Whoever looks after a pet.
+      type: object
+      properties:
+        name:
+          type: string
+`
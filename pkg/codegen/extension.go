@@ -0,0 +1,380 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// These are the vendor extensions which we understand. They let a spec
+// override what we'd otherwise generate.
+const (
+	// Use this Go type instead of generating one for a schema
+	extPropGoType = "x-go-type"
+	// The import which provides the x-go-type, either a path, or an object
+	// with "path" and optionally "name" for the package name to use
+	extPropGoTypeImport = "x-go-type-import"
+	// Use this name for a property's field, a component's type, or an
+	// operation's functions
+	extGoName = "x-go-name"
+	// Don't marshal the property to or from JSON
+	extPropGoJsonIgnore = "x-go-json-ignore"
+	// Force omitempty on or off for the property
+	extPropOmitEmpty = "x-omitempty"
+	// Additional struct tags for the property's field, eg {db: "id"}
+	extPropExtraTags = "x-oapi-codegen-extra-tags"
+	// The webhooks which the API sends, by name, in the same form as the
+	// webhooks of OpenAPI 3.1, which 3.0 lacks
+	extWebhooks = "x-webhooks"
+)
+
+// kin-openapi hands us the raw JSON of extensions, but objects built in code
+// may contain decoded values. This decodes either into dest.
+func extDecode(value interface{}, dest interface{}) error {
+	raw, ok := value.(json.RawMessage)
+	if !ok {
+		var err error
+		raw, err = json.Marshal(value)
+		if err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(raw, dest)
+}
+
+// Returns the compact JSON of an extension, whether kin-openapi handed us the
+// raw JSON or a decoded value.
+func extJSON(value interface{}) (string, error) {
+	raw, ok := value.(json.RawMessage)
+	if !ok {
+		var err error
+		raw, err = json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Looks up a string extension. It's an error for the extension to be
+// present but not a string.
+func extString(extensions map[string]interface{}, name string) (string, bool, error) {
+	value, found := extensions[name]
+	if !found {
+		return "", false, nil
+	}
+	var str string
+	if err := extDecode(value, &str); err != nil {
+		return "", false, fmt.Errorf("invalid value for %s, expected a string: %s", name, err)
+	}
+	return str, true, nil
+}
+
+// Looks up a boolean extension.
+func extBool(extensions map[string]interface{}, name string) (bool, bool, error) {
+	value, found := extensions[name]
+	if !found {
+		return false, false, nil
+	}
+	var b bool
+	if err := extDecode(value, &b); err != nil {
+		return false, false, fmt.Errorf("invalid value for %s, expected a boolean: %s", name, err)
+	}
+	return b, true, nil
+}
+
+// Parses x-oapi-codegen-extra-tags, which maps tag names to values.
+func extExtraTags(extensions map[string]interface{}) (map[string]string, error) {
+	value, found := extensions[extPropExtraTags]
+	if !found {
+		return nil, nil
+	}
+	var tags map[string]string
+	if err := extDecode(value, &tags); err != nil {
+		return nil, fmt.Errorf("invalid value for %s, expected an object of strings: %s", extPropExtraTags, err)
+	}
+	return tags, nil
+}
+
+// Parses x-go-type along with its x-go-type-import, if any.
+func extGoType(extensions map[string]interface{}) (FormatMapping, bool, error) {
+	goType, found, err := extString(extensions, extPropGoType)
+	if err != nil || !found {
+		return FormatMapping{}, false, err
+	}
+	mapping := FormatMapping{Type: goType}
+
+	value, found := extensions[extPropGoTypeImport]
+	if !found {
+		return mapping, true, nil
+	}
+	// Either a plain path, or an object
+	var path string
+	if err := extDecode(value, &path); err == nil {
+		mapping.Import = path
+		return mapping, true, nil
+	}
+	var imp struct {
+		Path string `json:"path"`
+		Name string `json:"name"`
+	}
+	if err := extDecode(value, &imp); err != nil || imp.Path == "" {
+		return FormatMapping{}, false, fmt.Errorf("invalid value for %s, expected a path, or an object with a path", extPropGoTypeImport)
+	}
+	mapping.Import = imp.Path
+	mapping.Name = imp.Name
+	return mapping, true, nil
+}
+
+// Returns the tags from x-oapi-codegen-extra-tags formatted for a struct tag,
+// in a stable order.
+func formatExtraTags(tags map[string]string) string {
+	var out string
+	for _, name := range SortedStringKeys(tags) {
+		out += fmt.Sprintf(" %s:\"%s\"", name, tags[name])
+	}
+	return out
+}
+
+// Applies the extensions which affect how a property's struct field is
+// generated.
+func applyPropertyExtensions(p *Property, extensions map[string]interface{}) error {
+	goName, found, err := extString(extensions, extGoName)
+	if err != nil {
+		return err
+	}
+	if found {
+		p.GoName = goName
+	}
+
+	p.JsonIgnore, _, err = extBool(extensions, extPropGoJsonIgnore)
+	if err != nil {
+		return err
+	}
+
+	omitEmpty, found, err := extBool(extensions, extPropOmitEmpty)
+	if err != nil {
+		return err
+	}
+	if found {
+		p.OmitEmpty = &omitEmpty
+	}
+
+	p.ExtraTags, err = extExtraTags(extensions)
+	return err
+}
+
+// This finds the x-go-name overrides for everything under #/components, so
+// that references to the components resolve to the overridden names. The
+// result is keyed by reference path, eg #/components/schemas/Foo.
+func componentGoNames(swagger *openapi3.Swagger) (map[string]string, error) {
+	names := make(map[string]string)
+	add := func(section string, name string, extensions map[string]interface{}) error {
+		goName, found, err := extString(extensions, extGoName)
+		if err != nil {
+			return fmt.Errorf("error in #/components/%s/%s: %s", section, name, err)
+		}
+		if found {
+			names["#/components/"+section+"/"+name] = goName
+		}
+		return nil
+	}
+
+	for name, s := range swagger.Components.Schemas {
+		if s.Value != nil {
+			if err := add("schemas", name, s.Value.Extensions); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for name, p := range swagger.Components.Parameters {
+		if p.Value != nil {
+			if err := add("parameters", name, p.Value.Extensions); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for name, r := range swagger.Components.Responses {
+		if r.Value != nil {
+			if err := add("responses", name, r.Value.Extensions); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for name, b := range swagger.Components.RequestBodies {
+		if b.Value != nil {
+			if err := add("requestBodies", name, b.Value.Extensions); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return names, nil
+}
+
+// Walks every schema in the spec, and collects the x-go-type mappings which
+// need an import.
+func goTypeImports(swagger *openapi3.Swagger) ([]FormatMapping, error) {
+	var mappings []FormatMapping
+	visited := make(map[*openapi3.Schema]bool)
+
+	var walk func(sref *openapi3.SchemaRef) error
+	walk = func(sref *openapi3.SchemaRef) error {
+		if sref == nil || sref.Value == nil || visited[sref.Value] {
+			return nil
+		}
+		s := sref.Value
+		visited[s] = true
+
+		mapping, found, err := extGoType(s.Extensions)
+		if err != nil {
+			return err
+		}
+		if found && mapping.Import != "" {
+			mappings = append(mappings, mapping)
+		}
+
+		children := append([]*openapi3.SchemaRef{s.Items, s.AdditionalProperties, s.Not}, s.AllOf...)
+		children = append(children, s.AnyOf...)
+		children = append(children, s.OneOf...)
+		for _, p := range s.Properties {
+			children = append(children, p)
+		}
+		for _, child := range children {
+			if err := walk(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	walkContent := func(content openapi3.Content) error {
+		for _, mt := range content {
+			if err := walk(mt.Schema); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	walkParam := func(p *openapi3.ParameterRef) error {
+		if p == nil || p.Value == nil {
+			return nil
+		}
+		if err := walk(p.Value.Schema); err != nil {
+			return err
+		}
+		return walkContent(p.Value.Content)
+	}
+	walkHeader := func(h *openapi3.HeaderRef) error {
+		if h == nil || h.Value == nil {
+			return nil
+		}
+		return walk(h.Value.Schema)
+	}
+	walkBody := func(b *openapi3.RequestBodyRef) error {
+		if b == nil || b.Value == nil {
+			return nil
+		}
+		return walkContent(b.Value.Content)
+	}
+	walkResponse := func(r *openapi3.ResponseRef) error {
+		if r == nil || r.Value == nil {
+			return nil
+		}
+		if err := walkContent(r.Value.Content); err != nil {
+			return err
+		}
+		for _, h := range r.Value.Headers {
+			if err := walkHeader(h); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	var walkPathItem func(pathItem *openapi3.PathItem) error
+	walkPathItem = func(pathItem *openapi3.PathItem) error {
+		if pathItem == nil {
+			return nil
+		}
+		for _, p := range pathItem.Parameters {
+			if err := walkParam(p); err != nil {
+				return err
+			}
+		}
+		for _, op := range pathItem.Operations() {
+			for _, p := range op.Parameters {
+				if err := walkParam(p); err != nil {
+					return err
+				}
+			}
+			if err := walkBody(op.RequestBody); err != nil {
+				return err
+			}
+			for _, r := range op.Responses {
+				if err := walkResponse(r); err != nil {
+					return err
+				}
+			}
+			for _, cb := range op.Callbacks {
+				if cb == nil || cb.Value == nil {
+					continue
+				}
+				for _, cbPathItem := range *cb.Value {
+					if err := walkPathItem(cbPathItem); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}
+
+	for _, s := range swagger.Components.Schemas {
+		if err := walk(s); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range swagger.Components.Parameters {
+		if err := walkParam(p); err != nil {
+			return nil, err
+		}
+	}
+	for _, r := range swagger.Components.Responses {
+		if err := walkResponse(r); err != nil {
+			return nil, err
+		}
+	}
+	for _, b := range swagger.Components.RequestBodies {
+		if err := walkBody(b); err != nil {
+			return nil, err
+		}
+	}
+	for _, h := range swagger.Components.Headers {
+		if err := walkHeader(h); err != nil {
+			return nil, err
+		}
+	}
+	for _, pathItem := range swagger.Paths {
+		if err := walkPathItem(pathItem); err != nil {
+			return nil, err
+		}
+	}
+	return mappings, nil
+}
@@ -0,0 +1,302 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// OperationFilter selects the operations which we generate code for. An
+// operation is included when it matches at least one value of each Include
+// list which isn't empty, and none of the values of the Exclude lists. Paths
+// are globs, in which * matches within a path segment, and ** matches
+// anything, so "/pets/*" matches "/pets/{id}", but not "/pets/{id}/toys".
+type OperationFilter struct {
+	IncludeTags         []string
+	ExcludeTags         []string
+	IncludeOperationIDs []string
+	ExcludeOperationIDs []string
+	IncludePaths        []string
+	ExcludePaths        []string
+}
+
+// IsEmpty returns whether the filter lets every operation through.
+func (f OperationFilter) IsEmpty() bool {
+	return len(f.IncludeTags) == 0 && len(f.ExcludeTags) == 0 &&
+		len(f.IncludeOperationIDs) == 0 && len(f.ExcludeOperationIDs) == 0 &&
+		len(f.IncludePaths) == 0 && len(f.ExcludePaths) == 0
+}
+
+// Matches returns whether the operation at the given path passes the filter.
+func (f OperationFilter) Matches(requestPath string, op *openapi3.Operation) bool {
+	if len(f.IncludeTags) != 0 && !anyInArray(op.Tags, f.IncludeTags) {
+		return false
+	}
+	if len(f.IncludeOperationIDs) != 0 && !StringInArray(op.OperationID, f.IncludeOperationIDs) {
+		return false
+	}
+	if len(f.IncludePaths) != 0 && !matchesAnyGlob(requestPath, f.IncludePaths) {
+		return false
+	}
+	if anyInArray(op.Tags, f.ExcludeTags) || StringInArray(op.OperationID, f.ExcludeOperationIDs) ||
+		matchesAnyGlob(requestPath, f.ExcludePaths) {
+		return false
+	}
+	return true
+}
+
+func anyInArray(values []string, array []string) bool {
+	for _, v := range values {
+		if StringInArray(v, array) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAnyGlob(requestPath string, globs []string) bool {
+	for _, glob := range globs {
+		if globToRegexp(glob).MatchString(requestPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// Converts a path glob into an anchored regular expression.
+func globToRegexp(glob string) *regexp.Regexp {
+	var re strings.Builder
+	re.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**"):
+			re.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			re.WriteString("[^/]*")
+		case glob[i] == '?':
+			re.WriteString("[^/]")
+		default:
+			re.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	re.WriteString("$")
+	return regexp.MustCompile(re.String())
+}
+
+// FilterSwagger returns a copy of the swagger spec with only the operations
+// which pass the filter. Components which were referenced by the operations
+// that have been removed, and aren't referenced by what remains, are removed
+// too, so that we don't generate types nobody uses. Components which no
+// operation referenced in the first place are left alone. The original spec
+// isn't modified.
+func FilterSwagger(swagger *openapi3.Swagger, filter OperationFilter) *openapi3.Swagger {
+	if filter.IsEmpty() {
+		return swagger
+	}
+
+	before := newRefCollector()
+	before.paths(swagger.Paths)
+
+	filtered := *swagger
+	filtered.Paths = make(openapi3.Paths)
+	for requestPath, pathItem := range swagger.Paths {
+		newItem := *pathItem
+		kept := 0
+		for method, op := range pathItem.Operations() {
+			if filter.Matches(requestPath, op) {
+				kept++
+			} else {
+				newItem.SetOperation(method, nil)
+			}
+		}
+		if kept != 0 {
+			filtered.Paths[requestPath] = &newItem
+		}
+	}
+
+	// Whatever the remaining operations refer to is still needed, and so is
+	// everything that the components we leave alone refer to.
+	after := newRefCollector()
+	after.paths(filtered.Paths)
+	// Webhooks aren't filtered, so what they refer to is needed, too. Errors
+	// in them are reported when we generate code for them.
+	if webhooks, err := extWebhookPaths(swagger); err == nil {
+		after.paths(webhooks)
+	}
+	c := swagger.Components
+	for name, s := range c.Schemas {
+		if !before.refs["#/components/schemas/"+name] {
+			after.schema(s)
+		}
+	}
+	for name, p := range c.Parameters {
+		if !before.refs["#/components/parameters/"+name] {
+			after.parameter(p)
+		}
+	}
+	for name, b := range c.RequestBodies {
+		if !before.refs["#/components/requestBodies/"+name] {
+			after.requestBody(b)
+		}
+	}
+	for name, r := range c.Responses {
+		if !before.refs["#/components/responses/"+name] {
+			after.response(r)
+		}
+	}
+	keep := func(section string, name string) bool {
+		ref := "#/components/" + section + "/" + name
+		return after.refs[ref] || !before.refs[ref]
+	}
+
+	filtered.Components = c
+	filtered.Components.Schemas = make(map[string]*openapi3.SchemaRef)
+	for name, s := range c.Schemas {
+		if keep("schemas", name) {
+			filtered.Components.Schemas[name] = s
+		}
+	}
+	filtered.Components.Parameters = make(map[string]*openapi3.ParameterRef)
+	for name, p := range c.Parameters {
+		if keep("parameters", name) {
+			filtered.Components.Parameters[name] = p
+		}
+	}
+	filtered.Components.RequestBodies = make(map[string]*openapi3.RequestBodyRef)
+	for name, b := range c.RequestBodies {
+		if keep("requestBodies", name) {
+			filtered.Components.RequestBodies[name] = b
+		}
+	}
+	filtered.Components.Responses = make(map[string]*openapi3.ResponseRef)
+	for name, r := range c.Responses {
+		if keep("responses", name) {
+			filtered.Components.Responses[name] = r
+		}
+	}
+	return &filtered
+}
+
+// refCollector collects the reference paths of all the components which the
+// parts of a spec it's shown refer to, directly or through other components.
+type refCollector struct {
+	refs    map[string]bool
+	visited map[*openapi3.Schema]bool
+}
+
+func newRefCollector() *refCollector {
+	return &refCollector{
+		refs:    make(map[string]bool),
+		visited: make(map[*openapi3.Schema]bool),
+	}
+}
+
+func (c *refCollector) paths(paths openapi3.Paths) {
+	for _, pathItem := range paths {
+		c.parameters(pathItem.Parameters)
+		for _, op := range pathItem.Operations() {
+			c.parameters(op.Parameters)
+			if op.RequestBody != nil {
+				c.requestBody(op.RequestBody)
+			}
+			for _, r := range op.Responses {
+				c.response(r)
+			}
+			for _, cb := range op.Callbacks {
+				if cb.Ref != "" {
+					c.refs[cb.Ref] = true
+				}
+				if cb.Value != nil {
+					c.paths(openapi3.Paths(*cb.Value))
+				}
+			}
+		}
+	}
+}
+
+func (c *refCollector) schema(sref *openapi3.SchemaRef) {
+	if sref == nil {
+		return
+	}
+	if sref.Ref != "" {
+		c.refs[sref.Ref] = true
+	}
+	s := sref.Value
+	if s == nil || c.visited[s] {
+		return
+	}
+	c.visited[s] = true
+
+	c.schema(s.Items)
+	c.schema(s.AdditionalProperties)
+	c.schema(s.Not)
+	for _, list := range [][]*openapi3.SchemaRef{s.AllOf, s.AnyOf, s.OneOf} {
+		for _, child := range list {
+			c.schema(child)
+		}
+	}
+	for _, p := range s.Properties {
+		c.schema(p)
+	}
+}
+
+func (c *refCollector) content(content openapi3.Content) {
+	for _, mt := range content {
+		c.schema(mt.Schema)
+	}
+}
+
+func (c *refCollector) parameters(params openapi3.Parameters) {
+	for _, p := range params {
+		c.parameter(p)
+	}
+}
+
+func (c *refCollector) parameter(p *openapi3.ParameterRef) {
+	if p.Ref != "" {
+		c.refs[p.Ref] = true
+	}
+	if p.Value != nil {
+		c.schema(p.Value.Schema)
+		c.content(p.Value.Content)
+	}
+}
+
+func (c *refCollector) requestBody(b *openapi3.RequestBodyRef) {
+	if b.Ref != "" {
+		c.refs[b.Ref] = true
+	}
+	if b.Value != nil {
+		c.content(b.Value.Content)
+	}
+}
+
+func (c *refCollector) response(r *openapi3.ResponseRef) {
+	if r.Ref != "" {
+		c.refs[r.Ref] = true
+	}
+	if r.Value == nil {
+		return
+	}
+	c.content(r.Value.Content)
+	for _, h := range r.Value.Headers {
+		if h.Value != nil {
+			c.schema(h.Value.Schema)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationFilterMatches(t *testing.T) {
+	op := &openapi3.Operation{OperationID: "getPet", Tags: []string{"pets", "public"}}
+
+	assert.True(t, OperationFilter{}.Matches("/pets/{id}", op))
+	assert.True(t, OperationFilter{IncludeTags: []string{"admin", "pets"}}.Matches("/pets/{id}", op))
+	assert.False(t, OperationFilter{IncludeTags: []string{"admin"}}.Matches("/pets/{id}", op))
+	assert.False(t, OperationFilter{ExcludeTags: []string{"public"}}.Matches("/pets/{id}", op))
+	assert.True(t, OperationFilter{IncludeOperationIDs: []string{"getPet"}}.Matches("/pets/{id}", op))
+	assert.False(t, OperationFilter{ExcludeOperationIDs: []string{"getPet"}}.Matches("/pets/{id}", op))
+
+	// Every include list must match
+	assert.False(t, OperationFilter{
+		IncludeTags:  []string{"pets"},
+		IncludePaths: []string{"/v2/**"},
+	}.Matches("/pets/{id}", op))
+
+	// Globs
+	match := func(glob string, path string) bool {
+		return OperationFilter{IncludePaths: []string{glob}}.Matches(path, op)
+	}
+	assert.True(t, match("/pets/*", "/pets/{id}"))
+	assert.False(t, match("/pets/*", "/pets/{id}/toys"))
+	assert.True(t, match("/pets/**", "/pets/{id}/toys"))
+	assert.True(t, match("/pet?", "/pets"))
+	assert.False(t, match("/pets", "/pets/{id}"))
+	assert.False(t, OperationFilter{ExcludePaths: []string{"/pets/**"}}.Matches("/pets/{id}", op))
+}
+
+func TestFilterSwagger(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(testFilterDefinition))
+	assert.NoError(t, err)
+
+	filtered := FilterSwagger(swagger, OperationFilter{IncludeTags: []string{"pets"}})
+	assert.Len(t, filtered.Paths, 1)
+	assert.NotNil(t, filtered.Paths["/pets"].Get)
+	assert.Nil(t, filtered.Paths["/pets"].Post)
+
+	// Pet and what it refers to are still used, Order isn't, and Unused
+	// wasn't used in the first place. It still needs Address, though only
+	// Order referred to it before.
+	assert.Contains(t, filtered.Components.Schemas, "Pet")
+	assert.Contains(t, filtered.Components.Schemas, "Owner")
+	assert.NotContains(t, filtered.Components.Schemas, "Order")
+	assert.NotContains(t, filtered.Components.RequestBodies, "Order")
+	assert.Contains(t, filtered.Components.Schemas, "Unused")
+	assert.Contains(t, filtered.Components.Schemas, "Address")
+
+	// The original is untouched
+	assert.Len(t, swagger.Paths, 2)
+	assert.NotNil(t, swagger.Paths["/pets"].Post)
+	assert.Contains(t, swagger.Components.Schemas, "Order")
+
+	code, err := Generate(swagger, "filter", Options{
+		GenerateTypes:  true,
+		GenerateServer: true,
+		Filter:         OperationFilter{ExcludeTags: []string{"orders"}},
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, code, "ListPets(ctx echo.Context) error")
+	assert.NotContains(t, code, "AddOrder")
+	assert.NotContains(t, code, "type Order ")
+}
+
+const testFilterDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Filtering
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets]
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      operationId: addPet
+      tags: [admin]
+      responses:
+        '204':
+          description: ok
+  /orders:
+    post:
+      operationId: addOrder
+      tags: [orders]
+      requestBody:
+        $ref: '#/components/requestBodies/Order'
+      responses:
+        '204':
+          description: ok
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: '#/components/schemas/Owner'
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string
+    Order:
+      type: object
+      properties:
+        pet:
+          $ref: '#/components/schemas/Pet'
+        address:
+          $ref: '#/components/schemas/Address'
+    Unused:
+      type: object
+      properties:
+        address:
+          $ref: '#/components/schemas/Address'
+    Address:
+      type: string
+  requestBodies:
+    Order:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Order'
+`
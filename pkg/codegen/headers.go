@@ -0,0 +1,157 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+)
+
+// ResponseHeadersDefinition describes the headers which a response declares,
+// which the client decodes into a struct of their own, alongside the body.
+type ResponseHeadersDefinition struct {
+	ResponseName string // The status code, or "default"
+	TypeName     string // The struct for the headers, eg ListPets200ResponseHeaders
+	Headers      []ResponseHeaderDefinition
+}
+
+// ResponseHeaderDefinition describes a single response header.
+type ResponseHeaderDefinition struct {
+	HeaderName string // The name of the header, as it's sent, eg X-Rate-Limit
+	Schema     Schema
+}
+
+// Returns the name of the field for the headers in the response type, eg
+// Headers200.
+func (d ResponseHeadersDefinition) FieldName() string {
+	return "Headers" + ToCamelCase(d.ResponseName)
+}
+
+// Returns the case, for the generated switch on the status code, under which
+// a response has these headers.
+func (d ResponseHeadersDefinition) Case() string {
+	if d.ResponseName == "default" {
+		return "default"
+	}
+	return "case " + d.ResponseName
+}
+
+// Returns the name of the header's field, eg XRateLimit.
+func (h ResponseHeaderDefinition) GoName() string {
+	return ToCamelCase(h.HeaderName)
+}
+
+// Returns the name of the variable which the header is decoded into.
+func (h ResponseHeaderDefinition) GoVariableName() string {
+	name := LowercaseFirstCharacter(h.GoName())
+	if IsGoKeyword(name) {
+		name = "p" + UppercaseFirstCharacter(name)
+	}
+	return name
+}
+
+func (h ResponseHeaderDefinition) TypeDef() string {
+	return h.Schema.TypeDecl()
+}
+
+// Headers are always optional, since kin-openapi doesn't give us their
+// required flag, so they're pointers, unless the type can already be nil.
+func (h ResponseHeaderDefinition) IndirectOptional() bool {
+	return !h.Schema.SkipOptionalPointer
+}
+
+// Returns the name of the struct for the headers of a response.
+func responseHeadersTypeName(operationID string, responseName string) string {
+	return operationID + ToCamelCase(responseName) + "ResponseHeaders"
+}
+
+// Returns the names of the headers which a response declares, in order.
+// Content-Type is left out, since the spec says that it's ignored.
+func responseHeaderNames(response *openapi3.Response) []string {
+	var names []string
+	for name, header := range response.Headers {
+		if header == nil || header.Value == nil || http.CanonicalHeaderKey(name) == "Content-Type" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetResponseHeadersDefinitions describes the headers of each of the
+// operation's responses which declares any, with those for specific status
+// codes before the default one, which the generated code must check last.
+func (o *OperationDefinition) GetResponseHeadersDefinitions() ([]ResponseHeadersDefinition, error) {
+	g := o.generator()
+	var specific, fallback []ResponseHeadersDefinition
+	for _, responseName := range SortedResponsesKeys(o.Spec.Responses) {
+		responseRef := o.Spec.Responses[responseName]
+		if responseRef == nil || responseRef.Value == nil {
+			continue
+		}
+		names := responseHeaderNames(responseRef.Value)
+		if len(names) == 0 {
+			continue
+		}
+		def := ResponseHeadersDefinition{
+			ResponseName: responseName,
+			TypeName:     responseHeadersTypeName(o.OperationId, responseName),
+		}
+		for _, name := range names {
+			header, err := g.describeResponseHeader(name, responseRef.Value.Headers[name], []string{def.TypeName, name})
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("error in header %s of response %s of %s", name, responseName, o.OperationId))
+			}
+			def.Headers = append(def.Headers, header)
+		}
+		if responseName == "default" {
+			fallback = append(fallback, def)
+		} else {
+			specific = append(specific, def)
+		}
+	}
+	return append(specific, fallback...), nil
+}
+
+// Describes a response header. Headers are always in the simple style, and
+// without a schema, we treat them as strings.
+func (g *generator) describeResponseHeader(name string, headerRef *openapi3.HeaderRef, path []string) (ResponseHeaderDefinition, error) {
+	def := ResponseHeaderDefinition{
+		HeaderName: name,
+		Schema:     Schema{GoType: "string"},
+	}
+	if headerRef.Value.Schema != nil {
+		componentType := ComponentResponses
+		var err error
+		def.Schema, err = g.goSchema(headerRef.Value.Schema, path, &componentType)
+		if err != nil {
+			return def, err
+		}
+	}
+	return def, nil
+}
+
+// This function is used by the template engine to describe response headers.
+func getResponseHeadersDefinitions(op *OperationDefinition) []ResponseHeadersDefinition {
+	defs, err := op.GetResponseHeadersDefinitions()
+	if err != nil {
+		panic(err)
+	}
+	return defs
+}
@@ -0,0 +1,479 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+)
+
+// Severity says how much a LintIssue matters.
+type Severity string
+
+const (
+	// The generator fails on the construct.
+	SeverityError Severity = "error"
+	// The generator handles the construct, but loses something, such as by
+	// typing it as interface{}.
+	SeverityWarning Severity = "warning"
+	// The construct is handled in a way which may be surprising.
+	SeverityInfo Severity = "info"
+)
+
+// The rules which Lint applies, with what each one is about.
+var LintRules = map[string]string{
+	"unsupported-schema":    "The generator can't turn the schema into a Go type",
+	"unsupported-ref":       "The reference can't be turned into a Go type name",
+	"missing-items":         "An array schema has no items schema",
+	"any-of":                "anyOf schemas are generated as interface{}",
+	"one-of":                "oneOf schemas are generated as interface{}",
+	"not":                   "not schemas are ignored",
+	"discriminator":         "Discriminators are ignored",
+	"untyped-schema":        "Schemas without a type or properties are generated as interface{}",
+	"missing-operation-id":  "Operations without an operationId can't be named",
+	"unsupported-parameter": "The generator can't turn the parameter into a Go type",
+	"parameter-content":     "Parameters with several content types are passed as strings",
+	"response-schema":       "Responses without a schema aren't decoded by the client",
+	"response-content-type": "Responses of other content types than JSON, YAML and XML aren't decoded by the client",
+	"type-name-collision":   "Components whose type names collide are renamed",
+}
+
+// LintIssue is a construct in the spec which the generator doesn't support,
+// or only supports in part.
+type LintIssue struct {
+	Pointer  string   `json:"pointer"` // The JSON pointer of the construct, eg /components/schemas/Pet
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"` // One of LintRules
+	Message  string   `json:"message"`
+	Fix      string   `json:"fix,omitempty"` // What to do about it, if there's anything
+}
+
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s: #%s: %s (%s)", i.Severity, i.Pointer, i.Message, i.Rule)
+}
+
+// Lint walks the spec the way Generate does, and reports each construct which
+// Generate would fail on, or would only partly support, in the order of their
+// JSON pointers. opts are the options which code would be generated with, which decide
+// what is supported, such as with SynthesizeOperationIDs.
+func Lint(swagger *openapi3.Swagger, opts Options) ([]LintIssue, error) {
+	swagger, err := PrepareSwagger(swagger, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "error preparing swagger spec")
+	}
+	l := linter{
+		gen:            &generator{options: opts},
+		schemaFailures: make(map[*openapi3.Schema]bool),
+	}
+
+	g, renames, err := newGenerator(swagger, opts)
+	if err != nil {
+		l.report(SeverityError, "", "type-name-collision", err.Error(),
+			"Give one of the components an x-go-name")
+	} else {
+		l.gen = g
+		for _, r := range renames {
+			l.report(SeverityWarning, strings.TrimPrefix(r.Ref, "#"), "type-name-collision", r.String(),
+				"Give one of the components an x-go-name")
+		}
+	}
+
+	components := swagger.Components
+	schemaType := ComponentSchemas
+	for _, name := range SortedSchemaKeys(components.Schemas) {
+		l.schema(components.Schemas[name], jsonPointer("components", "schemas", name), &schemaType)
+	}
+	for _, name := range SortedParameterKeys(components.Parameters) {
+		l.parameter(components.Parameters[name], jsonPointer("components", "parameters", name))
+	}
+	for _, name := range SortedRequestBodyKeys(components.RequestBodies) {
+		l.requestBody(components.RequestBodies[name], jsonPointer("components", "requestBodies", name))
+	}
+	for _, name := range SortedResponsesKeys(components.Responses) {
+		l.response(components.Responses[name], jsonPointer("components", "responses", name))
+	}
+	for _, name := range sortedHeaderKeys(components.Headers) {
+		l.header(components.Headers[name], jsonPointer("components", "headers", name))
+	}
+
+	for _, path := range SortedPathsKeys(swagger.Paths) {
+		l.pathItem(swagger.Paths[path], jsonPointer("paths", path), false)
+	}
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		return l.issues[i].Pointer < l.issues[j].Pointer
+	})
+	return l.issues, nil
+}
+
+type linter struct {
+	gen    *generator
+	issues []LintIssue
+	// Whether each schema we've been through, or one within it, is one which
+	// the generator fails on. A failure is only reported where it happens,
+	// and not again by each schema which contains it.
+	schemaFailures map[*openapi3.Schema]bool
+}
+
+func (l *linter) report(severity Severity, pointer string, rule string, message string, fix string) {
+	l.issues = append(l.issues, LintIssue{
+		Pointer:  pointer,
+		Severity: severity,
+		Rule:     rule,
+		Message:  message,
+		Fix:      fix,
+	})
+}
+
+// Lints a schema, and returns whether the generator fails on it.
+func (l *linter) schema(sref *openapi3.SchemaRef, pointer string, componentType *ComponentType) bool {
+	if sref == nil || sref.Value == nil {
+		return false
+	}
+	if sref.Ref != "" {
+		if _, err := l.gen.refPathToGoType(sref.Ref); err != nil {
+			l.report(SeverityError, pointer, "unsupported-ref", err.Error(),
+				"Refer to a component of this spec, or a file next to it, instead")
+			return true
+		}
+		// The referenced schema is linted where it is.
+		if strings.HasPrefix(sref.Ref, "#/") {
+			pointer = sref.Ref[1:]
+		} else {
+			pointer = sref.Ref
+		}
+		sref = &openapi3.SchemaRef{Value: sref.Value}
+	}
+	schema := sref.Value
+	if failed, done := l.schemaFailures[schema]; done {
+		return failed
+	}
+	// Schemas may refer to themselves, which we mustn't chase.
+	l.schemaFailures[schema] = false
+
+	failed := l.schemaContents(schema, pointer, componentType)
+	if !failed && !(schema.Type == "array" && schema.Items == nil) {
+		if _, err := l.gen.goSchema(sref, strings.Split(pointer[1:], "/"), componentType); err != nil {
+			l.report(SeverityError, pointer, "unsupported-schema", err.Error(), schemaFix(schema))
+			failed = true
+		}
+	}
+	l.schemaFailures[schema] = failed
+	return failed
+}
+
+// Lints what a schema contains, and returns whether the generator fails on
+// any of it.
+func (l *linter) schemaContents(schema *openapi3.Schema, pointer string, componentType *ComponentType) bool {
+	// The generator takes x-go-type as it is, without looking any further.
+	if _, found, _ := extGoType(schema.Extensions); found {
+		return false
+	}
+	if schema.AnyOf != nil {
+		l.report(SeverityWarning, pointer, "any-of", "anyOf is generated as interface{}",
+			"Give the schema an x-go-type which can hold each of the alternatives")
+		return false
+	}
+	if schema.OneOf != nil {
+		l.report(SeverityWarning, pointer, "one-of", "oneOf is generated as interface{}",
+			"Give the schema an x-go-type which can hold each of the alternatives")
+		return false
+	}
+	if schema.Not != nil {
+		l.report(SeverityWarning, pointer, "not", "not is ignored, so the type allows what it excludes",
+			"Check the value in your own code")
+	}
+	if schema.Discriminator != nil {
+		l.report(SeverityWarning, pointer+"/discriminator", "discriminator", "The discriminator is ignored", "")
+	}
+
+	failed := false
+	for i, sref := range schema.AllOf {
+		failed = l.schema(sref, pointer+"/allOf/"+strconv.Itoa(i), componentType) || failed
+	}
+	if schema.AllOf != nil {
+		return failed
+	}
+	for _, name := range SortedSchemaKeys(schema.Properties) {
+		failed = l.schema(schema.Properties[name], pointer+jsonPointer("properties", name), componentType) || failed
+	}
+	if schema.AdditionalProperties != nil {
+		failed = l.schema(schema.AdditionalProperties, pointer+"/additionalProperties", componentType) || failed
+	}
+	switch {
+	case schema.Type == "array" && schema.Items == nil:
+		l.report(SeverityError, pointer, "missing-items", "The array has no items schema",
+			"Add an items schema, which may be {} for items of any type")
+		failed = true
+	case schema.Type == "array":
+		failed = l.schema(schema.Items, pointer+"/items", componentType) || failed
+	case schema.Type == "" && len(schema.Properties) == 0 && !SchemaHasAdditionalProperties(schema):
+		l.report(SeverityInfo, pointer, "untyped-schema", "The schema has no type, so it's generated as interface{}",
+			"Give the schema a type, if it has one")
+	}
+	return failed
+}
+
+// Suggests what to do about a schema which the generator fails on.
+func schemaFix(schema *openapi3.Schema) string {
+	switch schema.Type {
+	case "integer":
+		return "Use the int32 or int64 format, or map the format to a Go type with -format-mapping"
+	case "number":
+		return "Use the float or double format, or map the format to a Go type with -format-mapping"
+	case "boolean":
+		return "Remove the format"
+	case "", "object", "array", "string":
+		return ""
+	}
+	return "Use one of the types of OpenAPI 3.0: string, number, integer, boolean, array or object"
+}
+
+func (l *linter) parameter(pref *openapi3.ParameterRef, pointer string) {
+	if pref == nil || pref.Value == nil || pref.Ref != "" {
+		return
+	}
+	param := pref.Value
+	paramType := ComponentParameters
+	failed := l.schema(param.Schema, pointer+"/schema", &paramType)
+	for _, contentType := range SortedContentKeys(param.Content) {
+		failed = l.schema(param.Content[contentType].Schema, pointer+jsonPointer("content", contentType, "schema"), &paramType) || failed
+	}
+	if len(param.Content) > 1 {
+		l.report(SeverityWarning, pointer+"/content", "parameter-content",
+			"The parameter has several content types, so it's passed as a string",
+			"Give the parameter a single content type, or a schema")
+	}
+	if !failed {
+		if _, err := l.gen.paramToGoType(param, []string{param.Name}); err != nil {
+			l.report(SeverityError, pointer, "unsupported-parameter", err.Error(),
+				"Give the parameter a schema")
+		}
+	}
+}
+
+func (l *linter) requestBody(bref *openapi3.RequestBodyRef, pointer string) {
+	if bref == nil || bref.Value == nil || bref.Ref != "" {
+		return
+	}
+	for _, contentType := range SortedContentKeys(bref.Value.Content) {
+		l.schema(bref.Value.Content[contentType].Schema, pointer+jsonPointer("content", contentType, "schema"), nil)
+	}
+}
+
+func (l *linter) response(rref *openapi3.ResponseRef, pointer string) {
+	if rref == nil || rref.Value == nil || rref.Ref != "" {
+		return
+	}
+	for _, name := range sortedHeaderKeys(rref.Value.Headers) {
+		l.header(rref.Value.Headers[name], pointer+jsonPointer("headers", name))
+	}
+	for _, contentType := range SortedContentKeys(rref.Value.Content) {
+		mediaType := rref.Value.Content[contentType]
+		contentPointer := pointer + jsonPointer("content", contentType)
+		decoded := StringInArray(contentType, contentTypesJSON) || StringInArray(contentType, contentTypesYAML) ||
+			StringInArray(contentType, contentTypesXML)
+		switch {
+		case mediaType.Schema == nil && decoded:
+			l.report(SeverityWarning, contentPointer, "response-schema",
+				"The response has no schema, so the client leaves it undecoded", "Give the response a schema")
+		case mediaType.Schema != nil && !decoded && streamKind(contentType) == "":
+			l.report(SeverityInfo, contentPointer, "response-content-type",
+				fmt.Sprintf("The client doesn't decode %s, so it only has the body as bytes", contentType), "")
+		}
+		l.schema(mediaType.Schema, contentPointer+"/schema", nil)
+	}
+}
+
+func (l *linter) header(href *openapi3.HeaderRef, pointer string) {
+	if href == nil || href.Value == nil || href.Ref != "" {
+		return
+	}
+	componentType := ComponentResponses
+	l.schema(href.Value.Schema, pointer+"/schema", &componentType)
+}
+
+// Lints the operations of a path, or of a callback, whose operations are
+// named after the callback when they have no operationId.
+func (l *linter) pathItem(pathItem *openapi3.PathItem, pointer string, callback bool) {
+	for i, param := range pathItem.Parameters {
+		l.parameter(param, pointer+"/parameters/"+strconv.Itoa(i))
+	}
+	operations := pathItem.Operations()
+	for _, method := range SortedOperationsKeys(operations) {
+		l.operation(operations[method], pointer+"/"+strings.ToLower(method), callback)
+	}
+}
+
+func (l *linter) operation(op *openapi3.Operation, pointer string, callback bool) {
+	if op.OperationID == "" && !callback {
+		if goName, _, _ := extString(op.Extensions, extGoName); goName == "" {
+			l.report(SeverityError, pointer, "missing-operation-id", "The operation has no operationId",
+				"Add an operationId, or generate with -synthesize-operation-ids")
+		}
+	}
+	for i, param := range op.Parameters {
+		l.parameter(param, pointer+"/parameters/"+strconv.Itoa(i))
+	}
+	l.requestBody(op.RequestBody, pointer+"/requestBody")
+	for _, name := range SortedResponsesKeys(op.Responses) {
+		l.response(op.Responses[name], pointer+jsonPointer("responses", name))
+	}
+	var callbackNames []string
+	for name := range op.Callbacks {
+		callbackNames = append(callbackNames, name)
+	}
+	sort.Strings(callbackNames)
+	for _, name := range callbackNames {
+		callback := op.Callbacks[name]
+		if callback == nil || callback.Value == nil {
+			continue
+		}
+		var expressions []string
+		for expression := range *callback.Value {
+			expressions = append(expressions, expression)
+		}
+		sort.Strings(expressions)
+		for _, expression := range expressions {
+			l.pathItem((*callback.Value)[expression], pointer+jsonPointer("callbacks", name, expression), true)
+		}
+	}
+}
+
+// Returns the JSON pointer of a path of keys, escaped as RFC 6901 says, eg
+// /paths/~1pets~1{id} for the keys "paths" and "/pets/{id}".
+func jsonPointer(keys ...string) string {
+	var pointer strings.Builder
+	for _, key := range keys {
+		pointer.WriteString("/")
+		pointer.WriteString(strings.Replace(strings.Replace(key, "~", "~0", -1), "/", "~1", -1))
+	}
+	return pointer.String()
+}
+
+func sortedHeaderKeys(headers map[string]*openapi3.HeaderRef) []string {
+	var keys []string
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// The SARIF 2.1.0 log which LintSARIF writes, with only what we fill in.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// LintSARIF returns the issues as a SARIF 2.1.0 log, for code scanning tools,
+// with specURI as the file they're in. SARIF locates results by line, which
+// we don't know, so each result has the JSON pointer of the construct as its
+// logical location, and any fix as its "fix" property.
+func LintSARIF(issues []LintIssue, specURI string) ([]byte, error) {
+	var ruleIDs []string
+	for id := range LintRules {
+		ruleIDs = append(ruleIDs, id)
+	}
+	sort.Strings(ruleIDs)
+	driver := sarifDriver{
+		Name:           "oapi-codegen",
+		InformationURI: "https://github.com/deepmap/oapi-codegen",
+	}
+	for _, id := range ruleIDs {
+		driver.Rules = append(driver.Rules, sarifRule{ID: id, ShortDescription: sarifMessage{Text: LintRules[id]}})
+	}
+
+	results := []sarifResult{}
+	for _, issue := range issues {
+		level := string(issue.Severity)
+		if issue.Severity == SeverityInfo {
+			level = "note"
+		}
+		result := sarifResult{
+			RuleID:  issue.Rule,
+			Level:   level,
+			Message: sarifMessage{Text: issue.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: specURI}},
+				LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: "#" + issue.Pointer}},
+			}},
+		}
+		if issue.Fix != "" {
+			result.Properties = map[string]string{"fix": issue.Fix}
+		}
+		results = append(results, result)
+	}
+
+	return json.MarshalIndent(sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}, "", "  ")
+}
@@ -0,0 +1,171 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lintSpec = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Lint
+paths:
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+    get:
+      parameters:
+        - name: filter
+          in: query
+          content:
+            application/json:
+              schema:
+                type: object
+            text/plain:
+              schema:
+                type: string
+      responses:
+        '200':
+          description: The pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+            application/xml: {}
+            text/csv:
+              schema:
+                type: string
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        age:
+          type: integer
+          format: int128
+        tags:
+          type: array
+        owner:
+          $ref: '#/components/schemas/Owner'
+        kind:
+          oneOf:
+            - type: string
+            - type: integer
+        anything: {}
+    Owner:
+      type: object
+      properties:
+        pets:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+        weight:
+          type: number
+          format: decimal
+    Named:
+      type: string
+      x-go-type: mytypes.Named
+`
+
+func TestLint(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(lintSpec))
+	require.NoError(t, err)
+
+	issues, err := Lint(swagger, Options{})
+	require.NoError(t, err)
+
+	var found []string
+	for _, issue := range issues {
+		found = append(found, issue.String())
+	}
+	assert.Equal(t, []string{
+		"error: #/components/schemas/Owner/properties/weight: invalid number format: decimal (unsupported-schema)",
+		"error: #/components/schemas/Pet/properties/age: invalid integer format: int128 (unsupported-schema)",
+		"info: #/components/schemas/Pet/properties/anything: The schema has no type, so it's generated as interface{} (untyped-schema)",
+		"warning: #/components/schemas/Pet/properties/kind: oneOf is generated as interface{} (one-of)",
+		"error: #/components/schemas/Pet/properties/tags: The array has no items schema (missing-items)",
+		"error: #/paths/~1pets~1{id}/get: The operation has no operationId (missing-operation-id)",
+		"warning: #/paths/~1pets~1{id}/get/parameters/0/content: The parameter has several content types, so it's passed as a string (parameter-content)",
+		"warning: #/paths/~1pets~1{id}/get/responses/200/content/application~1xml: The response has no schema, so the client leaves it undecoded (response-schema)",
+		"info: #/paths/~1pets~1{id}/get/responses/200/content/text~1csv: The client doesn't decode text/csv, so it only has the body as bytes (response-content-type)",
+	}, found)
+	assert.Equal(t, "Use the int32 or int64 format, or map the format to a Go type with -format-mapping", issues[1].Fix)
+
+	// What's supported depends on the options.
+	issues, err = Lint(swagger, Options{
+		SynthesizeOperationIDs: true,
+		FormatMappings:         map[string]FormatMapping{"int128": {Type: "big.Int"}},
+	})
+	require.NoError(t, err)
+	for _, issue := range issues {
+		assert.NotEqual(t, "missing-operation-id", issue.Rule)
+		assert.NotEqual(t, "/components/schemas/Pet/properties/age", issue.Pointer)
+	}
+}
+
+func TestLintSARIF(t *testing.T) {
+	issues := []LintIssue{{
+		Pointer:  "/components/schemas/Pet",
+		Severity: SeverityInfo,
+		Rule:     "untyped-schema",
+		Message:  "The schema has no type",
+		Fix:      "Give the schema a type",
+	}}
+	out, err := LintSARIF(issues, "api.yaml")
+	require.NoError(t, err)
+
+	var log struct {
+		Version string
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct{ ID string }
+				}
+			}
+			Results []struct {
+				RuleID    string
+				Level     string
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct{ URI string }
+					}
+					LogicalLocations []struct{ FullyQualifiedName string }
+				}
+				Properties map[string]string
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(out, &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	assert.Len(t, log.Runs[0].Tool.Driver.Rules, len(LintRules))
+	require.Len(t, log.Runs[0].Results, 1)
+	result := log.Runs[0].Results[0]
+	assert.Equal(t, "untyped-schema", result.RuleID)
+	assert.Equal(t, "note", result.Level)
+	assert.Equal(t, "api.yaml", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, "#/components/schemas/Pet", result.Locations[0].LogicalLocations[0].FullyQualifiedName)
+	assert.Equal(t, "Give the schema a type", result.Properties["fix"])
+}
@@ -0,0 +1,368 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+)
+
+// ModelVersion is the version of the model's schema, which is described by
+// model.schema.json. It changes when the model changes in a way which may
+// break those reading it, and not when fields are added.
+const ModelVersion = 1
+
+// Model is what we generate code from, described as JSON, so that other tools,
+// such as documentation generators, SDK wrappers or gateways, can build on
+// the same names which the generated code uses. All the Go types are as they
+// appear in the generated code, and may refer to the types in Types.
+type Model struct {
+	ModelVersion int    `json:"modelVersion"` // Always ModelVersion
+	Generator    string `json:"generator"`    // The version of oapi-codegen, see Version
+	InputHash    string `json:"inputHash"`    // See InputHash
+	PackageName  string `json:"packageName"`
+
+	Operations []ModelOperation `json:"operations"`
+	// The operations of callbacks and webhooks, see CallbackOperationDefinitions
+	Callbacks []ModelOperation `json:"callbacks"`
+	// All the named types which are generated, in the order they're declared
+	Types []ModelType `json:"types"`
+}
+
+// ModelOperation describes an operation.
+type ModelOperation struct {
+	OperationID string   `json:"operationId"` // As in the spec, eg list_pets
+	GoName      string   `json:"goName"`      // The name of handlers and client methods, eg ListPets
+	Method      string   `json:"method"`      // GET, POST, etc
+	Path        string   `json:"path"`        // The path template, eg /pets/{id}, which is empty for callbacks
+	Summary     string   `json:"summary,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Deprecated  bool     `json:"deprecated,omitempty"`
+
+	// The security requirements which apply to the operation, which are those
+	// of the spec, unless it has its own. Any one of them must be met, and
+	// an empty list means that none need to be.
+	Security []map[string][]string `json:"security"`
+
+	// Path parameters are in the order they appear in the path, which is the
+	// order they're passed to handlers and client methods in.
+	PathParams   []ModelParameter `json:"pathParams"`
+	QueryParams  []ModelParameter `json:"queryParams"`
+	HeaderParams []ModelParameter `json:"headerParams"`
+	CookieParams []ModelParameter `json:"cookieParams"`
+	// The type which holds the query, header and cookie parameters, eg
+	// ListPetsParams, if there are any.
+	ParamsType string `json:"paramsType,omitempty"`
+
+	Bodies    []ModelBody     `json:"bodies"`
+	Responses []ModelResponse `json:"responses"`
+
+	Callback *ModelCallback `json:"callback,omitempty"` // Only for callbacks and webhooks
+}
+
+// ModelParameter describes a parameter of an operation.
+type ModelParameter struct {
+	Name        string `json:"name"`   // As in the spec, eg pet_id
+	In          string `json:"in"`     // path, query, header or cookie
+	GoName      string `json:"goName"` // Which names its field in the params type, eg PetId
+	GoType      string `json:"goType"` // Without the * of optional parameters
+	Required    bool   `json:"required"`
+	Style       string `json:"style"` // The style it's serialized with, eg form
+	Explode     bool   `json:"explode"`
+	JSON        bool   `json:"json"` // Whether it's serialized as JSON, rather than styled
+	Description string `json:"description,omitempty"`
+	Deprecated  bool   `json:"deprecated,omitempty"`
+}
+
+// ModelBody describes a request body of an operation, of which there's one
+// for each content type we generate code for.
+type ModelBody struct {
+	ContentType string `json:"contentType"`
+	GoType      string `json:"goType"`
+	// The name of the type which the body is declared as, eg
+	// AddPetJSONRequestBody
+	TypeName string `json:"typeName"`
+	Required bool   `json:"required"`
+}
+
+// ModelResponse describes a response of an operation.
+type ModelResponse struct {
+	Status      string         `json:"status"` // The status code, or default
+	Description string         `json:"description,omitempty"`
+	Content     []ModelContent `json:"content"`
+}
+
+// ModelContent describes the content of a response of one content type.
+type ModelContent struct {
+	ContentType string `json:"contentType"`
+	GoType      string `json:"goType,omitempty"` // Empty when there's no schema
+	// The field of the client's response type which holds the decoded
+	// content, eg JSON200, if the client decodes it.
+	Field string `json:"field,omitempty"`
+}
+
+// ModelCallback describes the callback or webhook which an operation belongs
+// to, see CallbackDefinition.
+type ModelCallback struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression,omitempty"`
+	Trigger    string `json:"trigger,omitempty"` // Empty for webhooks
+}
+
+// ModelType describes a named type which we generate.
+type ModelType struct {
+	Name string `json:"name"`
+	// The name of the component it's generated for, if any, eg pet_owner.
+	JSONName string `json:"jsonName,omitempty"`
+	// The type it's declared as, which is a struct for objects
+	GoType string `json:"goType"`
+	// Whether it's declared as an alias, so Name = GoType
+	Alias       bool   `json:"alias,omitempty"`
+	Description string `json:"description,omitempty"`
+	Deprecated  bool   `json:"deprecated,omitempty"`
+	// The fields of struct types, in order
+	Properties []ModelProperty `json:"properties"`
+	// The type of additional properties, if the type allows them
+	AdditionalPropertiesType string `json:"additionalPropertiesType,omitempty"`
+}
+
+// ModelProperty describes a field of a struct type.
+type ModelProperty struct {
+	JSONName    string `json:"jsonName"`
+	GoName      string `json:"goName"`
+	GoType      string `json:"goType"` // Including the * of optional fields
+	Required    bool   `json:"required"`
+	ReadOnly    bool   `json:"readOnly,omitempty"`
+	WriteOnly   bool   `json:"writeOnly,omitempty"`
+	Description string `json:"description,omitempty"`
+	Deprecated  bool   `json:"deprecated,omitempty"`
+}
+
+// GenerateModel describes the code which Generate would generate for the
+// spec and options, as indented JSON, see Model. Which code is generated
+// doesn't matter, as the model describes all of it.
+func GenerateModel(swagger *openapi3.Swagger, packageName string, opts Options) (string, error) {
+	model, err := BuildModel(swagger, packageName, opts)
+	if err != nil {
+		return "", err
+	}
+	return MarshalModel(model)
+}
+
+// MarshalModel returns the model as GenerateModel does, as indented JSON.
+func MarshalModel(model *Model) (string, error) {
+	out, err := json.MarshalIndent(model, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "error marshaling model")
+	}
+	return string(out) + "\n", nil
+}
+
+// BuildModel describes the code which Generate would generate for the spec
+// and options, see Model.
+func BuildModel(swagger *openapi3.Swagger, packageName string, opts Options) (*Model, error) {
+	model, _, err := BuildModelWithRenames(swagger, packageName, opts)
+	return model, err
+}
+
+// BuildModelWithRenames is BuildModel, but also returns the components which
+// had to be renamed, as GenerateWithRenames does.
+func BuildModelWithRenames(swagger *openapi3.Swagger, packageName string, opts Options) (*Model, []TypeRename, error) {
+	inputHash, err := InputHash(swagger, packageName, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	swagger, err = PrepareSwagger(swagger, opts)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error preparing swagger spec")
+	}
+	g, renames, err := newGenerator(swagger, opts)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error resolving type names")
+	}
+
+	ops, err := g.operationDefinitions(swagger)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating operation definitions")
+	}
+	callbackOps, err := g.callbackOperationDefinitions(swagger)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating callback operation definitions")
+	}
+
+	types, err := g.componentTypeDefinitions(swagger)
+	if err != nil {
+		return nil, nil, err
+	}
+	if opts.SplitReadWriteTypes {
+		variants := FindReadWriteVariants(types)
+		ApplyReadWriteVariants(ops, variants)
+		ApplyReadWriteVariants(callbackOps, variants)
+	}
+
+	model := &Model{
+		ModelVersion: ModelVersion,
+		Generator:    Version,
+		InputHash:    inputHash,
+		PackageName:  packageName,
+		Operations:   []ModelOperation{},
+		Callbacks:    []ModelOperation{},
+	}
+	for _, op := range ops {
+		mop, err := modelOperation(op)
+		if err != nil {
+			return nil, nil, err
+		}
+		model.Operations = append(model.Operations, mop)
+		types = append(types, op.TypeDefinitions...)
+	}
+	for _, op := range callbackOps {
+		mop, err := modelOperation(op)
+		if err != nil {
+			return nil, nil, err
+		}
+		model.Callbacks = append(model.Callbacks, mop)
+		types = append(types, op.TypeDefinitions...)
+	}
+	model.Types = modelTypes(types)
+	return model, renames, nil
+}
+
+func modelOperation(op OperationDefinition) (ModelOperation, error) {
+	doc := op.Doc()
+	mop := ModelOperation{
+		OperationID:  op.Spec.OperationID,
+		GoName:       op.OperationId,
+		Method:       op.Method,
+		Path:         op.Path,
+		Summary:      op.Summary,
+		Description:  op.Spec.Description,
+		Tags:         op.Spec.Tags,
+		Deprecated:   doc.Deprecated,
+		Security:     []map[string][]string{},
+		PathParams:   modelParameters(op.PathParams),
+		QueryParams:  modelParameters(op.QueryParams),
+		HeaderParams: modelParameters(op.HeaderParams),
+		CookieParams: modelParameters(op.CookieParams),
+		Bodies:       []ModelBody{},
+		Responses:    []ModelResponse{},
+	}
+	if op.RequiresParamObject() {
+		mop.ParamsType = op.OperationId + "Params"
+	}
+	for _, requirement := range op.SecurityRequirements {
+		mop.Security = append(mop.Security, requirement)
+	}
+	for _, body := range op.Bodies {
+		mop.Bodies = append(mop.Bodies, ModelBody{
+			ContentType: body.ContentType,
+			GoType:      body.TypeDef(),
+			TypeName:    op.OperationId + body.NameTag + "RequestBody",
+			Required:    body.Required,
+		})
+	}
+
+	responses := op.Spec.Responses
+	for _, status := range SortedResponsesKeys(responses) {
+		response := responses[status].Value
+		if response == nil {
+			continue
+		}
+		mr := ModelResponse{
+			Status:      status,
+			Description: response.Description,
+			Content:     []ModelContent{},
+		}
+		for _, contentType := range SortedContentKeys(response.Content) {
+			content := ModelContent{ContentType: contentType}
+			if sref := response.Content[contentType].Schema; sref != nil {
+				schema, err := op.generator().goSchema(sref, []string{status}, nil)
+				if err != nil {
+					return ModelOperation{}, errors.Wrap(err, fmt.Sprintf("Unable to determine Go type for %s.%s", op.OperationId, contentType))
+				}
+				content.GoType = op.responseSchema(schema).TypeDecl()
+				content.Field = responseFieldName(status, contentType)
+			}
+			mr.Content = append(mr.Content, content)
+		}
+		mop.Responses = append(mop.Responses, mr)
+	}
+
+	if cb := op.Callback; cb != nil {
+		mop.Callback = &ModelCallback{
+			Name:       cb.Name,
+			Expression: cb.Expression,
+			Trigger:    cb.Trigger,
+		}
+	}
+	return mop, nil
+}
+
+func modelParameters(params []ParameterDefinition) []ModelParameter {
+	mps := []ModelParameter{}
+	for _, param := range params {
+		doc := parameterDocumentation(param.Spec)
+		mps = append(mps, ModelParameter{
+			Name:        param.ParamName,
+			In:          param.In,
+			GoName:      param.GoName(),
+			GoType:      param.TypeDef(),
+			Required:    param.Required,
+			Style:       param.Style(),
+			Explode:     param.Explode(),
+			JSON:        param.IsJson(),
+			Description: strings.TrimSpace(doc.Description),
+			Deprecated:  doc.Deprecated,
+		})
+	}
+	return mps
+}
+
+func modelTypes(types []TypeDefinition) []ModelType {
+	mts := []ModelType{}
+	for _, td := range types {
+		mt := ModelType{
+			Name:        td.TypeName,
+			JSONName:    td.JsonName,
+			GoType:      td.Schema.TypeDecl(),
+			Alias:       td.IsAlias,
+			Description: strings.TrimSpace(td.Doc.Description),
+			Deprecated:  td.Doc.Deprecated,
+			Properties:  []ModelProperty{},
+		}
+		if td.Schema.HasAdditionalProperties && td.Schema.AdditionalPropertiesType != nil {
+			mt.AdditionalPropertiesType = td.Schema.AdditionalPropertiesType.TypeDecl()
+		}
+		for _, p := range td.Schema.Properties {
+			mt.Properties = append(mt.Properties, ModelProperty{
+				JSONName:    p.JsonFieldName,
+				GoName:      p.GoFieldName(),
+				GoType:      p.GoTypeDef(),
+				Required:    p.Required,
+				ReadOnly:    p.ReadOnly,
+				WriteOnly:   p.WriteOnly,
+				Description: strings.TrimSpace(p.Doc.Description),
+				Deprecated:  p.Doc.Deprecated,
+			})
+		}
+		mts = append(mts, mt)
+	}
+	return mts
+}
@@ -0,0 +1,231 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const modelSpec = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Model
+security:
+  - apiKey: []
+paths:
+  /owners/{owner_id}/pets/{pet_id}:
+    parameters:
+      - name: pet_id
+        in: path
+        required: true
+        schema:
+          type: integer
+      - name: owner_id
+        in: path
+        required: true
+        schema:
+          type: string
+    put:
+      operationId: update_pet
+      tags: [pets]
+      parameters:
+        - name: dry_run
+          in: query
+          description: Don't change anything.
+          schema:
+            type: boolean
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '200':
+          description: The updated pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        default:
+          description: An error
+          content:
+            text/plain:
+              schema:
+                type: string
+  /pets:
+    get:
+      operationId: listPets
+      deprecated: true
+      security: []
+      responses:
+        '204':
+          description: No pets
+components:
+  schemas:
+    Pet:
+      type: object
+      description: A pet.
+      required: [name]
+      properties:
+        name:
+          type: string
+        tag:
+          type: string
+          readOnly: true
+`
+
+func TestBuildModel(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(modelSpec))
+	require.NoError(t, err)
+	opts := Options{GenerateTypes: true}
+
+	model, err := BuildModel(swagger, "api", opts)
+	require.NoError(t, err)
+
+	inputHash, err := InputHash(swagger, "api", opts)
+	require.NoError(t, err)
+	assert.Equal(t, ModelVersion, model.ModelVersion)
+	assert.Equal(t, Version, model.Generator)
+	assert.Equal(t, inputHash, model.InputHash)
+	assert.Equal(t, "api", model.PackageName)
+	assert.Empty(t, model.Callbacks)
+
+	require.Len(t, model.Operations, 2)
+	update := model.Operations[0]
+	assert.Equal(t, "update_pet", update.OperationID)
+	assert.Equal(t, "UpdatePet", update.GoName)
+	assert.Equal(t, "PUT", update.Method)
+	assert.Equal(t, "/owners/{owner_id}/pets/{pet_id}", update.Path)
+	assert.Equal(t, []string{"pets"}, update.Tags)
+	assert.Equal(t, []map[string][]string{{"apiKey": {}}}, update.Security)
+
+	// Path parameters are in the order of the path, not the spec.
+	assert.Equal(t, []ModelParameter{
+		{Name: "owner_id", In: "path", GoName: "OwnerId", GoType: "string", Required: true, Style: "simple"},
+		{Name: "pet_id", In: "path", GoName: "PetId", GoType: "json.Number", Required: true, Style: "simple"},
+	}, update.PathParams)
+	assert.Equal(t, []ModelParameter{
+		{Name: "dry_run", In: "query", GoName: "DryRun", GoType: "bool", Style: "form", Explode: true,
+			Description: "Don't change anything."},
+	}, update.QueryParams)
+	assert.Empty(t, update.HeaderParams)
+	assert.Equal(t, "UpdatePetParams", update.ParamsType)
+
+	assert.Equal(t, []ModelBody{
+		{ContentType: "application/json", GoType: "Pet", TypeName: "UpdatePetJSONRequestBody", Required: true},
+	}, update.Bodies)
+	assert.Equal(t, []ModelResponse{
+		{Status: "200", Description: "The updated pet", Content: []ModelContent{
+			{ContentType: "application/json", GoType: "Pet", Field: "JSON200"},
+		}},
+		{Status: "default", Description: "An error", Content: []ModelContent{
+			{ContentType: "text/plain", GoType: "string"},
+		}},
+	}, update.Responses)
+
+	list := model.Operations[1]
+	assert.Equal(t, "ListPets", list.GoName)
+	assert.True(t, list.Deprecated)
+	// security: [] means that no credentials are needed.
+	assert.Equal(t, []map[string][]string{}, list.Security)
+	assert.Empty(t, list.ParamsType)
+	assert.Equal(t, []ModelResponse{{Status: "204", Description: "No pets", Content: []ModelContent{}}}, list.Responses)
+
+	var typeNames []string
+	for _, mt := range model.Types {
+		typeNames = append(typeNames, mt.Name)
+	}
+	assert.Equal(t, []string{"Pet", "UpdatePetParams"}, typeNames)
+	pet := model.Types[0]
+	assert.Equal(t, "Pet", pet.JSONName)
+	assert.Equal(t, "A pet.", pet.Description)
+	assert.Equal(t, []ModelProperty{
+		{JSONName: "name", GoName: "Name", GoType: "string", Required: true},
+		{JSONName: "tag", GoName: "Tag", GoType: "*string", ReadOnly: true},
+	}, pet.Properties)
+
+	// The JSON has all the fields which the schema requires, even when
+	// they're empty.
+	out, err := GenerateModel(swagger, "api", opts)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(out), &decoded))
+	listJSON := decoded["operations"].([]interface{})[1].(map[string]interface{})
+	assert.Equal(t, []interface{}{}, listJSON["pathParams"])
+	assert.Equal(t, []interface{}{}, listJSON["security"])
+}
+
+// The schema which documents the model must describe the same fields as the
+// Go types, and require those which are always present.
+func TestModelSchema(t *testing.T) {
+	data, err := ioutil.ReadFile("model.schema.json")
+	require.NoError(t, err)
+	var schema struct {
+		jsonSchemaObject
+		Definitions map[string]jsonSchemaObject `json:"definitions"`
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	for _, c := range []struct {
+		name   string
+		object jsonSchemaObject
+		goType interface{}
+	}{
+		{"model", schema.jsonSchemaObject, Model{}},
+		{"operation", schema.Definitions["operation"], ModelOperation{}},
+		{"parameter", schema.Definitions["parameter"], ModelParameter{}},
+		{"body", schema.Definitions["body"], ModelBody{}},
+		{"response", schema.Definitions["response"], ModelResponse{}},
+		{"content", schema.Definitions["content"], ModelContent{}},
+		{"callback", schema.Definitions["callback"], ModelCallback{}},
+		{"type", schema.Definitions["type"], ModelType{}},
+		{"property", schema.Definitions["property"], ModelProperty{}},
+	} {
+		var fields, required []string
+		goType := reflect.TypeOf(c.goType)
+		for i := 0; i < goType.NumField(); i++ {
+			tag := strings.Split(goType.Field(i).Tag.Get("json"), ",")
+			fields = append(fields, tag[0])
+			if len(tag) == 1 {
+				required = append(required, tag[0])
+			}
+		}
+		var properties []string
+		for name := range c.object.Properties {
+			properties = append(properties, name)
+		}
+		sort.Strings(fields)
+		sort.Strings(required)
+		sort.Strings(properties)
+		sort.Strings(c.object.Required)
+		assert.Equal(t, fields, properties, c.name)
+		assert.Equal(t, required, c.object.Required, c.name)
+	}
+}
+
+type jsonSchemaObject struct {
+	Required   []string                   `json:"required"`
+	Properties map[string]json.RawMessage `json:"properties"`
+}
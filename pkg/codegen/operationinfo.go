@@ -0,0 +1,56 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// VendorExtensions returns the compact JSON of each of the operation's x-
+// extensions, by name.
+func (o *OperationDefinition) VendorExtensions() (map[string]string, error) {
+	extensions := make(map[string]string)
+	for name, value := range o.Spec.Extensions {
+		if !strings.HasPrefix(name, "x-") {
+			continue
+		}
+		raw, err := extJSON(value)
+		if err != nil {
+			return nil, fmt.Errorf("error in extension %s of operation %s: %s", name, o.OperationId, err)
+		}
+		extensions[name] = raw
+	}
+	return extensions, nil
+}
+
+// GenerateOperationInfos uses the template engine to generate the table of
+// the operations' metadata, which the handler wrappers stash in the context.
+func GenerateOperationInfos(t *template.Template, ops []OperationDefinition) (string, error) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+
+	err := t.ExecuteTemplate(w, "operations.tmpl", ops)
+	if err != nil {
+		return "", fmt.Errorf("error generating operation metadata: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for operation metadata: %s", err)
+	}
+	return buf.String(), nil
+}
@@ -30,6 +30,17 @@ type ParameterDefinition struct {
 	Required  bool   // Is this a required parameter?
 	Spec      *openapi3.Parameter
 	Schema    Schema
+
+	gen *generator // The generator which described the parameter
+}
+
+// Returns the generator which described the parameter, for describing the
+// types of its schema in the same way.
+func (pd ParameterDefinition) generator() *generator {
+	if pd.gen == nil {
+		return defaultGenerator
+	}
+	return pd.gen
 }
 
 // This function is here as an adapter after a large refactoring so that I don't
@@ -138,11 +149,15 @@ func (p ParameterDefinitions) FindByName(name string) *ParameterDefinition {
 // descriptors into a flat list. This makes it a lot easier to traverse the
 // data in the template engine.
 func DescribeParameters(params openapi3.Parameters, path []string) ([]ParameterDefinition, error) {
+	return defaultGenerator.describeParameters(params, path)
+}
+
+func (g *generator) describeParameters(params openapi3.Parameters, path []string) ([]ParameterDefinition, error) {
 	outParams := make([]ParameterDefinition, 0)
 	for _, paramOrRef := range params {
 		param := paramOrRef.Value
 
-		goType, err := paramToGoType(param, append(path, param.Name))
+		goType, err := g.paramToGoType(param, append(path, param.Name))
 		if err != nil {
 			return nil, fmt.Errorf("error generating type for param (%s): %s",
 				param.Name, err)
@@ -168,13 +183,14 @@ func DescribeParameters(params openapi3.Parameters, path []string) ([]ParameterD
 			Required:  param.Required,
 			Spec:      param,
 			Schema:    goType,
+			gen:       g,
 		}
 
 		// If this is a reference to a predefined type, simply use the reference
 		// name as the type. $ref: "#/components/schemas/custom_type" becomes
 		// "CustomType".
 		if paramOrRef.Ref != "" {
-			goType, err := RefPathToGoType(paramOrRef.Ref)
+			goType, err := g.refPathToGoType(paramOrRef.Ref)
 			if err != nil {
 				return nil, fmt.Errorf("error dereferencing (%s) for param (%s): %s",
 					paramOrRef.Ref, param.Name, err)
@@ -201,6 +217,30 @@ type OperationDefinition struct {
 	Method          string                  // GET, POST, DELETE, etc.
 	Path            string                  // The Swagger path for the operation, like /resource/{id}
 	Spec            *openapi3.Operation
+
+	// The security requirements of the operation, which are its own, if it
+	// has any, otherwise those of the spec. An empty list means that none
+	// are needed.
+	SecurityRequirements openapi3.SecurityRequirements
+
+	// Types which have separate request and response variants, keyed by Go
+	// type name. This is only set when generating with SplitReadWriteTypes.
+	ReadWriteVariants map[string]bool
+
+	// Set for the operations of callbacks and webhooks, which have no Path,
+	// see CallbackOperationDefinitions.
+	Callback *CallbackDefinition
+
+	gen *generator // The generator which described the operation
+}
+
+// Returns the generator which described the operation, for describing the
+// types of its responses, which templates ask for, in the same way.
+func (o *OperationDefinition) generator() *generator {
+	if o.gen == nil {
+		return defaultGenerator
+	}
+	return o.gen
 }
 
 // Returns the list of all parameters except Path parameters. Path parameters
@@ -251,6 +291,7 @@ func (o *OperationDefinition) SummaryAsComment() string {
 // response object for automatic deserialization of responses in the generated
 // Client code. See "client-with-responses.tmpl".
 func (o *OperationDefinition) GetResponseTypeDefinitions() ([]TypeDefinition, error) {
+	g := o.generator()
 	var tds []TypeDefinition
 
 	responses := o.Spec.Responses
@@ -265,22 +306,13 @@ func (o *OperationDefinition) GetResponseTypeDefinitions() ([]TypeDefinition, er
 				contentType := responseRef.Value.Content[contentTypeName]
 				// We can only generate a type if we have a schema:
 				if contentType.Schema != nil {
-					responseSchema, err := GenerateGoSchema(contentType.Schema, []string{responseName}, nil)
+					responseSchema, err := g.goSchema(contentType.Schema, []string{responseName}, nil)
 					if err != nil {
 						return nil, errors.Wrap(err, fmt.Sprintf("Unable to determine Go type for %s.%s", o.OperationId, contentTypeName))
 					}
 
-					var typeName string
-					switch {
-					case StringInArray(contentTypeName, contentTypesJSON):
-						typeName = fmt.Sprintf("JSON%s", ToCamelCase(responseName))
-					// YAML:
-					case StringInArray(contentTypeName, contentTypesYAML):
-						typeName = fmt.Sprintf("YAML%s", ToCamelCase(responseName))
-					// XML:
-					case StringInArray(contentTypeName, contentTypesXML):
-						typeName = fmt.Sprintf("XML%s", ToCamelCase(responseName))
-					default:
+					typeName := responseFieldName(responseName, contentTypeName)
+					if typeName == "" {
 						continue
 					}
 
@@ -289,12 +321,13 @@ func (o *OperationDefinition) GetResponseTypeDefinitions() ([]TypeDefinition, er
 						Schema:   responseSchema,
 					}
 					if contentType.Schema.Ref != "" {
-						refType, err := RefPathToGoType(contentType.Schema.Ref)
+						refType, err := g.refPathToGoType(contentType.Schema.Ref)
 						if err != nil {
 							return nil, errors.Wrap(err, "error dereferencing response Ref")
 						}
 						td.Schema.RefType = refType
 					}
+					td.Schema = o.responseSchema(td.Schema)
 					tds = append(tds, td)
 				}
 			}
@@ -303,6 +336,32 @@ func (o *OperationDefinition) GetResponseTypeDefinitions() ([]TypeDefinition, er
 	return tds, nil
 }
 
+// Returns the name of the field of the response type of the client with
+// responses, such as JSON200, which holds the decoded response with the given
+// status and content type, or "" if we can't decode it.
+func responseFieldName(responseName string, contentType string) string {
+	switch {
+	case StringInArray(contentType, contentTypesJSON):
+		return fmt.Sprintf("JSON%s", ToCamelCase(responseName))
+	// YAML:
+	case StringInArray(contentType, contentTypesYAML):
+		return fmt.Sprintf("YAML%s", ToCamelCase(responseName))
+	// XML:
+	case StringInArray(contentType, contentTypesXML):
+		return fmt.Sprintf("XML%s", ToCamelCase(responseName))
+	}
+	return ""
+}
+
+// Redirects a response schema to the response variants of the types it refers
+// to, if we're generating those.
+func (o *OperationDefinition) responseSchema(s Schema) Schema {
+	if o.ReadWriteVariants == nil {
+		return s
+	}
+	return projectSchema(s, o.ReadWriteVariants, responseVariantSuffix)
+}
+
 // This describes a request body
 type RequestBodyDefinition struct {
 	// Is this body required, or optional?
@@ -360,13 +419,17 @@ func FilterParameterDefinitionByType(params []ParameterDefinition, in string) []
 
 // OperationDefinitions returns all operations for a swagger definition.
 func OperationDefinitions(swagger *openapi3.Swagger) ([]OperationDefinition, error) {
+	return defaultGenerator.operationDefinitions(swagger)
+}
+
+func (g *generator) operationDefinitions(swagger *openapi3.Swagger) ([]OperationDefinition, error) {
 	var operations []OperationDefinition
 
 	for _, requestPath := range SortedPathsKeys(swagger.Paths) {
 		pathItem := swagger.Paths[requestPath]
 		// These are parameters defined for all methods on a given path. They
 		// are shared by all methods.
-		globalParams, err := DescribeParameters(pathItem.Parameters, nil)
+		globalParams, err := g.describeParameters(pathItem.Parameters, nil)
 		if err != nil {
 			return nil, fmt.Errorf("error describing global parameters for %s: %s",
 				requestPath, err)
@@ -378,13 +441,17 @@ func OperationDefinitions(swagger *openapi3.Swagger) ([]OperationDefinition, err
 			op := pathOps[opName]
 
 			// We rely on OperationID to generate function names, it's required
-			if op.OperationID == "" {
+			operationID, err := operationGoName(op)
+			if err != nil {
+				return nil, err
+			}
+			if operationID == "" {
 				return nil, fmt.Errorf("OperationId is missing on path '%s %s'", opName, requestPath)
 			}
 
 			// These are parameters defined for the specific path method that
 			// we're iterating over.
-			localParams, err := DescribeParameters(op.Parameters, []string{op.OperationID + "Params"})
+			localParams, err := g.describeParameters(op.Parameters, []string{operationID + "Params"})
 			if err != nil {
 				return nil, fmt.Errorf("error describing global parameters for %s/%s: %s",
 					opName, requestPath, err)
@@ -402,7 +469,7 @@ func OperationDefinitions(swagger *openapi3.Swagger) ([]OperationDefinition, err
 				return nil, err
 			}
 
-			bodyDefinitions, typeDefinitions, err := GenerateBodyDefinitions(op.OperationID, op.RequestBody)
+			bodyDefinitions, typeDefinitions, err := g.bodyDefinitions(operationID, op.RequestBody)
 			if err != nil {
 				return nil, errors.Wrap(err, "error generating body definitions")
 			}
@@ -412,7 +479,7 @@ func OperationDefinitions(swagger *openapi3.Swagger) ([]OperationDefinition, err
 				HeaderParams: FilterParameterDefinitionByType(allParams, "header"),
 				QueryParams:  FilterParameterDefinitionByType(allParams, "query"),
 				CookieParams: FilterParameterDefinitionByType(allParams, "cookie"),
-				OperationId:  ToCamelCase(op.OperationID),
+				OperationId:  ToCamelCase(operationID),
 				// Replace newlines in summary.
 				Summary:         op.Summary,
 				Method:          opName,
@@ -420,11 +487,17 @@ func OperationDefinitions(swagger *openapi3.Swagger) ([]OperationDefinition, err
 				Spec:            op,
 				Bodies:          bodyDefinitions,
 				TypeDefinitions: typeDefinitions,
+				gen:             g,
 			}
 
 			if op.RequestBody != nil {
 				opDef.BodyRequired = op.RequestBody.Value.Required
 			}
+			if op.Security != nil {
+				opDef.SecurityRequirements = *op.Security
+			} else {
+				opDef.SecurityRequirements = swagger.Security
+			}
 
 			// Generate all the type definitions needed for this operation
 			opDef.TypeDefinitions = append(opDef.TypeDefinitions, GenerateTypeDefsForOperation(opDef)...)
@@ -435,9 +508,26 @@ func OperationDefinitions(swagger *openapi3.Swagger) ([]OperationDefinition, err
 	return operations, nil
 }
 
+// Returns the name from which an operation's functions and types are named,
+// which is its operationId, unless x-go-name says otherwise.
+func operationGoName(op *openapi3.Operation) (string, error) {
+	goName, found, err := extString(op.Extensions, extGoName)
+	if err != nil {
+		return "", fmt.Errorf("error in extensions for operation '%s': %s", op.OperationID, err)
+	}
+	if found {
+		return goName, nil
+	}
+	return op.OperationID, nil
+}
+
 // This function turns the Swagger body definitions into a list of our body
 // definitions which will be used for code generation.
 func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBodyRef) ([]RequestBodyDefinition, []TypeDefinition, error) {
+	return defaultGenerator.bodyDefinitions(operationID, bodyOrRef)
+}
+
+func (g *generator) bodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBodyRef) ([]RequestBodyDefinition, []TypeDefinition, error) {
 	if bodyOrRef == nil {
 		return nil, nil, nil
 	}
@@ -459,7 +549,7 @@ func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBody
 		}
 
 		bodyTypeName := operationID + tag + "Body"
-		bodySchema, err := GenerateGoSchema(content.Schema, []string{bodyTypeName}, nil)
+		bodySchema, err := g.goSchema(content.Schema, []string{bodyTypeName}, nil)
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "error generating request body definition")
 		}
@@ -467,7 +557,7 @@ func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBody
 		// If the body is a pre-defined type
 		if bodyOrRef.Ref != "" {
 			// Convert the reference path to Go type
-			refType, err := RefPathToGoType(bodyOrRef.Ref)
+			refType, err := g.refPathToGoType(bodyOrRef.Ref)
 			if err != nil {
 				return nil, nil, errors.Wrap(err, fmt.Sprintf("error turning reference (%s) into a Go type", bodyOrRef.Ref))
 			}
@@ -481,6 +571,7 @@ func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi3.RequestBody
 			td := TypeDefinition{
 				TypeName: bodyTypeName,
 				Schema:   bodySchema,
+				Doc:      Documentation{Description: body.Description},
 			}
 			typeDefinitions = append(typeDefinitions, td)
 			// The body schema now is a reference to a type
@@ -544,7 +635,8 @@ func GenerateParamsTypes(op OperationDefinition) []TypeDefinition {
 			Required:       param.Required,
 			Schema:         pSchema,
 			IsRequestParam: true,
-			Validation:     GenerateValidationRules(param.Spec.Schema, param.Required),
+			Validation:     GenerateValidationRules(param.Spec.Schema, param.Required, param.Schema.GoType),
+			Doc:            parameterDocumentation(param.Spec),
 		}
 		s.Properties = append(s.Properties, prop)
 	}
@@ -619,6 +711,7 @@ func GenerateServer(t *template.Template, operations []OperationDefinition) (str
 	if err != nil {
 		return "", fmt.Errorf("Error generating handler registration: %s", err)
 	}
+
 	return strings.Join([]string{si, wrappers, register}, "\n"), nil
 }
 
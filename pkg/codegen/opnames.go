@@ -0,0 +1,114 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+var nonAlphanumericRE = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// This function derives an operation name from its method and path, so that
+// GET /pets/{id} becomes GetPetsId. Path parameters are named like any other
+// path segment.
+func SynthesizeOperationName(method, requestPath string) string {
+	name := UppercaseFirstCharacter(strings.ToLower(method))
+	words := nonAlphanumericRE.Split(requestPath, -1)
+	empty := true
+	for _, word := range words {
+		if word != "" {
+			name += UppercaseFirstCharacter(word)
+			empty = false
+		}
+	}
+	if empty {
+		name += "Root"
+	}
+	return name
+}
+
+// SynthesizeOperationIDs returns a copy of the swagger spec in which every
+// operation without an operationId, or an x-go-name, has been given an
+// operationId from SynthesizeOperationName. Names which are already taken
+// get a numeric suffix, which is handed out in path and method order, so
+// the same spec always produces the same names. The original spec isn't
+// modified.
+func SynthesizeOperationIDs(swagger *openapi3.Swagger) (*openapi3.Swagger, error) {
+	// Operations which have been named explicitly keep their names, so those
+	// are taken before we start.
+	taken := make(map[string]bool)
+	for _, requestPath := range SortedPathsKeys(swagger.Paths) {
+		pathOps := swagger.Paths[requestPath].Operations()
+		for _, method := range SortedOperationsKeys(pathOps) {
+			name, err := operationGoName(pathOps[method])
+			if err != nil {
+				return nil, err
+			}
+			if name != "" {
+				taken[ToCamelCase(name)] = true
+			}
+		}
+	}
+
+	synthesized := *swagger
+	synthesized.Paths = make(openapi3.Paths)
+	for _, requestPath := range SortedPathsKeys(swagger.Paths) {
+		pathItem := *swagger.Paths[requestPath]
+		pathOps := pathItem.Operations()
+		for _, method := range SortedOperationsKeys(pathOps) {
+			op := pathOps[method]
+			if name, _ := operationGoName(op); name != "" {
+				continue
+			}
+			base := SynthesizeOperationName(method, requestPath)
+			name := base
+			for i := 2; taken[name]; i++ {
+				name = fmt.Sprintf("%s%d", base, i)
+			}
+			taken[name] = true
+
+			newOp := *op
+			newOp.OperationID = name
+			pathItem.SetOperation(method, &newOp)
+		}
+		synthesized.Paths[requestPath] = &pathItem
+	}
+	return &synthesized, nil
+}
+
+// PrepareSwagger applies the options which change the spec itself, rather
+// than the code generated from it, which are SynthesizeOperationIDs and
+// Filter. Generate does this itself, but it's useful when inspecting a spec
+// the way Generate will see it, such as with ResolveTypeNames.
+func PrepareSwagger(swagger *openapi3.Swagger, opts Options) (*openapi3.Swagger, error) {
+	// The filter needs to see what callbacks refer to, which the loader
+	// leaves to us.
+	swagger, err := resolveCallbacks(swagger)
+	if err != nil {
+		return nil, err
+	}
+	if opts.SynthesizeOperationIDs {
+		swagger, err = SynthesizeOperationIDs(swagger)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Operations are filtered after they've been named, so that the names
+	// don't depend on the filter, and can be used in it.
+	return FilterSwagger(swagger, opts.Filter), nil
+}
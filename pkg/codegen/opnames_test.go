@@ -0,0 +1,149 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSynthesizeOperationName(t *testing.T) {
+	assert.Equal(t, "GetPetsId", SynthesizeOperationName("GET", "/pets/{id}"))
+	assert.Equal(t, "DeletePetsPetIdToys", SynthesizeOperationName("DELETE", "/pets/{pet_id}/toys"))
+	assert.Equal(t, "PostV1PetsSearch", SynthesizeOperationName("POST", "/v1/pets:search"))
+	assert.Equal(t, "GetRoot", SynthesizeOperationName("GET", "/"))
+}
+
+func TestSynthesizeOperationIDs(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(testOperationNamesDefinition))
+	assert.NoError(t, err)
+
+	// Without the option, a missing operationId is an error.
+	_, err = Generate(swagger, "opnames", Options{GenerateServer: true})
+	assert.Error(t, err)
+
+	synthesized, err := SynthesizeOperationIDs(swagger)
+	assert.NoError(t, err)
+
+	// /pets/id sorts before /pets/{id}, so it claims the name first.
+	assert.Equal(t, "GetPetsId", synthesized.Paths["/pets/id"].Get.OperationID)
+	assert.Equal(t, "GetPetsId2", synthesized.Paths["/pets/{id}"].Get.OperationID)
+	// Explicit names are kept, and win over synthesized ones.
+	assert.Equal(t, "getPetsOwner", synthesized.Paths["/pets/owner"].Get.OperationID)
+	assert.Equal(t, "PostPetsOwner2", synthesized.Paths["/pets/owner"].Post.OperationID)
+	assert.Equal(t, "", synthesized.Paths["/pets"].Get.OperationID)
+
+	// The original is untouched
+	assert.Equal(t, "", swagger.Paths["/pets/{id}"].Get.OperationID)
+
+	// Naming is deterministic
+	again, err := SynthesizeOperationIDs(swagger)
+	assert.NoError(t, err)
+	assert.Equal(t, synthesized.Paths["/pets/{id}"].Get.OperationID, again.Paths["/pets/{id}"].Get.OperationID)
+
+	code, err := Generate(swagger, "opnames", Options{GenerateServer: true, SynthesizeOperationIDs: true})
+	assert.NoError(t, err)
+	assert.Contains(t, code, "GetPetsId2(ctx echo.Context, id string) error")
+	assert.Contains(t, code, "ListAllPets(ctx echo.Context) error")
+	assert.Contains(t, code, "PostPetsOwner2(ctx echo.Context) error")
+
+	// Synthesized names may be used to filter operations.
+	code, err = Generate(swagger, "opnames", Options{
+		GenerateServer:         true,
+		SynthesizeOperationIDs: true,
+		Filter:                 OperationFilter{IncludeOperationIDs: []string{"GetPetsId2"}},
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, code, "GetPetsId2(ctx echo.Context, id string) error")
+	assert.NotContains(t, code, "ListAllPets")
+}
+
+func TestPrepareSwaggerLeavesSpec(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromFile("../../internal/test/callbacks/callbacks.yaml")
+	assert.NoError(t, err)
+	before, err := json.Marshal(swagger)
+	assert.NoError(t, err)
+	hash, err := InputHash(swagger, "callbacks", Options{})
+	assert.NoError(t, err)
+
+	prepared, err := PrepareSwagger(swagger, Options{})
+	assert.NoError(t, err)
+
+	// The prepared spec has its callbacks resolved, to the spec's components.
+	callbacks := prepared.Paths["/subscriptions"].Post.Callbacks
+	assert.NotNil(t, callbacks["onCancel"].Value)
+	event := (*callbacks["onEvent"].Value)["{$request.body#/callbackUrl}/events"].Post
+	assert.True(t, event.RequestBody.Value.Content["application/json"].Schema.Value == swagger.Components.Schemas["Event"].Value)
+
+	// but the spec we were given is as it was.
+	callbacks = swagger.Paths["/subscriptions"].Post.Callbacks
+	assert.Nil(t, callbacks["onCancel"].Value)
+	event = (*callbacks["onEvent"].Value)["{$request.body#/callbackUrl}/events"].Post
+	assert.Nil(t, event.RequestBody.Value.Content["application/json"].Schema.Value)
+	after, err := json.Marshal(swagger)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(before), string(after))
+	again, err := InputHash(swagger, "callbacks", Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, hash, again)
+}
+
+const testOperationNamesDefinition = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Operation names
+paths:
+  /pets:
+    get:
+      x-go-name: ListAllPets
+      responses:
+        '204':
+          description: ok
+  /pets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '204':
+          description: ok
+  /pets/id:
+    get:
+      responses:
+        '204':
+          description: ok
+  /pets/owner:
+    get:
+      operationId: getPetsOwner
+      responses:
+        '204':
+          description: ok
+    post:
+      responses:
+        '204':
+          description: ok
+  /owners:
+    post:
+      operationId: postPetsOwner
+      responses:
+        '204':
+          description: ok
+`
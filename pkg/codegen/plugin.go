@@ -0,0 +1,79 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// PluginPrefix is what the names of plugin executables start with. The
+// plugin named foo, which the command runs for -plugin foo, is the
+// executable oapi-codegen-gen-foo on the PATH.
+const PluginPrefix = "oapi-codegen-gen-"
+
+// PluginRequest is what a plugin reads, as JSON, from its standard input.
+type PluginRequest struct {
+	// The parameter given to the plugin on the command line, as in
+	// -plugin foo=parameter, which is up to the plugin to interpret.
+	Parameter string `json:"parameter"`
+	// The options which code is generated with.
+	Options Options `json:"options"`
+	// The model of the code, see BuildModel. Plugins should use the names in
+	// it to refer to generated code.
+	Model *Model `json:"model"`
+	// The spec, as JSON, for anything which the model doesn't describe.
+	Spec json.RawMessage `json:"spec"`
+}
+
+// PluginResponse is what a plugin writes, as JSON, to its standard output.
+type PluginResponse struct {
+	Files []PluginFile `json:"files"`
+	// A plugin which can't generate its files for reasons to do with the
+	// spec or parameter, rather than a bug, says why here, and exits with 0.
+	Error string `json:"error,omitempty"`
+}
+
+// PluginFile is a file generated by a plugin.
+type PluginFile struct {
+	// The path of the file, relative to the directory which plugins write
+	// to, with / as the separator. It mustn't leave that directory.
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// ServePlugin implements the protocol for plugins written in Go. It reads a
+// request from in, calls generate with it and writes the files it returns,
+// or its error, to out. Plugins call it from main with os.Stdin and
+// os.Stdout, and exit non-zero if it returns an error, which it only does
+// when it can't read or write.
+func ServePlugin(in io.Reader, out io.Writer, generate func(*PluginRequest) ([]PluginFile, error)) error {
+	var request PluginRequest
+	if err := json.NewDecoder(in).Decode(&request); err != nil {
+		return errors.Wrap(err, "error reading plugin request")
+	}
+	var response PluginResponse
+	files, err := generate(&request)
+	if err != nil {
+		response.Error = err.Error()
+	} else {
+		response.Files = files
+	}
+	if err := json.NewEncoder(out).Encode(response); err != nil {
+		return errors.Wrap(err, "error writing plugin response")
+	}
+	return nil
+}
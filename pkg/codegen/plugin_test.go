@@ -0,0 +1,70 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServePlugin(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(modelSpec))
+	require.NoError(t, err)
+	opts := Options{GenerateTypes: true}
+	model, err := BuildModel(swagger, "api", opts)
+	require.NoError(t, err)
+	spec, err := json.Marshal(swagger)
+	require.NoError(t, err)
+	input, err := json.Marshal(PluginRequest{Parameter: "routes.txt", Options: opts, Model: model, Spec: spec})
+	require.NoError(t, err)
+
+	// A plugin which lists the routes of the operations.
+	routes := func(request *PluginRequest) ([]PluginFile, error) {
+		if request.Parameter == "" {
+			return nil, errors.New("the file name is missing")
+		}
+		var lines []string
+		for _, op := range request.Model.Operations {
+			lines = append(lines, op.Method+" "+op.Path+" "+op.GoName)
+		}
+		return []PluginFile{{Name: request.Parameter, Content: strings.Join(lines, "\n")}}, nil
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, ServePlugin(bytes.NewReader(input), &out, routes))
+	var response PluginResponse
+	require.NoError(t, json.Unmarshal(out.Bytes(), &response))
+	assert.Equal(t, PluginResponse{Files: []PluginFile{{
+		Name:    "routes.txt",
+		Content: "PUT /owners/{owner_id}/pets/{pet_id} UpdatePet\nGET /pets ListPets",
+	}}}, response)
+
+	// Errors of the plugin are reported in the response.
+	input, err = json.Marshal(PluginRequest{Model: model})
+	require.NoError(t, err)
+	out.Reset()
+	require.NoError(t, ServePlugin(bytes.NewReader(input), &out, routes))
+	assert.JSONEq(t, `{"files": null, "error": "the file name is missing"}`, out.String())
+
+	// While those of reading the request are returned.
+	err = ServePlugin(strings.NewReader("{"), &out, routes)
+	assert.Error(t, err)
+}
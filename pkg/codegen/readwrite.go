@@ -0,0 +1,304 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// OpenAPI allows properties to be marked readOnly, meaning that the server
+// sends them but never accepts them, and writeOnly, meaning the opposite. A
+// single Go struct can't express that, so when asked to, we generate two
+// extra types for each affected schema, Foo, which has the suffixes below.
+// FooRequest drops the readOnly properties, and FooResponse drops the
+// writeOnly ones.
+const (
+	requestVariantSuffix  = "Request"
+	responseVariantSuffix = "Response"
+)
+
+var goIdentifierRE = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+
+// This function finds all the types which need a request and response variant.
+// A type needs variants if it has readOnly or writeOnly properties, or if it
+// refers to another type which needs variants, since FooRequest must then
+// refer to BarRequest. The result is keyed by Go type name.
+func FindReadWriteVariants(types []TypeDefinition) map[string]bool {
+	variants := make(map[string]bool)
+	for _, td := range types {
+		if hasReadWriteProperties(td.Schema) {
+			variants[td.TypeName] = true
+		}
+	}
+
+	// Propagate the need for variants through references until nothing
+	// changes.
+	for changed := true; changed; {
+		changed = false
+		for _, td := range types {
+			if variants[td.TypeName] {
+				continue
+			}
+			for _, ref := range referencedTypeNames(td.Schema) {
+				if variants[ref] {
+					variants[td.TypeName] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	return variants
+}
+
+// This function finds the component schemas which will need request and
+// response variants, as FindReadWriteVariants does for their types, but from
+// the spec, so that the variants' names can be reserved before the types are
+// named. The result is keyed by reference path.
+func readWriteSchemaRefs(schemas map[string]*openapi3.SchemaRef) map[string]bool {
+	variants := make(map[string]bool)
+	refs := make(map[string]map[string]bool)
+	for name, sref := range schemas {
+		ref := "#/components/schemas/" + name
+		refs[ref] = make(map[string]bool)
+		if scanReadWriteSchema(sref, refs[ref]) {
+			variants[ref] = true
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for ref, deps := range refs {
+			if variants[ref] {
+				continue
+			}
+			for dep := range deps {
+				if variants[dep] {
+					variants[ref] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	return variants
+}
+
+// Returns whether a schema, or any inline schema within it, has readOnly or
+// writeOnly properties, and records the schemas it refers to in refs.
+func scanReadWriteSchema(sref *openapi3.SchemaRef, refs map[string]bool) bool {
+	if sref == nil || sref.Value == nil {
+		return false
+	}
+	if sref.Ref != "" {
+		refs[sref.Ref] = true
+		return false
+	}
+	s := sref.Value
+	found := false
+	for _, p := range s.Properties {
+		if p != nil && p.Value != nil && (p.Value.ReadOnly || p.Value.WriteOnly) {
+			found = true
+		}
+		if scanReadWriteSchema(p, refs) {
+			found = true
+		}
+	}
+	// Members of allOf are merged into the schema, so their properties are
+	// its own, and a referenced member's need for variants is, too.
+	for _, member := range s.AllOf {
+		if scanReadWriteSchema(member, refs) {
+			found = true
+		}
+	}
+	scanReadWriteSchema(s.Items, refs)
+	scanReadWriteSchema(s.AdditionalProperties, refs)
+	return found
+}
+
+// Returns whether a schema, or any inline object within it, has readOnly or
+// writeOnly properties.
+func hasReadWriteProperties(s Schema) bool {
+	for _, p := range s.Properties {
+		if p.ReadOnly || p.WriteOnly {
+			return true
+		}
+		if p.Schema.RefType == "" && hasReadWriteProperties(p.Schema) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the names of all the types which a schema refers to.
+func referencedTypeNames(s Schema) []string {
+	var decls []string
+	if len(s.Properties) == 0 {
+		decls = append(decls, s.TypeDecl())
+	}
+	for _, p := range s.Properties {
+		decls = append(decls, p.Schema.TypeDecl())
+	}
+	if s.HasAdditionalProperties && s.AdditionalPropertiesType != nil {
+		decls = append(decls, s.AdditionalPropertiesType.TypeDecl())
+	}
+	var names []string
+	for _, decl := range decls {
+		names = append(names, typeDeclIdentifiers(decl)...)
+	}
+	return names
+}
+
+// Returns the identifiers in a type declaration which may refer to other types.
+// Struct field names, struct tags and comments are skipped.
+func typeDeclIdentifiers(decl string) []string {
+	var names []string
+	_ = rewriteTypeDecl(decl, func(name string) string {
+		names = append(names, name)
+		return name
+	})
+	return names
+}
+
+// Calls rename on every identifier which is in a type position in the given
+// Go type declaration, and substitutes the result. Declarations may be
+// simple, like "[]Foo", or struct literals spanning many lines, in which case
+// we need to leave the field names, tags and comments alone.
+func rewriteTypeDecl(decl string, rename func(string) string) string {
+	lines := strings.Split(decl, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "//") {
+			continue
+		}
+		// Everything from the first backquote on is a struct tag.
+		code, tag := line, ""
+		if idx := strings.Index(line, "`"); idx >= 0 {
+			code, tag = line[:idx], line[idx:]
+		}
+		// Inside a struct literal, a line with more than one token starts
+		// with the field name. Embedded fields are a single type name.
+		skipFirst := len(lines) > 1 && i > 0 && len(strings.Fields(code)) > 1
+		first := true
+		code = goIdentifierRE.ReplaceAllStringFunc(code, func(name string) string {
+			if first {
+				first = false
+				if skipFirst {
+					return name
+				}
+			}
+			if name == "struct" || name == "map" || name == "interface" {
+				return name
+			}
+			return rename(name)
+		})
+		lines[i] = code + tag
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Renames any types with variants referenced in decl to their variant names.
+func variantTypeDecl(decl string, variants map[string]bool, suffix string) string {
+	return rewriteTypeDecl(decl, func(name string) string {
+		if variants[name] {
+			return name + suffix
+		}
+		return name
+	})
+}
+
+// This produces the request or response projection of a schema. If the schema
+// is a reference to a type with variants, the reference is redirected to the
+// variant. Otherwise, properties which may not appear in this direction are
+// removed, and the remaining ones are redirected to variants as necessary.
+func projectSchema(s Schema, variants map[string]bool, suffix string) Schema {
+	if s.RefType != "" {
+		if variants[s.RefType] {
+			s.RefType += suffix
+		}
+		return s
+	}
+	if len(s.Properties) == 0 {
+		s.GoType = variantTypeDecl(s.GoType, variants, suffix)
+		return s
+	}
+
+	var props []Property
+	for _, p := range s.Properties {
+		if suffix == requestVariantSuffix && p.ReadOnly {
+			continue
+		}
+		if suffix == responseVariantSuffix && p.WriteOnly {
+			continue
+		}
+		p.Schema = projectSchema(p.Schema, variants, suffix)
+		props = append(props, p)
+	}
+	s.Properties = props
+
+	if s.HasAdditionalProperties && s.AdditionalPropertiesType != nil {
+		addProps := projectSchema(*s.AdditionalPropertiesType, variants, suffix)
+		s.AdditionalPropertiesType = &addProps
+	}
+	s.GoType = GenStructFromSchema(s)
+	return s
+}
+
+// Generates the request and response variants for each of the given types
+// which needs them.
+func GenerateReadWriteVariants(types []TypeDefinition, variants map[string]bool) []TypeDefinition {
+	var out []TypeDefinition
+	for _, td := range types {
+		if !variants[td.TypeName] {
+			continue
+		}
+		for _, suffix := range []string{requestVariantSuffix, responseVariantSuffix} {
+			out = append(out, TypeDefinition{
+				TypeName: td.TypeName + suffix,
+				JsonName: td.JsonName,
+				Schema:   projectSchema(td.Schema, variants, suffix),
+				Doc:      td.Doc,
+			})
+		}
+	}
+	return out
+}
+
+// Redirects the request bodies of the given operations to the request
+// variants of their types, and records which types have response variants
+// so that the response types may be redirected, too.
+func ApplyReadWriteVariants(ops []OperationDefinition, variants map[string]bool) {
+	for i := range ops {
+		op := &ops[i]
+		op.ReadWriteVariants = variants
+
+		for j := range op.Bodies {
+			body := &op.Bodies[j]
+			if variants[body.Schema.RefType] {
+				body.Schema.RefType += requestVariantSuffix
+				continue
+			}
+			// Inline bodies have a type definition generated alongside the
+			// operation, which we need to project in place.
+			for k := range op.TypeDefinitions {
+				td := &op.TypeDefinitions[k]
+				if td.TypeName == body.Schema.RefType {
+					td.Schema = projectSchema(td.Schema, variants, requestVariantSuffix)
+				}
+			}
+		}
+	}
+}
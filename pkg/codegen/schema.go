@@ -28,6 +28,9 @@ type Schema struct {
 	AdditionalTypes          []TypeDefinition // We may need to generate auxiliary helper types, stored here
 
 	SkipOptionalPointer bool // Some types don't need a * in front when they're optional
+
+	ArrayType  *Schema          // For arrays, the schema of the items
+	OAPISchema *openapi3.Schema // The schema we were generated from, for its constraints
 }
 
 func (s Schema) IsRef() bool {
@@ -67,12 +70,33 @@ type Property struct {
 	Required       bool
 	Validation     string
 	IsRequestParam bool
+	ReadOnly       bool // Only ever sent by the server, see SplitReadWriteTypes
+	WriteOnly      bool // Only ever sent by the client, see SplitReadWriteTypes
+
+	GoName     string            // Overrides the field name, from x-go-name
+	JsonIgnore bool              // Skip the field in JSON, from x-go-json-ignore
+	OmitEmpty  *bool             // Overrides omitempty, from x-omitempty
+	ExtraTags  map[string]string // Additional struct tags, from x-oapi-codegen-extra-tags
+
+	Doc Documentation // The description, example and deprecation of the field
 }
 
 func (p Property) GoFieldName() string {
+	if p.GoName != "" {
+		return p.GoName
+	}
 	return ToCamelCase(p.JsonFieldName)
 }
 
+// Whether the field should be tagged with omitempty. By default, optional
+// fields are, but x-omitempty may say otherwise.
+func (p Property) HasOmitEmpty() bool {
+	if p.OmitEmpty != nil {
+		return *p.OmitEmpty
+	}
+	return !p.Required
+}
+
 func (p Property) GoTypeDef() string {
 	typeDef := p.Schema.TypeDecl()
 	if !p.Schema.SkipOptionalPointer && !p.Required {
@@ -85,6 +109,8 @@ type TypeDefinition struct {
 	TypeName string
 	JsonName string
 	Schema   Schema
+	IsAlias  bool // Declare the type as an alias, eg, for x-go-type
+	Doc      Documentation
 }
 
 func PropertiesEqual(a, b Property) bool {
@@ -92,6 +118,10 @@ func PropertiesEqual(a, b Property) bool {
 }
 
 func GenerateGoSchema(sref *openapi3.SchemaRef, path []string, componentType *ComponentType) (Schema, error) {
+	return defaultGenerator.goSchema(sref, path, componentType)
+}
+
+func (g *generator) goSchema(sref *openapi3.SchemaRef, path []string, componentType *ComponentType) (Schema, error) {
 	schema := sref.Value
 
 	// If Ref is set on the SchemaRef, it means that this type is actually a reference to
@@ -100,13 +130,21 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string, componentType *Co
 	if sref.Ref != "" {
 		var err error
 		// Convert the reference path to Go type
-		refType, err = RefPathToGoType(sref.Ref)
+		refType, err = g.refPathToGoType(sref.Ref)
 		if err != nil {
 			return Schema{}, fmt.Errorf("error turning reference (%s) into a Go type: %s",
 				sref.Ref, err)
 		}
 	}
 
+	// The spec may tell us which Go type to use, in which case, we don't need
+	// to look any further.
+	if goType, found, err := extGoType(schema.Extensions); err != nil {
+		return Schema{}, err
+	} else if found {
+		return Schema{GoType: goType.Type, RefType: refType, OAPISchema: schema}, nil
+	}
+
 	// We can't support this in any meaningful way
 	if schema.AnyOf != nil {
 		return Schema{GoType: "interface{}", RefType: refType}, nil
@@ -121,11 +159,12 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string, componentType *Co
 	// so that in a RESTful paradigm, the Create operation can return
 	// (object, id), so that other operations can refer to (id)
 	if schema.AllOf != nil {
-		mergedSchema, err := MergeSchemas(schema.AllOf, path, componentType)
+		mergedSchema, err := g.mergeSchemas(schema.AllOf, path, componentType)
 		if err != nil {
 			return Schema{}, errors.Wrap(err, "error merging schemas")
 		}
 		mergedSchema.RefType = refType
+		mergedSchema.OAPISchema = schema
 		return mergedSchema, nil
 	}
 
@@ -138,7 +177,8 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string, componentType *Co
 	}
 
 	outSchema := Schema{
-		RefType: refType,
+		RefType:    refType,
+		OAPISchema: schema,
 	}
 	// Handle objects and empty schemas first as a special case
 	if t == "" || t == "object" {
@@ -162,7 +202,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string, componentType *Co
 			for _, pName := range SortedSchemaKeys(schema.Properties) {
 				p := schema.Properties[pName]
 				propertyPath := append(path, pName)
-				pSchema, err := GenerateGoSchema(p, propertyPath, componentType)
+				pSchema, err := g.goSchema(p, propertyPath, componentType)
 				if err != nil {
 					return Schema{}, errors.Wrap(err, fmt.Sprintf("error generating Go schema for property '%s'", pName))
 				}
@@ -190,7 +230,18 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string, componentType *Co
 					JsonFieldName: pName,
 					Schema:        pSchema,
 					Required:      required,
-					Validation:    GenerateValidationRules(p, required),
+					Validation:    GenerateValidationRules(p, required, pSchema.GoType),
+					ReadOnly:      p.Value.ReadOnly,
+					WriteOnly:     p.Value.WriteOnly,
+					Doc:           schemaDocumentation(p),
+				}
+				// Extensions next to a $ref are ignored, as with any other
+				// sibling of a reference, otherwise we'd pick up the referenced
+				// schema's extensions.
+				if p.Ref == "" {
+					if err := applyPropertyExtensions(&prop, p.Value.Extensions); err != nil {
+						return Schema{}, errors.Wrap(err, fmt.Sprintf("error in extensions for property '%s'", pName))
+					}
 				}
 				outSchema.Properties = append(outSchema.Properties, prop)
 			}
@@ -200,7 +251,7 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string, componentType *Co
 				GoType: "interface{}",
 			}
 			if schema.AdditionalProperties != nil {
-				additionalSchema, err := GenerateGoSchema(schema.AdditionalProperties, path, componentType)
+				additionalSchema, err := g.goSchema(schema.AdditionalProperties, path, componentType)
 				if err != nil {
 					return Schema{}, errors.Wrap(err, "error generating type for additional properties")
 				}
@@ -213,15 +264,23 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string, componentType *Co
 	} else {
 		f := schema.Format
 
+		// Formats which the user has mapped to Go types win over everything
+		// else.
+		if mapping, found := g.options.FormatMappings[f]; found && f != "" && t != "array" {
+			outSchema.GoType = mapping.Type
+			return outSchema, nil
+		}
+
 		switch t {
 		case "array":
 			// For arrays, we'll get the type of the Items and throw a
 			// [] in front of it.
-			arrayType, err := GenerateGoSchema(schema.Items, path, componentType)
+			arrayType, err := g.goSchema(schema.Items, path, componentType)
 			if err != nil {
 				return Schema{}, errors.Wrap(err, "error generating type for array")
 			}
 			outSchema.GoType = "[]" + arrayType.TypeDecl()
+			outSchema.ArrayType = &arrayType
 		case "integer":
 			// We default to int if format doesn't ask for something else.
 			if isRequestComponent {
@@ -252,15 +311,26 @@ func GenerateGoSchema(sref *openapi3.SchemaRef, path []string, componentType *Co
 			}
 			outSchema.GoType = "bool"
 		case "string":
-			// Special case string formats here.
-			switch f {
-			case "date-time", "date":
-				if isRequestComponent {
-					outSchema.GoType = "string"
-				} else {
-					outSchema.GoType = "time.Time"
-				}
-			case "json":
+			// Special case string formats here. Request components are bound
+			// from strings, so we leave those alone.
+			switch {
+			case isRequestComponent && StringInArray(f, []string{"date-time", "date", "uuid", "email", "byte", "binary"}):
+				outSchema.GoType = "string"
+			case f == "date-time":
+				outSchema.GoType = "time.Time"
+			case f == "date":
+				outSchema.GoType = "openapi_types.Date"
+			case f == "uuid":
+				outSchema.GoType = "uuid.UUID"
+			case f == "email":
+				outSchema.GoType = "openapi_types.Email"
+			case f == "byte":
+				// Base64 encoded, which is how encoding/json handles []byte.
+				outSchema.GoType = "[]byte"
+				outSchema.SkipOptionalPointer = true
+			case f == "binary":
+				outSchema.GoType = "openapi_types.File"
+			case f == "json":
 				outSchema.GoType = "json.RawMessage"
 				outSchema.SkipOptionalPointer = true
 			default:
@@ -294,6 +364,9 @@ type FieldDescriptor struct {
 func GenFieldsFromProperties(props []Property) []string {
 	var fields []string
 	for _, p := range props {
+		if comment := p.Doc.Comment(); comment != "" {
+			fields = append(fields, comment)
+		}
 		field := fmt.Sprintf("    %s %s", p.GoFieldName(), p.GoTypeDef())
 
 		tagName := "json"
@@ -304,15 +377,18 @@ func GenFieldsFromProperties(props []Property) []string {
 			}
 		}
 		tags := ""
-		if p.Required {
-			tags += fmt.Sprintf("%s:\"%s\"", tagName, p.JsonFieldName)
-		} else {
+		if p.JsonIgnore && !p.IsRequestParam {
+			tags += fmt.Sprintf("%s:\"-\"", tagName)
+		} else if p.HasOmitEmpty() {
 			tags += fmt.Sprintf("%s:\"%s,omitempty\"", tagName, p.JsonFieldName)
+		} else {
+			tags += fmt.Sprintf("%s:\"%s\"", tagName, p.JsonFieldName)
 		}
 
 		if p.Validation != "" {
 			tags += fmt.Sprintf(" validate:\"%s\"", p.Validation)
 		}
+		tags += formatExtraTags(p.ExtraTags)
 
 		field += fmt.Sprintf(" `%s`", tags)
 
@@ -342,6 +418,10 @@ func GenStructFromSchema(schema Schema) string {
 
 // Merge all the fields in the schemas supplied into one giant schema.
 func MergeSchemas(allOf []*openapi3.SchemaRef, path []string, componentType *ComponentType) (Schema, error) {
+	return defaultGenerator.mergeSchemas(allOf, path, componentType)
+}
+
+func (g *generator) mergeSchemas(allOf []*openapi3.SchemaRef, path []string, componentType *ComponentType) (Schema, error) {
 	var outSchema Schema
 	for _, schemaOrRef := range allOf {
 		ref := schemaOrRef.Ref
@@ -349,13 +429,13 @@ func MergeSchemas(allOf []*openapi3.SchemaRef, path []string, componentType *Com
 		var refType string
 		var err error
 		if ref != "" {
-			refType, err = RefPathToGoType(ref)
+			refType, err = g.refPathToGoType(ref)
 			if err != nil {
 				return Schema{}, errors.Wrap(err, "error converting reference path to a go type")
 			}
 		}
 
-		schema, err := GenerateGoSchema(schemaOrRef, path, componentType)
+		schema, err := g.goSchema(schemaOrRef, path, componentType)
 		if err != nil {
 			return Schema{}, errors.Wrap(err, "error generating Go schema in allOf")
 		}
@@ -386,7 +466,7 @@ func MergeSchemas(allOf []*openapi3.SchemaRef, path []string, componentType *Com
 
 	// Now, we generate the struct which merges together all the fields.
 	var err error
-	outSchema.GoType, err = GenStructFromAllOf(allOf, path, componentType)
+	outSchema.GoType, err = g.genStructFromAllOf(allOf, path, componentType)
 	if err != nil {
 		return Schema{}, errors.Wrap(err, "unable to generate aggregate type for AllOf")
 	}
@@ -397,6 +477,10 @@ func MergeSchemas(allOf []*openapi3.SchemaRef, path []string, componentType *Com
 // input array. In the case of Ref objects, we use an embedded struct, otherwise,
 // we inline the fields.
 func GenStructFromAllOf(allOf []*openapi3.SchemaRef, path []string, componentType *ComponentType) (string, error) {
+	return defaultGenerator.genStructFromAllOf(allOf, path, componentType)
+}
+
+func (g *generator) genStructFromAllOf(allOf []*openapi3.SchemaRef, path []string, componentType *ComponentType) (string, error) {
 	// Start out with struct {
 	objectParts := []string{"struct {"}
 	for _, schemaOrRef := range allOf {
@@ -408,7 +492,7 @@ func GenStructFromAllOf(allOf []*openapi3.SchemaRef, path []string, componentTyp
 			//   InlinedMember
 			//   ...
 			// }
-			goType, err := RefPathToGoType(ref)
+			goType, err := g.refPathToGoType(ref)
 			if err != nil {
 				return "", err
 			}
@@ -419,7 +503,7 @@ func GenStructFromAllOf(allOf []*openapi3.SchemaRef, path []string, componentTyp
 		} else {
 			// Inline all the fields from the schema into the output struct,
 			// just like in the simple case of generating an object.
-			goSchema, err := GenerateGoSchema(schemaOrRef, path, componentType)
+			goSchema, err := g.goSchema(schemaOrRef, path, componentType)
 			if err != nil {
 				return "", err
 			}
@@ -434,7 +518,7 @@ func GenStructFromAllOf(allOf []*openapi3.SchemaRef, path []string, componentTyp
 
 // This constructs a Go type for a parameter, looking at either the schema or
 // the content, whichever is available
-func paramToGoType(param *openapi3.Parameter, path []string) (Schema, error) {
+func (g *generator) paramToGoType(param *openapi3.Parameter, path []string) (Schema, error) {
 	componentType := ComponentParameters
 
 	if param.Content == nil && param.Schema == nil {
@@ -443,7 +527,7 @@ func paramToGoType(param *openapi3.Parameter, path []string) (Schema, error) {
 
 	// We can process the schema through the generic schema processor
 	if param.Schema != nil {
-		return GenerateGoSchema(param.Schema, path, &componentType)
+		return g.goSchema(param.Schema, path, &componentType)
 	}
 
 	// At this point, we have a content type. We know how to deal with
@@ -465,5 +549,5 @@ func paramToGoType(param *openapi3.Parameter, path []string) (Schema, error) {
 	}
 
 	// For json, we go through the standard schema mechanism
-	return GenerateGoSchema(mt.Schema, path, &componentType)
+	return g.goSchema(mt.Schema, path, &componentType)
 }
@@ -0,0 +1,123 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SecuritySchemeDefinition describes one of the security schemes of the spec,
+// for which we generate a constant with its name and, for the schemes which
+// the runtime has a provider for, a constructor of that provider.
+type SecuritySchemeDefinition struct {
+	Name        string // The name of the scheme in the spec, eg petstore_auth
+	GoName      string // The name of the scheme in Go, eg PetstoreAuth
+	Description string
+
+	// The provider for the scheme: basic, bearer, apiKey or clientCredentials,
+	// or empty if the runtime has none for it, as for openIdConnect.
+	Provider string
+
+	In        string // Where an apiKey is sent: header, query or cookie
+	ParamName string // The name of the header, query parameter or cookie
+	TokenURL  string // The token URL of the clientCredentials flow
+}
+
+// Returns the description, on a single line, for use in comments.
+func (s SecuritySchemeDefinition) DescriptionLine() string {
+	return strings.Join(strings.Fields(s.Description), " ")
+}
+
+// This function describes the security schemes of the spec, in the order of
+// their names.
+func DescribeSecuritySchemes(schemes map[string]*openapi3.SecuritySchemeRef) ([]SecuritySchemeDefinition, error) {
+	names := make([]string, 0, len(schemes))
+	for name, scheme := range schemes {
+		if scheme != nil && scheme.Value != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var defs []SecuritySchemeDefinition
+	for _, name := range names {
+		scheme := schemes[name].Value
+		def := SecuritySchemeDefinition{
+			Name:        name,
+			GoName:      ToCamelCase(name),
+			Description: scheme.Description,
+		}
+		switch scheme.Type {
+		case "http":
+			switch strings.ToLower(scheme.Scheme) {
+			case "basic":
+				def.Provider = "basic"
+			case "bearer":
+				def.Provider = "bearer"
+			}
+		case "apiKey":
+			switch scheme.In {
+			case "header", "query", "cookie":
+			default:
+				return nil, fmt.Errorf("security scheme %s sends its API key in %q, rather than in a header, query or cookie", name, scheme.In)
+			}
+			def.Provider = "apiKey"
+			def.In = scheme.In
+			def.ParamName = scheme.Name
+		case "oauth2":
+			if scheme.Flows != nil && scheme.Flows.ClientCredentials != nil {
+				def.Provider = "clientCredentials"
+				def.TokenURL = scheme.Flows.ClientCredentials.TokenURL
+			}
+		}
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+// Returns the names which the security scheme definitions declare.
+func securityTypeNames(defs []SecuritySchemeDefinition) []string {
+	var names []string
+	for _, def := range defs {
+		names = append(names, def.GoName+"SecurityScheme")
+		if def.Provider != "" {
+			names = append(names, "New"+def.GoName+"SecurityProvider")
+		}
+	}
+	return names
+}
+
+// Uses the template engine to generate the names of the security schemes and
+// the constructors of their providers.
+func GenerateSecurityProviders(t *template.Template, schemes []SecuritySchemeDefinition) (string, error) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+
+	err := t.ExecuteTemplate(w, "security.tmpl", schemes)
+	if err != nil {
+		return "", fmt.Errorf("error generating security providers: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for security providers: %s", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeSecuritySchemes(t *testing.T) {
+	schemes := map[string]*openapi3.SecuritySchemeRef{
+		"api_key": {Value: &openapi3.SecurityScheme{Type: "apiKey", In: "query", Name: "key"}},
+		"basic":   {Value: &openapi3.SecurityScheme{Type: "http", Scheme: "Basic"}},
+		"jwt":     {Value: &openapi3.SecurityScheme{Type: "http", Scheme: "bearer", BearerFormat: "JWT"}},
+		"machines": {Value: &openapi3.SecurityScheme{Type: "oauth2", Flows: &openapi3.OAuthFlows{
+			ClientCredentials: &openapi3.OAuthFlow{TokenURL: "https://example.com/token"},
+		}}},
+		"users": {Value: &openapi3.SecurityScheme{Type: "oauth2", Flows: &openapi3.OAuthFlows{
+			AuthorizationCode: &openapi3.OAuthFlow{TokenURL: "https://example.com/token"},
+		}}},
+	}
+	defs, err := DescribeSecuritySchemes(schemes)
+	assert.NoError(t, err)
+	assert.Equal(t, []SecuritySchemeDefinition{
+		{Name: "api_key", GoName: "ApiKey", Provider: "apiKey", In: "query", ParamName: "key"},
+		{Name: "basic", GoName: "Basic", Provider: "basic"},
+		{Name: "jwt", GoName: "Jwt", Provider: "bearer"},
+		{Name: "machines", GoName: "Machines", Provider: "clientCredentials", TokenURL: "https://example.com/token"},
+		{Name: "users", GoName: "Users"},
+	}, defs)
+	assert.Equal(t, []string{
+		"ApiKeySecurityScheme", "NewApiKeySecurityProvider",
+		"BasicSecurityScheme", "NewBasicSecurityProvider",
+		"JwtSecurityScheme", "NewJwtSecurityProvider",
+		"MachinesSecurityScheme", "NewMachinesSecurityProvider",
+		"UsersSecurityScheme",
+	}, securityTypeNames(defs))
+
+	// API keys must go somewhere the runtime can put them
+	_, err = DescribeSecuritySchemes(map[string]*openapi3.SecuritySchemeRef{
+		"api_key": {Value: &openapi3.SecurityScheme{Type: "apiKey", In: "body", Name: "key"}},
+	})
+	assert.Error(t, err)
+}
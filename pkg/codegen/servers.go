@@ -0,0 +1,250 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ServerDefinition describes one of the servers of the spec, for which we
+// generate a URL constant, or, if its URL has variables, a constructor.
+type ServerDefinition struct {
+	Name        string // Server, if the spec has one server, or Server1, Server2, etc
+	URL         string // The URL from the spec, eg https://{region}.example.com/{version}
+	Description string
+	Variables   []ServerVariableDefinition // In the order they're used in URL
+}
+
+// ServerVariableDefinition describes a variable in a server's URL.
+type ServerVariableDefinition struct {
+	Name        string // The name of the variable in the spec, eg region
+	TypeName    string // The Go type of the variable, which is string, unless it has an enum
+	Default     string
+	Description string
+	Enum        []ServerEnumValue
+}
+
+// ServerEnumValue is one of the values which a server variable may take, along
+// with the name of the constant we generate for it.
+type ServerEnumValue struct {
+	ConstName string
+	Value     string
+}
+
+// Returns the name of the argument to the URL constructor for the variable.
+func (v ServerVariableDefinition) GoVariableName() string {
+	name := LowercaseFirstCharacter(ToCamelCase(v.Name))
+	if IsGoKeyword(name) {
+		name = "p" + UppercaseFirstCharacter(name)
+	}
+	return name
+}
+
+// Returns the URL with the default values of its variables.
+func (s ServerDefinition) DefaultURL() string {
+	url := s.URL
+	for _, v := range s.Variables {
+		url = strings.Replace(url, "{"+v.Name+"}", v.Default, -1)
+	}
+	return url
+}
+
+// Returns the arguments of the URL constructor, eg
+// "region Region, version string".
+func (s ServerDefinition) ParamArgs() string {
+	parts := make([]string, len(s.Variables))
+	for i, v := range s.Variables {
+		parts[i] = v.GoVariableName() + " " + v.TypeName
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Returns a Go expression which builds the URL from the constructor's
+// arguments, eg `"https://" + string(region) + ".example.com/" + version`.
+func (s ServerDefinition) URLExpression() string {
+	var parts []string
+	rest := s.URL
+	for {
+		start := strings.Index(rest, "{")
+		end := strings.Index(rest, "}")
+		if start < 0 || end < start {
+			break
+		}
+		if start > 0 {
+			parts = append(parts, strconv.Quote(rest[:start]))
+		}
+		name := strings.TrimSpace(rest[start+1 : end])
+		for _, v := range s.Variables {
+			if v.Name == name {
+				if v.TypeName == "string" {
+					parts = append(parts, v.GoVariableName())
+				} else {
+					parts = append(parts, "string("+v.GoVariableName()+")")
+				}
+			}
+		}
+		rest = rest[end+1:]
+	}
+	if rest != "" || len(parts) == 0 {
+		parts = append(parts, strconv.Quote(rest))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// Returns the description, on a single line, for use in comments.
+func (s ServerDefinition) DescriptionLine() string {
+	return strings.Join(strings.Fields(s.Description), " ")
+}
+
+// Returns the description, on a single line, for use in comments.
+func (v ServerVariableDefinition) DescriptionLine() string {
+	return strings.Join(strings.Fields(v.Description), " ")
+}
+
+// This function describes the servers of the spec.
+func DescribeServers(servers openapi3.Servers) ([]ServerDefinition, error) {
+	var defs []ServerDefinition
+	for i, server := range servers {
+		def := ServerDefinition{
+			Name:        "Server",
+			URL:         server.URL,
+			Description: server.Description,
+		}
+		if len(servers) > 1 {
+			def.Name = fmt.Sprintf("Server%d", i+1)
+		}
+
+		names, err := server.ParameterNames()
+		if err != nil {
+			return nil, fmt.Errorf("error in URL of server %s: %s", server.URL, err)
+		}
+		seen := make(map[string]bool)
+		for _, name := range names {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			variable, found := server.Variables[name]
+			if !found || variable == nil {
+				return nil, fmt.Errorf("the URL of server %s uses variable %s, which isn't declared", server.URL, name)
+			}
+			v := ServerVariableDefinition{
+				Name:        name,
+				TypeName:    "string",
+				Default:     serverVariableValue(variable.Default),
+				Description: variable.Description,
+			}
+			if len(variable.Enum) != 0 {
+				v.TypeName = ToCamelCase(name)
+				if len(servers) > 1 {
+					v.TypeName = def.Name + v.TypeName
+				}
+				v.Enum = serverEnumValues(v.TypeName, variable.Enum)
+			}
+			def.Variables = append(def.Variables, v)
+		}
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+// Server variables are strings, but YAML may well have parsed them as numbers.
+func serverVariableValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// Names the constants for the values of an enum, as the type name followed by
+// the value, so "eu" of Region is RegionEu.
+func serverEnumValues(typeName string, enum []interface{}) []ServerEnumValue {
+	var values []ServerEnumValue
+	taken := make(map[string]bool)
+	for _, e := range enum {
+		value := serverVariableValue(e)
+		name := typeName
+		for _, word := range nonAlphanumericRE.Split(value, -1) {
+			name += UppercaseFirstCharacter(word)
+		}
+		if name == typeName {
+			name += "Empty"
+		}
+		constName := name
+		for n := 2; taken[constName]; n++ {
+			constName = fmt.Sprintf("%s%d", name, n)
+		}
+		taken[constName] = true
+		values = append(values, ServerEnumValue{ConstName: constName, Value: value})
+	}
+	return values
+}
+
+// Returns the names which the server definitions declare.
+func serverTypeNames(defs []ServerDefinition) []string {
+	var names []string
+	for _, def := range defs {
+		if len(def.Variables) == 0 {
+			names = append(names, def.Name+"URL")
+			continue
+		}
+		names = append(names, "New"+def.Name+"URL", def.Name+"DefaultURL")
+		for _, v := range def.Variables {
+			if len(v.Enum) == 0 {
+				continue
+			}
+			names = append(names, v.TypeName)
+			for _, e := range v.Enum {
+				names = append(names, e.ConstName)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GenerateServerURLs generates the constants and constructors for the URLs
+// of the servers in the spec.
+func GenerateServerURLs(t *template.Template, defs []ServerDefinition) (string, error) {
+	if len(defs) == 0 {
+		return "", nil
+	}
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+
+	err := t.ExecuteTemplate(w, "servers.tmpl", defs)
+	if err != nil {
+		return "", fmt.Errorf("error generating server URLs: %s", err)
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", fmt.Errorf("error flushing output buffer for server URLs: %s", err)
+	}
+	return buf.String(), nil
+}
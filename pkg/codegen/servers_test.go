@@ -0,0 +1,49 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeServers(t *testing.T) {
+	servers := openapi3.Servers{{
+		URL: "https://{region}.example.com/{version}/{region}",
+		Variables: map[string]*openapi3.ServerVariable{
+			"region":  {Default: "eu", Enum: []interface{}{"eu", "us-east", float64(2)}},
+			"version": {Default: float64(1)},
+		},
+	}}
+	defs, err := DescribeServers(servers)
+	assert.NoError(t, err)
+	if assert.Len(t, defs, 1) {
+		def := defs[0]
+		assert.Equal(t, "Server", def.Name)
+		assert.Equal(t, "https://eu.example.com/1/eu", def.DefaultURL())
+		assert.Equal(t, "region Region, version string", def.ParamArgs())
+		assert.Equal(t, `"https://" + string(region) + ".example.com/" + version + "/" + string(region)`, def.URLExpression())
+		assert.Equal(t, []ServerEnumValue{
+			{ConstName: "RegionEu", Value: "eu"},
+			{ConstName: "RegionUsEast", Value: "us-east"},
+			{ConstName: "Region2", Value: "2"},
+		}, def.Variables[0].Enum)
+	}
+
+	// Variables must be declared
+	_, err = DescribeServers(openapi3.Servers{{URL: "https://{region}.example.com"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,183 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+// Responses with these content types may be endless, or just huge, so the
+// client hands them over as they arrive, rather than reading them whole.
+var (
+	contentTypesSSE    = []string{"text/event-stream"}
+	contentTypesNDJSON = []string{"application/x-ndjson", "application/ndjson", "application/jsonl",
+		"application/x-jsonlines", "application/jsonlines"}
+	contentTypesBinary = []string{"application/octet-stream"}
+)
+
+// The kinds of streaming response, which prefix the fields for them in the
+// response types, in the same way that JSON prefixes JSON responses.
+const (
+	streamKindSSE    = "SSE"
+	streamKindNDJSON = "NDJSON"
+	streamKindBinary = "Binary"
+)
+
+// Returns the kind of streaming response which a content type is, or "" if it
+// isn't one.
+func streamKind(contentType string) string {
+	switch {
+	case StringInArray(contentType, contentTypesSSE):
+		return streamKindSSE
+	case StringInArray(contentType, contentTypesNDJSON):
+		return streamKindNDJSON
+	case StringInArray(contentType, contentTypesBinary):
+		return streamKindBinary
+	}
+	return ""
+}
+
+// ResponseStreamDefinition describes a response which the client streams,
+// either with an iterator over its events or lines, or, for binary content, by
+// handing over the body unread.
+type ResponseStreamDefinition struct {
+	ResponseName string // The status code, or "default"
+	ContentType  string
+	Kind         string // SSE, NDJSON or Binary
+	TypeName     string // The iterator type, eg ListEventsSSE200Stream, unless Kind is Binary
+	EventType    string // The Go type of the events or lines
+}
+
+// Returns the name of the field for the stream in the response type, eg SSE200.
+func (d ResponseStreamDefinition) FieldName() string {
+	return d.Kind + ToCamelCase(d.ResponseName)
+}
+
+// Returns the type of the field for the stream in the response type.
+func (d ResponseStreamDefinition) FieldType() string {
+	if d.IsBinary() {
+		return "io.ReadCloser"
+	}
+	return "*" + d.TypeName
+}
+
+func (d ResponseStreamDefinition) IsSSE() bool {
+	return d.Kind == streamKindSSE
+}
+
+func (d ResponseStreamDefinition) IsBinary() bool {
+	return d.Kind == streamKindBinary
+}
+
+// Returns whether events are decoded from JSON, which they are, unless their
+// schema is a plain string.
+func (d ResponseStreamDefinition) DecodesJSON() bool {
+	return d.EventType != "string"
+}
+
+// Returns the condition, for the generated Parse function, under which a
+// response is this stream.
+func (d ResponseStreamDefinition) Condition() string {
+	condition := fmt.Sprintf("strings.Contains(rsp.Header.Get(\"%s\"), \"%s\")", echo.HeaderContentType, d.ContentType)
+	if d.ResponseName != "default" {
+		condition += " && rsp.StatusCode == " + d.ResponseName
+	}
+	return condition
+}
+
+// A streaming response of an operation, before we've described it.
+type streamingResponse struct {
+	responseName string
+	contentType  string
+	kind         string
+	mediaType    *openapi3.MediaType
+}
+
+// Finds the streaming responses of an operation, with those for specific
+// status codes before the default one, which the generated code must check
+// last.
+func streamingResponses(op *openapi3.Operation) []streamingResponse {
+	var specific, fallback []streamingResponse
+	for _, responseName := range SortedResponsesKeys(op.Responses) {
+		responseRef := op.Responses[responseName]
+		if responseRef == nil || responseRef.Value == nil {
+			continue
+		}
+		for _, contentType := range SortedContentKeys(responseRef.Value.Content) {
+			kind := streamKind(contentType)
+			if kind == "" {
+				continue
+			}
+			sr := streamingResponse{
+				responseName: responseName,
+				contentType:  contentType,
+				kind:         kind,
+				mediaType:    responseRef.Value.Content[contentType],
+			}
+			if responseName == "default" {
+				fallback = append(fallback, sr)
+			} else {
+				specific = append(specific, sr)
+			}
+		}
+	}
+	return append(specific, fallback...)
+}
+
+// Returns the name of the iterator type for a streaming response.
+func streamTypeName(operationID string, sr streamingResponse) string {
+	if sr.kind == streamKindBinary {
+		return ""
+	}
+	return operationID + sr.kind + ToCamelCase(sr.responseName) + "Stream"
+}
+
+// GetResponseStreamDefinitions describes the responses of the operation which
+// the client streams, see ResponseStreamDefinition.
+func (o *OperationDefinition) GetResponseStreamDefinitions() ([]ResponseStreamDefinition, error) {
+	g := o.generator()
+	var defs []ResponseStreamDefinition
+	for _, sr := range streamingResponses(o.Spec) {
+		def := ResponseStreamDefinition{
+			ResponseName: sr.responseName,
+			ContentType:  sr.contentType,
+			Kind:         sr.kind,
+			TypeName:     streamTypeName(o.OperationId, sr),
+			EventType:    "string",
+		}
+		if sr.kind != streamKindBinary && sr.mediaType != nil && sr.mediaType.Schema != nil {
+			eventSchema, err := g.goSchema(sr.mediaType.Schema, []string{def.TypeName, "Event"}, nil)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("Unable to determine Go type for %s.%s", o.OperationId, sr.contentType))
+			}
+			def.EventType = o.responseSchema(eventSchema).TypeDecl()
+		}
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+// This function is used by the template engine to describe streaming
+// responses.
+func getResponseStreamDefinitions(op *OperationDefinition) []ResponseStreamDefinition {
+	defs, err := op.GetResponseStreamDefinitions()
+	if err != nil {
+		panic(err)
+	}
+	return defs
+}
@@ -20,7 +20,6 @@ import (
 	"strings"
 	"text/template"
 
-	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
 )
 
@@ -94,7 +93,10 @@ func genResponsePayload(operationID string) string {
 }
 
 // genResponseUnmarshal generates unmarshaling steps for structured response payloads
-func genResponseUnmarshal(operationID string, responses openapi3.Responses) string {
+func genResponseUnmarshal(op *OperationDefinition) string {
+	g := op.generator()
+	operationID := op.OperationId
+	responses := op.Spec.Responses
 	var buffer = bytes.NewBufferString("")
 	var mostSpecific = make(map[string]string)  // content-type and status-code
 	var lessSpecific = make(map[string]string)  // status-code only
@@ -135,6 +137,11 @@ func genResponseUnmarshal(operationID string, responses openapi3.Responses) stri
 				continue
 			}
 
+			// Streams are handled before the body is read, see ResponseStreamDefinition:
+			if streamKind(contentTypeName) != "" {
+				continue
+			}
+
 			// But we can only do this if we actually have a schema (otherwise there will be no struct to unmarshal into):
 			if contentType.Schema == nil {
 				fmt.Fprintf(os.Stderr, "Response %s.%s has nil schema\n", operationID, responseName)
@@ -142,11 +149,12 @@ func genResponseUnmarshal(operationID string, responses openapi3.Responses) stri
 			}
 
 			// Make sure that we actually have a go-type for this response:
-			goType, err := GenerateGoSchema(contentType.Schema, []string{contentTypeName}, nil)
+			goType, err := g.goSchema(contentType.Schema, []string{contentTypeName}, nil)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Unable to determine Go type for %s.%s: %v\n", operationID, contentTypeName, err)
 				continue
 			}
+			goType = op.responseSchema(goType)
 
 			// We get "interface{}" when using "anyOf" or "oneOf" (which doesn't work with Go types):
 			if goType.TypeDecl() == "interface{}" {
@@ -236,18 +244,34 @@ func getResponseTypeDefinitions(op *OperationDefinition) []TypeDefinition {
 	return td
 }
 
+// templateFunctions overrides those of TemplateFunctions which depend on the
+// generator's run.
+func (g *generator) templateFunctions() template.FuncMap {
+	return template.FuncMap{
+		"opts": func() Options { return g.options },
+	}
+}
+
 // This function map is passed to the template engine, and we can call each
 // function here by keyName from the template code.
 var TemplateFunctions = template.FuncMap{
-	"genParamArgs":               genParamArgs,
-	"genParamTypes":              genParamTypes,
-	"genParamNames":              genParamNames,
-	"genParamFmtString":          genParamFmtString,
-	"swaggerUriToEchoUri":        SwaggerUriToEchoUri,
-	"lcFirst":                    LowercaseFirstCharacter,
-	"camelCase":                  ToCamelCase,
-	"genResponsePayload":         genResponsePayload,
-	"genResponseTypeName":        genResponseTypeName,
-	"genResponseUnmarshal":       genResponseUnmarshal,
-	"getResponseTypeDefinitions": getResponseTypeDefinitions,
+	"genParamArgs":                  genParamArgs,
+	"genParamTypes":                 genParamTypes,
+	"genParamNames":                 genParamNames,
+	"genParamFmtString":             genParamFmtString,
+	"swaggerUriToEchoUri":           SwaggerUriToEchoUri,
+	"lcFirst":                       LowercaseFirstCharacter,
+	"camelCase":                     ToCamelCase,
+	"genResponsePayload":            genResponsePayload,
+	"genResponseTypeName":           genResponseTypeName,
+	"genResponseUnmarshal":          genResponseUnmarshal,
+	"getResponseTypeDefinitions":    getResponseTypeDefinitions,
+	"getResponseStreamDefinitions":  getResponseStreamDefinitions,
+	"getResponseHeadersDefinitions": getResponseHeadersDefinitions,
+	"genValidate":                   genValidate,
+	"genValidateParams":             genValidateParams,
+	"genBindStyledParam":            genBindStyledParam,
+	"genBindQueryParam":             genBindQueryParam,
+	"canHaveMethods":                canHaveMethods,
+	"opts":                          func() Options { return defaultGenerator.options },
 }
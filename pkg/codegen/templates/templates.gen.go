@@ -28,7 +28,7 @@ func (a *{{.TypeName}}) UnmarshalJSON(b []byte) error {
 	if err != nil {
 		return err
 	}
-{{range .Schema.Properties}}
+{{range .Schema.Properties}}{{if not .JsonIgnore}}
     if raw, found := object["{{.JsonFieldName}}"]; found {
         err = json.Unmarshal(raw, &a.{{.GoFieldName}})
         if err != nil {
@@ -36,7 +36,7 @@ func (a *{{.TypeName}}) UnmarshalJSON(b []byte) error {
         }
         delete(object, "{{.JsonFieldName}}")
     }
-{{end}}
+{{end}}{{end}}
     if len(object) != 0 {
         a.AdditionalProperties = make(map[string]{{$addType}})
         for fieldName, fieldBuf := range object {
@@ -55,14 +55,14 @@ func (a *{{.TypeName}}) UnmarshalJSON(b []byte) error {
 func (a {{.TypeName}}) MarshalJSON() ([]byte, error) {
     var err error
     object := make(map[string]json.RawMessage)
-{{range .Schema.Properties}}
+{{range .Schema.Properties}}{{if not .JsonIgnore}}
 {{if not .Required}}if a.{{.GoFieldName}} != nil { {{end}}
     object["{{.JsonFieldName}}"], err = json.Marshal(a.{{.GoFieldName}})
     if err != nil {
         return nil, errors.Wrap(err, fmt.Sprintf("error marshaling '{{.JsonFieldName}}'"))
     }
 {{if not .Required}} }{{end}}
-{{end}}
+{{end}}{{end}}
     for fieldName, field := range a.AdditionalProperties {
 		object[fieldName], err = json.Marshal(field)
 		if err != nil {
@@ -72,6 +72,117 @@ func (a {{.TypeName}}) MarshalJSON() ([]byte, error) {
 	return json.Marshal(object)
 }
 {{end}}
+`,
+	"callback-client.tmpl": `// CallbackClient sends the callbacks and webhooks which this API makes.
+type CallbackClient struct {
+    // HTTP client with any customized settings, such as certificate chains.
+    Client http.Client
+
+    // A callback for modifying requests which are generated before sending over
+    // the network.
+    RequestEditor func(req *http.Request, ctx context.Context) error
+}
+
+{{range . -}}
+{{$hasParams := .RequiresParamObject -}}
+{{$opid := .OperationId -}}
+{{$cb := .Callback -}}
+{{if $cb.IsWebhook -}}
+// {{$opid}}{{if .HasBody}}WithBody{{end}} sends the {{$cb.Name}} webhook to the given URL{{if .HasBody}}, with any body{{end}}
+func (c *CallbackClient) {{$opid}}{{if .HasBody}}WithBody{{end}}(ctx context.Context, webhookURL string{{if $hasParams}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}) (*http.Response, error) {
+    req, err := New{{$opid}}Request{{if .HasBody}}WithBody{{end}}(webhookURL{{if $hasParams}}, params{{end}}{{if .HasBody}}, contentType, body{{end}})
+{{- else -}}
+// {{$opid}}{{if .HasBody}}WithBody{{end}} sends the {{$cb.Name}} callback of {{$cb.Trigger}} to {{$cb.Expression}},
+// which is resolved against the request which triggered it{{if .HasBody}}, with any body{{end}}
+func (c *CallbackClient) {{$opid}}{{if .HasBody}}WithBody{{end}}(ctx context.Context, trigger runtime.CallbackTrigger{{if $hasParams}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}) (*http.Response, error) {
+    callbackURL, err := runtime.ResolveCallbackURL({{printf "%q" $cb.Expression}}, trigger)
+    if err != nil {
+        return nil, err
+    }
+    req, err := New{{$opid}}Request{{if .HasBody}}WithBody{{end}}(callbackURL{{if $hasParams}}, params{{end}}{{if .HasBody}}, contentType, body{{end}})
+{{- end}}
+    if err != nil {
+        return nil, err
+    }
+    return c.do(ctx, req)
+}
+
+{{range .Bodies}}
+// {{$opid}}{{.Suffix}} sends {{$opid}} with a {{.ContentType}} body
+func (c *CallbackClient) {{$opid}}{{.Suffix}}(ctx context.Context, {{if $cb.IsWebhook}}webhookURL string{{else}}trigger runtime.CallbackTrigger{{end}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{.TypeDef}}) (*http.Response, error) {
+{{- if $cb.IsWebhook}}
+    req, err := New{{$opid}}{{.Suffix}}Request(webhookURL{{if $hasParams}}, params{{end}}, body)
+{{- else}}
+    callbackURL, err := runtime.ResolveCallbackURL({{printf "%q" $cb.Expression}}, trigger)
+    if err != nil {
+        return nil, err
+    }
+    req, err := New{{$opid}}{{.Suffix}}Request(callbackURL{{if $hasParams}}, params{{end}}, body)
+{{- end}}
+    if err != nil {
+        return nil, err
+    }
+    return c.do(ctx, req)
+}
+{{end}}{{/* range .Bodies */}}
+{{end}}
+
+func (c *CallbackClient) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+    req = req.WithContext(ctx)
+    if c.RequestEditor != nil {
+        err := c.RequestEditor(req, ctx)
+        if err != nil {
+            return nil, err
+        }
+    }
+    return c.Client.Do(req)
+}
+
+{{template "request-builders.tmpl" .}}
+`,
+	"callback-server.tmpl": `// CallbackServerInterface represents the handlers for the callbacks and
+// webhooks which this API makes.
+type CallbackServerInterface interface {
+{{range .}}{{$where := .Callback.Expression}}{{if .Callback.IsWebhook}}{{$where = printf "webhook %s" .Callback.Name}}{{end -}}
+{{.DocComment (printf "(%s %s)" .Method $where)}}
+{{.OperationId}}(ctx echo.Context{{if .RequiresParamObject}}, params {{.OperationId}}Params{{end}}) error
+{{end}}
+}
+
+// CallbackServerInterfaceWrapper converts echo contexts to parameters.
+type CallbackServerInterfaceWrapper struct {
+    Handler CallbackServerInterface
+}
+
+{{range .}}// {{.OperationId}} converts echo context to params.
+func (w *CallbackServerInterfaceWrapper) {{.OperationId}} (ctx echo.Context) error {
+{{template "param-binding.tmpl" .}}
+    // Invoke the callback with all the unmarshalled arguments
+    err = w.Handler.{{.OperationId}}(ctx{{if .RequiresParamObject}}, params{{end}})
+    return err
+}
+{{end}}
+
+// CallbackPaths holds the paths at which callbacks and webhooks are received,
+// which depend on the URLs given to the API. Those which are left empty aren't
+// registered.
+type CallbackPaths struct {
+{{range .}}    {{.OperationId}} string
+{{end}}
+}
+
+// RegisterCallbackHandlers adds a route for each callback and webhook to the
+// EchoRouter, at its path in paths.
+func RegisterCallbackHandlers(router runtime.EchoRouter, si CallbackServerInterface, paths CallbackPaths) {
+    wrapper := CallbackServerInterfaceWrapper{
+        Handler: si,
+    }
+{{range .}}
+    if paths.{{.OperationId}} != "" {
+        router.{{.Method}}(paths.{{.OperationId}}, wrapper.{{.OperationId}})
+    }
+{{- end}}
+}
 `,
 	"client-with-responses.tmpl": `// ClientWithResponses builds on ClientInterface to offer response payloads
 type ClientWithResponses struct {
@@ -99,6 +210,17 @@ func NewClientWithResponsesAndRequestEditorFunc(server string, reqEditorFn Reque
 	}
 }
 
+// NewClientWithResponsesAndSecurityProviders returns a ClientWithResponses with a default Client, which authenticates requests with the given providers of security schemes, by name:
+func NewClientWithResponsesAndSecurityProviders(server string, providers map[string]runtime.SecurityProvider) *ClientWithResponses {
+	return &ClientWithResponses{
+		ClientInterface: &Client{
+			Client: http.Client{},
+			Server: server,
+			SecurityProviders: providers,
+		},
+	}
+}
+
 
 {{range .}}{{$opid := .OperationId}}{{$op := .}}
 type {{$opid | lcFirst}}Response struct {
@@ -107,7 +229,21 @@ type {{$opid | lcFirst}}Response struct {
     {{- range getResponseTypeDefinitions .}}
     {{.TypeName}} *{{.Schema.TypeDecl}}
     {{- end}}
+    {{- range getResponseStreamDefinitions .}}
+    {{.FieldName}} {{.FieldType}}
+    {{- end}}
+    {{- range getResponseHeadersDefinitions .}}
+    {{.FieldName}} *{{.TypeName}}
+    {{- end}}
+}
+{{range getResponseHeadersDefinitions .}}
+// {{.TypeName}} holds the headers of the {{.ResponseName}} response to {{$opid}}.
+type {{.TypeName}} struct {
+{{- range .Headers}}
+    {{.GoName}} {{if .IndirectOptional}}*{{end}}{{.TypeDef}}
+{{- end}}
 }
+{{end}}
 
 // Status returns HTTPResponse.Status
 func (r {{$opid | lcFirst}}Response) Status() string {
@@ -129,9 +265,11 @@ func (r {{$opid | lcFirst}}Response) StatusCode() int {
 
 {{range .}}
 {{$opid := .OperationId -}}
+{{$doc := .DocComment -}}
 {{/* Generate client methods (with responses)*/}}
 
-// {{$opid}}{{if .HasBody}}WithBody{{end}}WithResponse request{{if .HasBody}} with arbitrary body{{end}} returning *{{$opid}}Response
+{{$what := printf "%sWithResponse request returning *%sResponse" $opid $opid}}{{if .HasBody}}{{$what = printf "%sWithBodyWithResponse request with arbitrary body returning *%sResponse" $opid $opid}}{{end -}}
+{{.DocComment $what}}
 func (c *ClientWithResponses) {{$opid}}{{if .HasBody}}WithBody{{end}}WithResponse(ctx context.Context{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}) (*{{genResponseTypeName $opid}}, error){
     rsp, err := c.{{$opid}}{{if .HasBody}}WithBody{{end}}(ctx{{genParamNames .PathParams}}{{if .RequiresParamObject}}, params{{end}}{{if .HasBody}}, contentType, body{{end}})
     if err != nil {
@@ -144,6 +282,7 @@ func (c *ClientWithResponses) {{$opid}}{{if .HasBody}}WithBody{{end}}WithRespons
 {{$pathParams := .PathParams -}}
 {{$bodyRequired := .BodyRequired -}}
 {{range .Bodies}}
+{{$doc}}
 func (c *ClientWithResponses) {{$opid}}{{.Suffix}}WithResponse(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{.TypeDef}}) (*{{genResponseTypeName $opid}}, error) {
     rsp, err := c.{{$opid}}{{.Suffix}}(ctx{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}}, body)
     if err != nil {
@@ -160,6 +299,25 @@ func (c *ClientWithResponses) {{$opid}}{{.Suffix}}WithResponse(ctx context.Conte
 
 // Parse{{genResponseTypeName $opid}} parses an HTTP response from a {{$opid}}WithResponse call
 func Parse{{genResponseTypeName $opid}}(rsp *http.Response) (*{{genResponseTypeName $opid}}, error) {
+{{- if getResponseStreamDefinitions .}}
+    // Streams are handed over unread, and closing them is up to the caller.
+{{- end}}
+{{- $hasHeaders := getResponseHeadersDefinitions .}}
+{{- range getResponseStreamDefinitions .}}
+    if {{.Condition}} {
+        response := &{{genResponseTypeName $opid}}{
+            HTTPResponse: rsp,
+            {{.FieldName}}: {{if .IsBinary}}rsp.Body{{else}}&{{.TypeName}}{reader: runtime.New{{if .IsSSE}}SSE{{else}}Line{{end}}Reader(rsp.Body)}{{end}},
+        }
+        {{- if $hasHeaders}}
+        if err := decode{{$opid}}ResponseHeaders(response); err != nil {
+            rsp.Body.Close()
+            return nil, err
+        }
+        {{- end}}
+        return response, nil
+    }
+{{end}}
     bodyBytes, err := ioutil.ReadAll(rsp.Body)
     defer rsp.Body.Close()
     if err != nil {
@@ -167,13 +325,102 @@ func Parse{{genResponseTypeName $opid}}(rsp *http.Response) (*{{genResponseTypeN
     }
 
     response := {{genResponsePayload $opid}}
+{{- if $hasHeaders}}
+
+    if err := decode{{$opid}}ResponseHeaders(response); err != nil {
+        return nil, err
+    }
+{{- end}}
 
-    {{genResponseUnmarshal $opid .Spec.Responses}}
+    {{genResponseUnmarshal .}}
 
     return response, nil
 }
 {{end}}{{/* range . $opid := .OperationId */}}
 
+{{/* Generate decoders for response headers */}}
+{{range .}}{{$opid := .OperationId}}{{$headers := getResponseHeadersDefinitions .}}
+{{- if $headers}}
+// decode{{$opid}}ResponseHeaders decodes the headers which the spec declares for
+// the status code of the response.
+func decode{{$opid}}ResponseHeaders(response *{{genResponseTypeName $opid}}) error {
+    rsp := response.HTTPResponse
+    switch rsp.StatusCode {
+{{- range $headers}}{{$field := .FieldName}}
+    {{.Case}}:
+        headers := &{{.TypeName}}{}
+{{- range .Headers}}
+        if value := rsp.Header.Get("{{.HeaderName}}"); value != "" {
+            var {{.GoVariableName}} {{.TypeDef}}
+            if err := runtime.BindStyledParameter("simple", false, "{{.HeaderName}}", value, &{{.GoVariableName}}); err != nil {
+                return fmt.Errorf("invalid format for header {{.HeaderName}}: %s", err)
+            }
+            headers.{{.GoName}} = {{if .IndirectOptional}}&{{end}}{{.GoVariableName}}
+        }
+{{- end}}
+        response.{{$field}} = headers
+{{- end}}
+    }
+    return nil
+}
+{{end}}
+{{- end}}{{/* range . */}}
+
+{{/* Generate iterators for streaming responses */}}
+{{range .}}{{$opid := .OperationId}}
+{{range getResponseStreamDefinitions .}}{{if not .IsBinary}}
+{{$raw := "Line()"}}{{if .IsSSE}}{{$raw = "Event().Data"}}{{end -}}
+// {{.TypeName}} iterates over the {{if .IsSSE}}events{{else}}lines{{end}} of the {{.ContentType}} response to {{$opid}},
+// as they arrive.
+type {{.TypeName}} struct {
+    reader *runtime.{{if .IsSSE}}SSE{{else}}Line{{end}}Reader
+    event  {{.EventType}}
+    err    error
+}
+
+// Next reads the next {{if .IsSSE}}event{{else}}line{{end}}, which Event then returns. It returns false at the end
+// of the stream, or on an error, which Err then returns.
+func (s *{{.TypeName}}) Next() bool {
+    if s.err != nil || !s.reader.Next() {
+        return false
+    }
+{{- if .DecodesJSON}}
+    var event {{.EventType}}
+    if err := json.Unmarshal(s.reader.{{$raw}}, &event); err != nil {
+        s.err = err
+        return false
+    }
+    s.event = event
+{{- else}}
+    s.event = string(s.reader.{{$raw}})
+{{- end}}
+    return true
+}
+
+// Event returns the {{if .IsSSE}}data of the event{{else}}line{{end}} which Next read.
+func (s *{{.TypeName}}) Event() {{.EventType}} {
+    return s.event
+}
+{{if .IsSSE}}
+// SSE returns the event which Next read, as it was sent, with its ID and type.
+func (s *{{.TypeName}}) SSE() runtime.SSEEvent {
+    return s.reader.Event()
+}
+{{end}}
+// Err returns the error which stopped Next, if any.
+func (s *{{.TypeName}}) Err() error {
+    if s.err != nil {
+        return s.err
+    }
+    return s.reader.Err()
+}
+
+// Close closes the response body.
+func (s *{{.TypeName}}) Close() error {
+    return s.reader.Close()
+}
+{{end}}{{end}}{{/* range getResponseStreamDefinitions */}}
+{{end}}{{/* range . */}}
 `,
 	"client.tmpl": `// RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(req *http.Request, ctx context.Context) error
@@ -190,6 +437,16 @@ type Client struct {
     // A callback for modifying requests which are generated before sending over
     // the network.
     RequestEditor RequestEditorFn
+
+    // The providers of the credentials of the security schemes, by the names
+    // which the spec gives them, as the ...SecurityScheme constants do. Each
+    // request is authenticated with those which its operation's security
+    // requirements ask for, before the RequestEditor is called.
+    SecurityProviders map[string]runtime.SecurityProvider
+
+    // Optional instrumentation, which is told of each operation the client
+    // sends, for tracing and metrics.
+    Instrumentation runtime.Instrumentation
 }
 
 // The interface specification for the client above.
@@ -198,9 +455,13 @@ type ClientInterface interface {
 {{$hasParams := .RequiresParamObject -}}
 {{$pathParams := .PathParams -}}
 {{$opid := .OperationId -}}
-    // {{$opid}} request {{if .HasBody}} with any body{{end}}
+{{$doc := .DocComment -}}
+    // {{$opid}} request{{if .HasBody}} with any body{{end}}{{with $doc}}
+    //
+    {{.}}{{end}}
     {{$opid}}{{if .HasBody}}WithBody{{end}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}) (*http.Response, error)
 {{range .Bodies}}
+    {{$doc}}
     {{$opid}}{{.Suffix}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{.TypeDef}}) (*http.Response, error)
 {{end}}{{/* range .Bodies */}}
 {{end}}{{/* range . $opid := .OperationId */}}
@@ -208,33 +469,48 @@ type ClientInterface interface {
 
 
 {{/* Generate client methods */}}
-{{range . -}}
+{{range $i, $op := . -}}
 {{$hasParams := .RequiresParamObject -}}
 {{$pathParams := .PathParams -}}
 {{$opid := .OperationId -}}
+{{$doc := .DocComment -}}
 
+{{$doc}}
 func (c *Client) {{$opid}}{{if .HasBody}}WithBody{{end}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}) (*http.Response, error) {
     req, err := New{{$opid}}Request{{if .HasBody}}WithBody{{end}}(c.Server{{genParamNames .PathParams}}{{if $hasParams}}, params{{end}}{{if .HasBody}}, contentType, body{{end}})
     if err != nil {
         return nil, err
     }
-    req = req.WithContext(ctx)
-    if c.RequestEditor != nil {
-        err = c.RequestEditor(req, ctx)
-        if err != nil {
-            return nil, err
-        }
-    }
-    return c.Client.Do(req)
+    return c.do(ctx, req, &Operations[{{$i}}])
 }
 
 {{range .Bodies}}
+{{$doc}}
 func (c *Client) {{$opid}}{{.Suffix}}(ctx context.Context{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{.TypeDef}}) (*http.Response, error) {
     req, err := New{{$opid}}{{.Suffix}}Request(c.Server{{genParamNames $pathParams}}{{if $hasParams}}, params{{end}}, body)
     if err != nil {
         return nil, err
     }
+    return c.do(ctx, req, &Operations[{{$i}}])
+}
+{{end}}{{/* range .Bodies */}}
+{{end}}
+
+// Sends the request for the operation, as the instrumentation observes it.
+func (c *Client) do(ctx context.Context, req *http.Request, op *runtime.OperationInfo) (*http.Response, error) {
+    if c.Instrumentation == nil {
+        return c.send(ctx, req, op)
+    }
+    return runtime.InstrumentRequest(ctx, req, op, c.Instrumentation, c.send)
+}
+
+// Authenticates and edits the request, then sends it.
+func (c *Client) send(ctx context.Context, req *http.Request, op *runtime.OperationInfo) (*http.Response, error) {
     req = req.WithContext(ctx)
+    err := runtime.ApplySecurity(req, op.Security, c.SecurityProviders)
+    if err != nil {
+        return nil, err
+    }
     if c.RequestEditor != nil {
         err = c.RequestEditor(req, ctx)
         if err != nil {
@@ -243,18 +519,309 @@ func (c *Client) {{$opid}}{{.Suffix}}(ctx context.Context{{genParamArgs $pathPar
     }
     return c.Client.Do(req)
 }
-{{end}}{{/* range .Bodies */}}
+
+{{template "request-builders.tmpl" .}}
+`,
+	"imports.tmpl": `// Package {{.PackageName}} provides primitives to interact the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version {{.Version}} DO NOT EDIT.
+// Input hash: {{.InputHash}}
+package {{.PackageName}}
+
+{{if .Imports}}
+import (
+{{range .Imports}} {{.}}
+{{end}})
+{{end}}
+`,
+	"inline.tmpl": `// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+{{range .}}
+    "{{.}}",{{end}}
+}
+
+// The spec is decoded once, by the first call to GetSwagger or GetSwaggerCopy.
+var (
+    swaggerOnce  sync.Once
+    swaggerJSON  []byte
+    swaggerCache *openapi3.Swagger
+    swaggerErr   error
+)
+
+func loadSwagger() {
+    swaggerJSON, swaggerErr = decompressSwagger()
+    if swaggerErr == nil {
+        swaggerCache, swaggerErr = decodeSwagger(swaggerJSON)
+    }
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The spec is decoded on the first call, and the same one is
+// returned from then on, so it must not be modified. Use GetSwaggerCopy for a
+// spec which may be.
+func GetSwagger() (*openapi3.Swagger, error) {
+    swaggerOnce.Do(loadSwagger)
+    return swaggerCache, swaggerErr
+}
+
+// GetSwaggerCopy returns a copy of the spec which GetSwagger returns, which is
+// the caller's own, so may be modified.
+func GetSwaggerCopy() (*openapi3.Swagger, error) {
+    swaggerOnce.Do(loadSwagger)
+    if swaggerErr != nil {
+        return nil, swaggerErr
+    }
+    return decodeSwagger(swaggerJSON)
+}
+
+func decodeSwagger(data []byte) (*openapi3.Swagger, error) {
+    swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(data)
+    if err != nil {
+        return nil, fmt.Errorf("error loading Swagger: %s", err)
+    }
+    return swagger, nil
+}
+
+func decompressSwagger() ([]byte, error) {
+    zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+    if err != nil {
+        return nil, fmt.Errorf("error base64 decoding spec: %s", err)
+    }
+    zr, err := gzip.NewReader(bytes.NewReader(zipped))
+    if err != nil {
+        return nil, fmt.Errorf("error decompressing spec: %s", err)
+    }
+    var buf bytes.Buffer
+    _, err = buf.ReadFrom(zr)
+    if err != nil {
+        return nil, fmt.Errorf("error decompressing spec: %s", err)
+    }
+    return buf.Bytes(), nil
+}
+
+// RegisterSpecHandlers adds routes to the router which serve the spec as JSON
+// and YAML, and as an HTML reference page, at the paths given by opts, such as
+// runtime.DefaultSpecHandlerOptions().
+func RegisterSpecHandlers(router runtime.EchoRouter, opts runtime.SpecHandlerOptions) error {
+    swagger, err := GetSwagger()
+    if err != nil {
+        return err
+    }
+    return runtime.RegisterSpecHandlers(router, swagger, opts)
+}
+`,
+	"operations.tmpl": `// Operations describes each of the operations of the API, as the spec does, in
+// the order of their paths and methods.
+var Operations = []runtime.OperationInfo{
+{{range .}}    {
+        OperationID: {{printf "%q" .Spec.OperationID}},
+        Method: "{{.Method}}",
+        Path: {{printf "%q" .Path}},
+{{if .Spec.Tags}}        Tags: []string{ {{range .Spec.Tags}}{{printf "%q" .}}, {{end}}},
+{{end}}{{if .SecurityRequirements}}        Security: []runtime.SecurityRequirement{
+{{range .SecurityRequirements}}            { {{range $scheme, $scopes := .}}{{printf "%q" $scheme}}: { {{range $scopes}}{{printf "%q" .}}, {{end}}}, {{end}}},
+{{end}}        },
+{{end}}{{if .Spec.Deprecated}}        Deprecated: true,
+{{end}}{{with .VendorExtensions}}        Extensions: map[string]json.RawMessage{
+{{range $name, $value := .}}            {{printf "%q" $name}}: json.RawMessage({{printf "%q" $value}}),
+{{end}}        },
+{{end}}    },
+{{end}}}
+
+// OperationsByRoute indexes Operations by method and path, eg "GET /pets/{id}",
+// as runtime.RouteKey makes them.
+var OperationsByRoute = map[string]*runtime.OperationInfo{
+{{range $i, $op := .}}    {{printf "%s %s" $op.Method $op.Path | printf "%q"}}: &Operations[{{$i}}],
+{{end}}}
+
+// OperationsByID indexes Operations by operationId. Operations without one,
+// which are named by x-go-name, are only in OperationsByRoute.
+var OperationsByID = map[string]*runtime.OperationInfo{
+{{range $i, $op := .}}{{if $op.Spec.OperationID}}    {{printf "%q" $op.Spec.OperationID}}: &Operations[{{$i}}],
+{{end}}{{end}}}
+`,
+	"param-binding.tmpl": `{{/* Binds the parameters of the operation in . from the echo context ctx, into
+the variables which the handler takes */}}
+    var err error
+{{range .PathParams}}// ------------- Path parameter "{{.ParamName}}" -------------
+    var {{$varName := .GoVariableName}}{{$varName}} {{.TypeDef}}
+{{if .IsPassThrough}}
+    {{$varName}} = runtime.PathParam(ctx, "{{.ParamName}}")
+{{end}}
+{{if .IsJson}}
+    err = json.Unmarshal([]byte(runtime.PathParam(ctx, "{{.ParamName}}")), &{{$varName}})
+    if err != nil {
+        return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON")
+    }
+{{end}}
+{{if .IsStyled}}
+{{genBindStyledParam .Style . (printf "runtime.PathParam(ctx, %q)" .ParamName) $varName}}
+{{end}}
+{{end}}
+
+{{if .RequiresParamObject}}
+    // Parameter object where we will unmarshal all parameters from the context
+    var params {{.OperationId}}Params
+{{range $paramIdx, $param := .QueryParams}}// ------------- {{if .Required}}Required{{else}}Optional{{end}} query parameter "{{.ParamName}}" -------------
+    if paramValue := ctx.QueryParam("{{.ParamName}}"); paramValue != "" {
+    {{if .IsPassThrough}}
+    params.{{.GoName}} = {{if not .Required}}&{{end}}paramValue
+    {{end}}
+    {{if .IsJson}}
+    var value {{.TypeDef}}
+    err = json.Unmarshal([]byte(paramValue), &value)
+    if err != nil {
+        return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON")
+    }
+    params.{{.GoName}} = {{if not .Required}}&{{end}}value
+    {{end}}
+    }{{if .Required}} else {
+        return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument {{.ParamName}} is required, but not found"))
+    }{{end}}
+    {{if .IsStyled}}
+{{genBindQueryParam .}}
+    {{end}}
+{{end}}
+
+{{if .HeaderParams}}
+    headers := ctx.Request().Header
+{{range .HeaderParams}}// ------------- {{if .Required}}Required{{else}}Optional{{end}} header parameter "{{.ParamName}}" -------------
+    if valueList, found := headers["{{.ParamName}}"]; found {
+        var {{.GoName}} {{.TypeDef}}
+        n := len(valueList)
+        if n != 1 {
+            return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for {{.ParamName}}, got %d", n))
+        }
+{{if .IsPassThrough}}
+        params.{{.GoName}} = {{if not .Required}}&{{end}}valueList[0]
+{{end}}
+{{if .IsJson}}
+        err = json.Unmarshal([]byte(valueList[0]), &{{.GoName}})
+        if err != nil {
+            return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON")
+        }
+{{end}}
+{{if .IsStyled}}
+{{genBindStyledParam .Style . "valueList[0]" .GoName}}
+{{end}}
+        params.{{.GoName}} = {{if not .Required}}&{{end}}{{.GoName}}
+        } {{if .Required}}else {
+            return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter {{.ParamName}} is required, but not found"))
+        }{{end}}
+{{end}}
+{{end}}
+
+{{range .CookieParams}}
+    if cookie, err := ctx.Cookie("{{.ParamName}}"); err == nil {
+    {{if .IsPassThrough}}
+    params.{{.GoName}} = {{if not .Required}}&{{end}}cookie.Value
+    {{end}}
+    {{if .IsJson}}
+    var value {{.TypeDef}}
+    var decoded string
+    decoded, err := url.QueryUnescape(cookie.Value)
+    if err != nil {
+        return echo.NewHTTPError(http.StatusBadRequest, "Error unescaping cookie parameter '{{.ParamName}}'")
+    }
+    err = json.Unmarshal([]byte(decoded), &value)
+    if err != nil {
+        return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON")
+    }
+    params.{{.GoName}} = {{if not .Required}}&{{end}}value
+    {{end}}
+    {{if .IsStyled}}
+    var value {{.TypeDef}}
+{{genBindStyledParam "simple" . "cookie.Value" "value"}}
+    params.{{.GoName}} = {{if not .Required}}&{{end}}value
+    {{end}}
+    }{{if .Required}} else {
+        return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument {{.ParamName}} is required, but not found"))
+    }{{end}}
+
+{{end}}{{/* .CookieParams */}}
+
+{{end}}{{/* .RequiresParamObject */}}
+{{if opts.ValidateParams}}
+    var errs openapi_types.ValidationErrors
+{{genValidateParams .}}
+    if err := errs.Err(); err != nil {
+        return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid parameters: %s", err))
+    }
 {{end}}
+`,
+	"param-types.tmpl": `{{range .}}{{$opid := .OperationId}}
+{{range .TypeDefinitions}}
+// {{.TypeName}} defines parameters for {{$opid}}.{{with .Doc.Comment}}
+//
+{{.}}{{end}}
+type {{.TypeName}} {{.Schema.TypeDecl}}
+{{end}}
+{{end}}
+`,
+	"register.tmpl": `// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router runtime.EchoRouter, si ServerInterface) {
+    registerHandlers(router, si, "", nil)
+}
+
+// RegisterHandlersWithBaseURL adds each server route to the EchoRouter, under
+// the path of baseURL, which is typically the URL of one of the servers in the
+// spec. The routes of https://example.com/v2 are under /v2, for example.
+func RegisterHandlersWithBaseURL(router runtime.EchoRouter, si ServerInterface, baseURL string) error {
+    basePath, err := runtime.BaseURLPath(baseURL)
+    if err != nil {
+        return err
+    }
+    registerHandlers(router, si, basePath, nil)
+    return nil
+}
 
-{{/* Generate request builders */}}
+// RegisterInstrumentedHandlers adds each server route to the EchoRouter under
+// the path of baseURL, as RegisterHandlersWithBaseURL does, and tells
+// instrumentation of each request which the routes handle, for tracing and
+// metrics.
+func RegisterInstrumentedHandlers(router runtime.EchoRouter, si ServerInterface, baseURL string, instrumentation runtime.Instrumentation) error {
+    basePath, err := runtime.BaseURLPath(baseURL)
+    if err != nil {
+        return err
+    }
+    registerHandlers(router, si, basePath, instrumentation)
+    return nil
+}
+
+func registerHandlers(router runtime.EchoRouter, si ServerInterface, basePath string, instrumentation runtime.Instrumentation) {
+{{if .}}
+    wrapper := ServerInterfaceWrapper{
+        Handler: si,
+    }
+{{end}}
+{{range $i, $op := .}}router.{{.Method}}(basePath+"{{.Path | swaggerUriToEchoUri}}", runtime.InstrumentHandler(wrapper.{{.OperationId}}, &Operations[{{$i}}], instrumentation))
+{{end}}
+}
+`,
+	"request-bodies.tmpl": `{{range .}}{{$opid := .OperationId}}
+{{range .Bodies}}
+// {{$opid}}RequestBody defines body for {{$opid}} for application/json ContentType.
+type {{$opid}}{{.NameTag}}RequestBody {{.TypeDef}}
+{{if canHaveMethods .Schema}}
+// Validate checks {{$opid}}{{.NameTag}}RequestBody against the constraints of its schema.
+func (v {{$opid}}{{.NameTag}}RequestBody) Validate() error {
+    return openapi_types.ValidateValue({{.TypeDef}}(v))
+}
+{{end}}{{end}}
+{{end}}
+`,
+	"request-builders.tmpl": `{{/* Generate request builders */}}
 {{range .}}
 {{$hasParams := .RequiresParamObject -}}
 {{$pathParams := .PathParams -}}
 {{$bodyRequired := .BodyRequired -}}
 {{$opid := .OperationId -}}
+{{$deprecated := .DeprecatedComment -}}
 
 {{range .Bodies}}
-// New{{$opid}}Request{{.Suffix}} calls the generic {{$opid}} builder with {{.ContentType}} body
+// New{{$opid}}Request{{.Suffix}} calls the generic {{$opid}} builder with {{.ContentType}} body{{with $deprecated}}
+//
+{{.}}{{end}}
 func New{{$opid}}Request{{.Suffix}}(server string{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}, body {{.Schema.TypeDecl}}) (*http.Request, error) {
     var bodyReader io.Reader
     buf, err := json.Marshal(body)
@@ -266,13 +833,15 @@ func New{{$opid}}Request{{.Suffix}}(server string{{genParamArgs $pathParams}}{{i
 }
 {{end}}
 
-// New{{$opid}}Request{{if .HasBody}}WithBody{{end}} generates requests for {{$opid}}{{if .HasBody}} with any type of body{{end}}
+// New{{$opid}}Request{{if .HasBody}}WithBody{{end}} generates requests for {{$opid}}{{if .HasBody}} with any type of body{{end}}{{with $deprecated}}
+//
+{{.}}{{end}}
 func New{{$opid}}Request{{if .HasBody}}WithBody{{end}}(server string{{genParamArgs $pathParams}}{{if $hasParams}}, params *{{$opid}}Params{{end}}{{if .HasBody}}, contentType string, body io.Reader{{end}}) (*http.Request, error) {
     var err error
 {{range $paramIdx, $param := .PathParams}}
     var pathParam{{$paramIdx}} string
     {{if .IsPassThrough}}
-    pathParam{{$paramIdx}} = {{.ParamName}}
+    pathParam{{$paramIdx}} = url.PathEscape({{.GoVariableName}})
     {{end}}
     {{if .IsJson}}
     var pathParamBuf{{$paramIdx}} []byte
@@ -280,23 +849,23 @@ func New{{$opid}}Request{{if .HasBody}}WithBody{{end}}(server string{{genParamAr
     if err != nil {
         return nil, err
     }
-    pathParam{{$paramIdx}} = string(pathParamBuf{{$paramIdx}})
+    pathParam{{$paramIdx}} = url.PathEscape(string(pathParamBuf{{$paramIdx}}))
     {{end}}
     {{if .IsStyled}}
-    pathParam{{$paramIdx}}, err = runtime.StyleParam("{{.Style}}", {{.Explode}}, "{{.ParamName}}", {{.GoVariableName}})
+    pathParam{{$paramIdx}}, err = runtime.StyleParamWithOptions("{{.Style}}", {{.Explode}}, "{{.ParamName}}", {{.GoVariableName}}, runtime.StyleParamOptions{Location: runtime.ParamLocationPath})
     if err != nil {
         return nil, err
     }
     {{end}}
 {{end}}
-    queryUrl := fmt.Sprintf("%s{{genParamFmtString .Path}}", server{{range $paramIdx, $param := .PathParams}}, pathParam{{$paramIdx}}{{end}})
+    operationPath := {{if .PathParams}}fmt.Sprintf({{printf "%q" (genParamFmtString .Path)}}{{range $paramIdx, $param := .PathParams}}, pathParam{{$paramIdx}}{{end}}){{else}}{{printf "%q" .Path}}{{end}}
 {{if .QueryParams}}
     var queryStrings []string
 {{range $paramIdx, $param := .QueryParams}}
     var queryParam{{$paramIdx}} string
     {{if not .Required}} if params.{{.GoName}} != nil { {{end}}
     {{if .IsPassThrough}}
-    queryParam{{$paramIdx}} = "{{.ParamName}}=" + {{if not .Required}}*{{end}}params.{{.GoName}}
+    queryParam{{$paramIdx}} = "{{.ParamName}}=" + url.QueryEscape({{if not .Required}}*{{end}}params.{{.GoName}})
     {{end}}
     {{if .IsJson}}
     var queryParamBuf{{$paramIdx}} []byte
@@ -304,11 +873,11 @@ func New{{$opid}}Request{{if .HasBody}}WithBody{{end}}(server string{{genParamAr
     if err != nil {
         return nil, err
     }
-    queryParam{{$paramIdx}} = "{{.ParamName}}=" + string(queryParamBuf{{$paramIdx}})
+    queryParam{{$paramIdx}} = "{{.ParamName}}=" + url.QueryEscape(string(queryParamBuf{{$paramIdx}}))
 
     {{end}}
     {{if .IsStyled}}
-    queryParam{{$paramIdx}}, err = runtime.StyleParam("{{.Style}}", {{.Explode}}, "{{.ParamName}}", {{if not .Required}}*{{end}}params.{{.GoName}})
+    queryParam{{$paramIdx}}, err = runtime.StyleParamWithOptions("{{.Style}}", {{.Explode}}, "{{.ParamName}}", {{if not .Required}}*{{end}}params.{{.GoName}}, runtime.StyleParamOptions{Location: runtime.ParamLocationQuery{{if .Spec.AllowReserved}}, AllowReserved: true{{end}}})
     if err != nil {
         return nil, err
     }
@@ -316,11 +885,12 @@ func New{{$opid}}Request{{if .HasBody}}WithBody{{end}}(server string{{genParamAr
     queryStrings = append(queryStrings, queryParam{{$paramIdx}})
     {{if not .Required}}}{{end}}
 {{end}}
-    if len(queryStrings) != 0 {
-        queryUrl += "?" + strings.Join(queryStrings, "&")
-    }
 {{end}}{{/* if .QueryParams */}}
-    req, err := http.NewRequest("{{.Method}}", queryUrl, {{if .HasBody}}body{{else}}nil{{end}})
+    queryURL, err := runtime.OperationURL(server, operationPath, {{if .QueryParams}}queryStrings{{else}}nil{{end}})
+    if err != nil {
+        return nil, err
+    }
+    req, err := http.NewRequest("{{.Method}}", queryURL.String(), {{if .HasBody}}body{{else}}nil{{end}})
     if err != nil {
         return nil, err
     }
@@ -382,203 +952,118 @@ func New{{$opid}}Request{{if .HasBody}}WithBody{{end}}(server string{{genParamAr
 
 {{end}}{{/* Range */}}
 `,
-	"imports.tmpl": `// Package {{.PackageName}} provides primitives to interact the openapi HTTP API.
-//
-// Code generated by github.com/deepmap/oapi-codegen DO NOT EDIT.
-package {{.PackageName}}
-
-{{if .Imports}}
-import (
-{{range .Imports}} "{{.}}"
-{{end}})
+	"security.tmpl": `{{if .}}
+// The names of the security schemes of the spec, under which their providers
+// go in Client.SecurityProviders.
+const (
+{{range .}}    {{.GoName}}SecurityScheme = {{printf "%q" .Name}}{{with .DescriptionLine}} // {{.}}{{end}}
+{{end -}}
+)
 {{end}}
-`,
-	"inline.tmpl": `// Base64 encoded, gzipped, json marshaled Swagger object
-var swaggerSpec = []string{
 {{range .}}
-    "{{.}}",{{end}}
+{{- if eq .Provider "basic"}}
+// New{{.GoName}}SecurityProvider returns a provider for the {{.Name}} security
+// scheme, which authenticates with HTTP basic authentication.
+func New{{.GoName}}SecurityProvider(username, password string) *runtime.BasicAuthProvider {
+    return runtime.NewBasicAuthProvider(username, password)
 }
-
-// GetSwagger returns the Swagger specification corresponding to the generated code
-// in this file.
-func GetSwagger() (*openapi3.Swagger, error) {
-    zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
-    if err != nil {
-        return nil, fmt.Errorf("error base64 decoding spec: %s", err)
-    }
-    zr, err := gzip.NewReader(bytes.NewReader(zipped))
-    if err != nil {
-        return nil, fmt.Errorf("error decompressing spec: %s", err)
-    }
-    var buf bytes.Buffer
-    _, err = buf.ReadFrom(zr)
-    if err != nil {
-        return nil, fmt.Errorf("error decompressing spec: %s", err)
-    }
-
-    swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(buf.Bytes())
-    if err != nil {
-        return nil, fmt.Errorf("error loading Swagger: %s", err)
-    }
-    return swagger, nil
+{{else if eq .Provider "bearer"}}
+// New{{.GoName}}SecurityProvider returns a provider for the {{.Name}} security
+// scheme, which authenticates with a bearer token.
+func New{{.GoName}}SecurityProvider(token string) *runtime.BearerTokenProvider {
+    return runtime.NewBearerTokenProvider(token)
 }
-`,
-	"param-types.tmpl": `{{range .}}{{$opid := .OperationId}}
-{{range .TypeDefinitions}}
-// {{.TypeName}} defines parameters for {{$opid}}.
-type {{.TypeName}} {{.Schema.TypeDecl}}
-{{end}}
-{{end}}
-`,
-	"register.tmpl": `// RegisterHandlers adds each server route to the EchoRouter.
-func RegisterHandlers(router runtime.EchoRouter, si ServerInterface) {
-{{if .}}
-    wrapper := ServerInterfaceWrapper{
-        Handler: si,
-    }
-{{end}}
-{{range .}}router.{{.Method}}("{{.Path | swaggerUriToEchoUri}}", wrapper.{{.OperationId}})
-{{end}}
+{{else if eq .Provider "apiKey"}}
+// New{{.GoName}}SecurityProvider returns a provider for the {{.Name}} security
+// scheme, which sends the API key in the {{.ParamName}} {{.In}}{{if eq .In "query"}} parameter{{end}}.
+func New{{.GoName}}SecurityProvider(key string) *runtime.APIKeyProvider {
+    return &runtime.APIKeyProvider{In: {{printf "%q" .In}}, Name: {{printf "%q" .ParamName}}, Key: key}
+}
+{{else if eq .Provider "clientCredentials"}}
+// New{{.GoName}}SecurityProvider returns a provider for the {{.Name}} security
+// scheme, which obtains access tokens with the client credentials flow from
+// {{.TokenURL}}. Its TokenURL may be changed, for other servers.
+func New{{.GoName}}SecurityProvider(clientID, clientSecret string) *runtime.OAuth2ClientCredentials {
+    return runtime.NewOAuth2ClientCredentials({{printf "%q" .TokenURL}}, clientID, clientSecret)
 }
-`,
-	"request-bodies.tmpl": `{{range .}}{{$opid := .OperationId}}
-{{range .Bodies}}
-// {{$opid}}RequestBody defines body for {{$opid}} for application/json ContentType.
-type {{$opid}}{{.NameTag}}RequestBody {{.TypeDef}}
-{{end}}
 {{end}}
+{{- end}}
 `,
 	"server-interface.tmpl": `// ServerInterface represents all server handlers.
 type ServerInterface interface {
-{{range .}}{{.SummaryAsComment -}}
-// ({{.Method}} {{.Path}})
+{{range .}}{{.DocComment (printf "(%s %s)" .Method .Path)}}
 {{.OperationId}}(ctx echo.Context{{genParamArgs .PathParams}}{{if .RequiresParamObject}}, params {{.OperationId}}Params{{end}}) error
 {{end}}
 }
 `,
-	"typedef.tmpl": `{{range .Types}}
-// {{.TypeName}} defines model for {{.JsonName}}.
-type {{.TypeName}} {{.Schema.TypeDecl}}
-{{end}}
-`,
-	"wrappers.tmpl": `// ServerInterfaceWrapper converts echo contexts to parameters.
-type ServerInterfaceWrapper struct {
-    Handler ServerInterface
-}
+	"servers.tmpl": `{{range .}}{{$server := .}}
+{{- if .Variables}}
+{{range .Variables}}{{if .Enum}}{{$type := .TypeName}}
+// {{.TypeName}} is the {{.Name}} variable of the URL of {{$server.Name}}.{{if .Description}}
+// {{.DescriptionLine}}{{end}}
+type {{.TypeName}} string
 
-{{range .}}{{$opid := .OperationId}}// {{$opid}} converts echo context to params.
-func (w *ServerInterfaceWrapper) {{.OperationId}} (ctx echo.Context) error {
-    var err error
-{{range .PathParams}}// ------------- Path parameter "{{.ParamName}}" -------------
-    var {{$varName := .GoVariableName}}{{$varName}} {{.TypeDef}}
-{{if .IsPassThrough}}
-    {{$varName}} = ctx.Param("{{.ParamName}}")
-{{end}}
-{{if .IsJson}}
-    err = json.Unmarshal([]byte(ctx.Param("{{.ParamName}}")), &{{$varName}})
-    if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON")
-    }
-{{end}}
-{{if .IsStyled}}
-    err = runtime.BindStyledParameter("{{.Style}}",{{.Explode}}, "{{.ParamName}}", ctx.Param("{{.ParamName}}"), &{{$varName}})
-    if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err))
-    }
-{{end}}
-{{end}}
+// The values which {{.TypeName}} may take.
+const (
+{{range .Enum}}    {{.ConstName}} {{$type}} = {{printf "%q" .Value}}
+{{end -}}
+)
 
-{{if .RequiresParamObject}}
-    // Parameter object where we will unmarshal all parameters from the context
-    var params {{.OperationId}}Params
-{{range $paramIdx, $param := .QueryParams}}// ------------- {{if .Required}}Required{{else}}Optional{{end}} query parameter "{{.ParamName}}" -------------
-    if paramValue := ctx.QueryParam("{{.ParamName}}"); paramValue != "" {
-    {{if .IsPassThrough}}
-    params.{{.GoName}} = {{if not .Required}}&{{end}}paramValue
-    {{end}}
-    {{if .IsJson}}
-    var value {{.TypeDef}}
-    err = json.Unmarshal([]byte(paramValue), &value)
-    if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON")
-    }
-    params.{{.GoName}} = {{if not .Required}}&{{end}}value
-    {{end}}
-    }{{if .Required}} else {
-        return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument {{.ParamName}} is required, but not found"))
-    }{{end}}
-    {{if .IsStyled}}
-    err = runtime.BindQueryParameter("{{.Style}}", {{.Explode}}, {{.Required}}, "{{.ParamName}}", ctx.QueryParams(), &params.{{.GoName}})
-    if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err))
+// Valid returns whether v is one of the values which the spec allows.
+func (v {{.TypeName}}) Valid() bool {
+    switch v {
+    case {{range $i, $e := .Enum}}{{if $i}}, {{end}}{{$e.ConstName}}{{end}}:
+        return true
     }
-    {{end}}
-{{end}}
+    return false
+}
+{{end}}{{end}}
+// {{.Name}}DefaultURL is the URL of {{if .Description}}{{.DescriptionLine}}{{else}}the server{{end}}, {{.URL}},
+// with the default values of its variables.
+const {{.Name}}DefaultURL = {{printf "%q" .DefaultURL}}
 
-{{if .HeaderParams}}
-    headers := ctx.Request().Header
-{{range .HeaderParams}}// ------------- {{if .Required}}Required{{else}}Optional{{end}} header parameter "{{.ParamName}}" -------------
-    if valueList, found := headers["{{.ParamName}}"]; found {
-        var {{.GoName}} {{.TypeDef}}
-        n := len(valueList)
-        if n != 1 {
-            return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for {{.ParamName}}, got %d", n))
-        }
-{{if .IsPassThrough}}
-        params.{{.GoName}} = {{if not .Required}}&{{end}}valueList[0]
-{{end}}
-{{if .IsJson}}
-        err = json.Unmarshal([]byte(valueList[0]), &{{.GoName}})
-        if err != nil {
-            return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON")
-        }
+// New{{.Name}}URL returns the URL of {{if .Description}}{{.DescriptionLine}}{{else}}the server{{end}}, {{.URL}},
+// with the given values of its variables.
+func New{{.Name}}URL({{.ParamArgs}}) (string, error) {
+{{- range .Variables}}{{if .Enum}}
+    if !{{.GoVariableName}}.Valid() {
+        return "", fmt.Errorf("invalid value for server variable {{.Name}}: %q", {{.GoVariableName}})
+    }
+{{- end}}{{end}}
+    return {{.URLExpression}}, nil
+}
+{{else}}
+// {{.Name}}URL is the URL of {{if .Description}}{{.DescriptionLine}}{{else}}the server{{end}}.
+const {{.Name}}URL = {{printf "%q" .URL}}
 {{end}}
-{{if .IsStyled}}
-        err = runtime.BindStyledParameter("{{.Style}}",{{.Explode}}, "{{.ParamName}}", valueList[0], &{{.GoName}})
-        if err != nil {
-            return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err))
-        }
 {{end}}
-        params.{{.GoName}} = {{if not .Required}}&{{end}}{{.GoName}}
-        } {{if .Required}}else {
-            return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter {{.ParamName}} is required, but not found"))
-        }{{end}}
+`,
+	"typedef.tmpl": `{{range .Types}}
+// {{.TypeName}} defines model for {{.JsonName}}.{{with .Doc.Comment}}
+//
+{{.}}{{end}}
+type {{.TypeName}} {{if .IsAlias}}= {{end}}{{.Schema.TypeDecl}}
 {{end}}
+`,
+	"validate.tmpl": `{{range .Types}}
+// Validate checks {{.TypeName}} against the constraints of its schema, and
+// returns every violation as openapi_types.ValidationErrors.
+func (v {{.TypeName}}) Validate() error {
+    var errs openapi_types.ValidationErrors
+{{genValidate .}}
+    return errs.Err()
+}
 {{end}}
+`,
+	"wrappers.tmpl": `// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+    Handler ServerInterface
+}
 
-{{range .CookieParams}}
-    if cookie, err := ctx.Cookie("{{.ParamName}}"); err == nil {
-    {{if .IsPassThrough}}
-    params.{{.GoName}} = {{if not .Required}}&{{end}}cookie.Value
-    {{end}}
-    {{if .IsJson}}
-    var value {{.TypeDef}}
-    var decoded string
-    decoded, err := url.QueryUnescape(cookie.Value)
-    if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, "Error unescaping cookie parameter '{{.ParamName}}'")
-    }
-    err = json.Unmarshal([]byte(decoded), &value)
-    if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter '{{.ParamName}}' as JSON")
-    }
-    params.{{.GoName}} = {{if not .Required}}&{{end}}value
-    {{end}}
-    {{if .IsStyled}}
-    var value {{.TypeDef}}
-    err = runtime.BindStyledParameter("simple",{{.Explode}}, "{{.ParamName}}", cookie.Value, &value)
-    if err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter {{.ParamName}}: %s", err))
-    }
-    params.{{.GoName}} = {{if not .Required}}&{{end}}value
-    {{end}}
-    }{{if .Required}} else {
-        return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Query argument {{.ParamName}} is required, but not found"))
-    }{{end}}
-
-{{end}}{{/* .CookieParams */}}
-
-{{end}}{{/* .RequiresParamObject */}}
+{{range $i, $op := .}}{{$opid := .OperationId}}// {{$opid}} converts echo context to params.
+func (w *ServerInterfaceWrapper) {{.OperationId}} (ctx echo.Context) error {
+    runtime.SetOperationInfo(ctx, &Operations[{{$i}}])
+{{template "param-binding.tmpl" .}}
     // Invoke the callback with all the unmarshalled arguments
     err = w.Handler.{{.OperationId}}(ctx{{genParamNames .PathParams}}{{if .RequiresParamObject}}, params{{end}})
     return err
@@ -0,0 +1,343 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// All the component sections produce types in the same Go package, and so do
+// operations, so names such as "Foo" in both #/components/schemas and
+// #/components/responses, or a schema called "FindPetsParams" next to an
+// operation called "findPets", would be declared twice. We resolve these by
+// giving the losing components the suffix of their section, in this order of
+// precedence.
+var componentSections = []struct {
+	Name   string
+	Suffix string
+}{
+	{"schemas", "Schema"},
+	{"parameters", "Parameter"},
+	{"requestBodies", "RequestBody"},
+	{"responses", "Response"},
+}
+
+// TypeRename describes a component whose Go type was renamed, because its name
+// collided with another type.
+type TypeRename struct {
+	Ref     string // The reference path of the component, eg #/components/schemas/Foo
+	Name    string // The name the type would have had
+	NewName string // The name it was given instead
+	Owner   string // What the original name belongs to
+}
+
+func (r TypeRename) String() string {
+	return fmt.Sprintf("%s is generated as %s, since %s is used by %s", r.Ref, r.NewName, r.Name, r.Owner)
+}
+
+// A component which we generate a type for.
+type namedComponent struct {
+	ref      string
+	suffix   string
+	name     string
+	explicit bool // The name was chosen with x-go-name, so we won't change it
+}
+
+// ResolveTypeNames works out the Go type names of everything under
+// #/components. The result is keyed by reference path, and includes every
+// component which we generate a type for. Colliding names are reported in the
+// returned renames, or, if opts.StrictTypeNames is set, as an error.
+func ResolveTypeNames(swagger *openapi3.Swagger, opts Options) (map[string]string, []TypeRename, error) {
+	explicitNames, err := componentGoNames(swagger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Operations and boilerplate have fixed names, so components have to give
+	// way to them.
+	owners, err := reservedTypeNames(swagger, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var components []namedComponent
+	for _, section := range componentSections {
+		for _, name := range componentsWithTypes(swagger, section.Name) {
+			ref := "#/components/" + section.Name + "/" + name
+			c := namedComponent{ref: ref, suffix: section.Suffix, name: ToCamelCase(name)}
+			if goName, found := explicitNames[ref]; found {
+				c.name = goName
+				c.explicit = true
+			}
+			components = append(components, c)
+		}
+	}
+
+	// Schemas with readOnly or writeOnly properties may have request and
+	// response variants, whose names are taken as well.
+	var variantRefs map[string]bool
+	if opts.SplitReadWriteTypes {
+		variantRefs = readWriteSchemaRefs(swagger.Components.Schemas)
+		for _, c := range components {
+			if variantRefs[c.ref] {
+				reserveVariantNames(owners, c.name, c.ref)
+			}
+		}
+	}
+
+	names := make(map[string]string)
+
+	// Names chosen in the spec are claimed first, and can't be moved.
+	for _, c := range components {
+		if !c.explicit {
+			continue
+		}
+		if owner, found := owners[c.name]; found {
+			return nil, nil, fmt.Errorf("the x-go-name %s of %s is already used by %s", c.name, c.ref, owner)
+		}
+		owners[c.name] = c.ref
+		names[c.ref] = c.name
+	}
+
+	// Then, in order of precedence, every other component claims its name
+	// if it's free.
+	var losers []namedComponent
+	for _, c := range components {
+		if c.explicit {
+			continue
+		}
+		if _, found := owners[c.name]; found {
+			losers = append(losers, c)
+			continue
+		}
+		owners[c.name] = c.ref
+		names[c.ref] = c.name
+	}
+
+	if len(losers) != 0 && opts.StrictTypeNames {
+		var msgs []string
+		for _, c := range losers {
+			msgs = append(msgs, fmt.Sprintf("%s is used by both %s and %s", c.name, owners[c.name], c.ref))
+		}
+		return nil, nil, fmt.Errorf("type name collisions: %s", strings.Join(msgs, "; "))
+	}
+
+	// The rest are renamed with the suffix of their section, and a number,
+	// should that be taken, too.
+	var renames []TypeRename
+	for _, c := range losers {
+		taken := func(name string) bool {
+			if variantRefs[c.ref] && (owners[name+requestVariantSuffix] != "" || owners[name+responseVariantSuffix] != "") {
+				return true
+			}
+			return owners[name] != ""
+		}
+		newName := c.name + c.suffix
+		for i := 2; taken(newName); i++ {
+			newName = fmt.Sprintf("%s%s%d", c.name, c.suffix, i)
+		}
+		if variantRefs[c.ref] {
+			reserveVariantNames(owners, newName, c.ref)
+		}
+		renames = append(renames, TypeRename{
+			Ref:     c.ref,
+			Name:    c.name,
+			NewName: newName,
+			Owner:   owners[c.name],
+		})
+		owners[newName] = c.ref
+		names[c.ref] = newName
+	}
+	return names, renames, nil
+}
+
+// Reserves the names of the request and response variants of a component's
+// type, unless something else already has them.
+func reserveVariantNames(owners map[string]string, name string, ref string) {
+	for _, suffix := range []string{requestVariantSuffix, responseVariantSuffix} {
+		if owners[name+suffix] == "" {
+			owners[name+suffix] = "the " + strings.ToLower(suffix) + " variant of " + ref
+		}
+	}
+}
+
+// Returns the names, in order, of the components in a section for which we
+// generate types. We only generate types for JSON responses and request bodies.
+func componentsWithTypes(swagger *openapi3.Swagger, section string) []string {
+	var names []string
+	switch section {
+	case "schemas":
+		names = SortedSchemaKeys(swagger.Components.Schemas)
+	case "parameters":
+		names = SortedParameterKeys(swagger.Components.Parameters)
+	case "responses":
+		for _, name := range SortedResponsesKeys(swagger.Components.Responses) {
+			r := swagger.Components.Responses[name]
+			if r.Value != nil && r.Value.Content.Get("application/json") != nil {
+				names = append(names, name)
+			}
+		}
+	case "requestBodies":
+		for _, name := range SortedRequestBodyKeys(swagger.Components.RequestBodies) {
+			b := swagger.Components.RequestBodies[name]
+			if b.Value != nil && b.Value.Content.Get("application/json") != nil {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// Returns the type names which operations and the boilerplate code will
+// declare, mapped to a description of what declares them.
+func reservedTypeNames(swagger *openapi3.Swagger, opts Options) (map[string]string, error) {
+	owners := make(map[string]string)
+
+	var boilerplate []string
+	if opts.GenerateClient {
+		boilerplate = append(boilerplate, "RequestEditorFn", "Client", "ClientInterface", "NewClient",
+			"ClientWithResponses", "NewClientWithResponses", "NewClientWithResponsesAndRequestEditorFunc", "NewClientWithResponsesAndSecurityProviders")
+		schemes, err := DescribeSecuritySchemes(swagger.Components.SecuritySchemes)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range securityTypeNames(schemes) {
+			owners[name] = "the security schemes"
+		}
+	}
+	if opts.GenerateServer {
+		boilerplate = append(boilerplate, "ServerInterface", "ServerInterfaceWrapper", "RegisterHandlers",
+			"RegisterHandlersWithBaseURL", "RegisterInstrumentedHandlers")
+	}
+	if opts.GenerateClient || opts.GenerateServer {
+		boilerplate = append(boilerplate, "Operations", "OperationsByRoute", "OperationsByID")
+	}
+	if opts.GenerateTypes {
+		servers, err := DescribeServers(swagger.Servers)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range serverTypeNames(servers) {
+			owners[name] = "the server URLs"
+		}
+	}
+	if opts.EmbedSpec {
+		boilerplate = append(boilerplate, "GetSwagger", "GetSwaggerCopy", "RegisterSpecHandlers")
+	}
+	for _, name := range boilerplate {
+		owners[name] = "the generated boilerplate"
+	}
+
+	for _, requestPath := range SortedPathsKeys(swagger.Paths) {
+		pathItem := swagger.Paths[requestPath]
+		pathOps := pathItem.Operations()
+		for _, method := range SortedOperationsKeys(pathOps) {
+			op := pathOps[method]
+			opName, err := operationGoName(op)
+			if err != nil {
+				return nil, err
+			}
+			owner := fmt.Sprintf("operation %s %s", method, requestPath)
+			reserveOperationTypeNames(owners, ToCamelCase(opName), owner, pathItem, op)
+			if opts.GenerateClient {
+				owners[LowercaseFirstCharacter(ToCamelCase(opName))+responseTypeSuffix] = owner
+				for _, sr := range streamingResponses(op) {
+					if name := streamTypeName(ToCamelCase(opName), sr); name != "" {
+						owners[name] = owner
+					}
+				}
+				for _, responseName := range SortedResponsesKeys(op.Responses) {
+					response := op.Responses[responseName]
+					if response != nil && response.Value != nil && len(responseHeaderNames(response.Value)) != 0 {
+						owners[responseHeadersTypeName(ToCamelCase(opName), responseName)] = owner
+					}
+				}
+			}
+		}
+	}
+
+	cbOps, err := callbackOperations(swagger)
+	if err != nil {
+		return nil, err
+	}
+	for _, cbOp := range cbOps {
+		owner := fmt.Sprintf("callback %s %s", cbOp.method, cbOp.callback.Name)
+		reserveOperationTypeNames(owners, cbOp.goName, owner, cbOp.pathItem, cbOp.op)
+	}
+	if len(cbOps) != 0 {
+		var callbackBoilerplate []string
+		if opts.GenerateServer {
+			callbackBoilerplate = append(callbackBoilerplate, "CallbackClient")
+		}
+		if opts.GenerateClient {
+			callbackBoilerplate = append(callbackBoilerplate, "CallbackServerInterface",
+				"CallbackServerInterfaceWrapper", "CallbackPaths", "RegisterCallbackHandlers")
+		}
+		for _, name := range callbackBoilerplate {
+			owners[name] = "the generated boilerplate"
+		}
+	}
+	return owners, nil
+}
+
+// Records the names of the parameter and body types of an operation.
+func reserveOperationTypeNames(owners map[string]string, opName string, owner string,
+	pathItem *openapi3.PathItem, op *openapi3.Operation) {
+	params := append(openapi3.Parameters{}, pathItem.Parameters...)
+	params = append(params, op.Parameters...)
+	for _, p := range params {
+		if p.Value != nil && p.Value.In != openapi3.ParameterInPath {
+			owners[opName+"Params"] = owner
+			break
+		}
+	}
+
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		if content := op.RequestBody.Value.Content.Get("application/json"); content != nil {
+			owners[opName+"JSONRequestBody"] = owner
+			if op.RequestBody.Ref == "" && content.Schema != nil && content.Schema.Ref == "" {
+				owners[opName+"JSONBody"] = owner
+			}
+		}
+	}
+}
+
+// Reports the first type name which is declared by more than one of the given
+// type definitions. Collisions between components are resolved up front, but
+// this catches anything else, such as read/write variants, before it turns
+// into a confusing compile error in the generated code.
+func checkDuplicateTypeNames(types []TypeDefinition) error {
+	seen := make(map[string]string)
+	var names []string
+	for _, td := range types {
+		source := td.JsonName
+		if source == "" {
+			source = "an operation"
+		}
+		if previous, found := seen[td.TypeName]; found {
+			names = append(names, fmt.Sprintf("%s (for %s and %s)", td.TypeName, previous, source))
+			continue
+		}
+		seen[td.TypeName] = source
+	}
+	if len(names) != 0 {
+		sort.Strings(names)
+		return fmt.Errorf("types are declared more than once: %s", strings.Join(names, ", "))
+	}
+	return nil
+}
@@ -194,6 +194,10 @@ func StringInArray(str string, array []string) bool {
 // We only support flat components for now, so no components in a schema under
 // components.
 func RefPathToGoType(refPath string) (string, error) {
+	return defaultGenerator.refPathToGoType(refPath)
+}
+
+func (g *generator) refPathToGoType(refPath string) (string, error) {
 	pathParts := strings.Split(refPath, "/")
 	if pathParts[0] != "#" {
 		return "", errors.New("Only local document components are supported")
@@ -201,7 +205,16 @@ func RefPathToGoType(refPath string) (string, error) {
 	if len(pathParts) != 4 {
 		return "", errors.New("Parameter nesting is deeper than supported")
 	}
-	return ToCamelCase(pathParts[3]), nil
+	return g.componentGoName(pathParts[2], pathParts[3]), nil
+}
+
+// Returns the Go type name for a component in the given section, eg "schemas",
+// which is the x-go-name if the component has one.
+func (g *generator) componentGoName(section string, name string) string {
+	if goName, found := g.componentGoNames["#/components/"+section+"/"+name]; found {
+		return goName
+	}
+	return ToCamelCase(name)
 }
 
 // This function converts a swagger style path URI with parameters to a
@@ -1,11 +1,17 @@
 package codegen
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
-	"github.com/getkin/kin-openapi/openapi3"
-	"gopkg.in/go-playground/validator.v9"
 	"regexp"
+	"strconv"
 	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+	"gopkg.in/go-playground/validator.v9"
 )
 
 type SchemaType string
@@ -51,7 +57,16 @@ var SchemaFormatToRule = map[SchemaFormat]RuleFormat{
 	FormatIPv6:     "ip_v6",
 }
 
-func GenerateValidationRules(sref *openapi3.SchemaRef, required bool) string {
+// Go types on which numeric constraints can be checked.
+var numericGoTypes = []string{"int", "int32", "int64", "float32", "float64"}
+
+// This generates the go-playground validator tags for a field of the given Go
+// type. Format rules are only added to string fields, since types such as
+// time.Time or uuid.UUID are already validated when they're unmarshaled.
+func GenerateValidationRules(sref *openapi3.SchemaRef, required bool, goType string) string {
+	if sref == nil || sref.Value == nil {
+		return ""
+	}
 	schema := sref.Value
 	rules := []string{}
 
@@ -67,12 +82,24 @@ func GenerateValidationRules(sref *openapi3.SchemaRef, required bool) string {
 		rules = append(rules, fmt.Sprintf(`%s`, string(SchemaTypeToRule[SchemaType(schema.Type)])))
 	}
 
-	if schema.Min != nil {
-		rules = append(rules, fmt.Sprintf(`min=%.0f`, *schema.Min))
-	}
+	// Bounds on values are gte/lte, since min and max on a string are
+	// bounds on its length.
+	if StringInArray(goType, numericGoTypes) {
+		if schema.Min != nil {
+			op := "gte"
+			if schema.ExclusiveMin {
+				op = "gt"
+			}
+			rules = append(rules, fmt.Sprintf(`%s=%s`, op, formatFloat(*schema.Min)))
+		}
 
-	if schema.Max != nil {
-		rules = append(rules, fmt.Sprintf(`max=%.0f`, *schema.Max))
+		if schema.Max != nil {
+			op := "lte"
+			if schema.ExclusiveMax {
+				op = "lt"
+			}
+			rules = append(rules, fmt.Sprintf(`%s=%s`, op, formatFloat(*schema.Max)))
+		}
 	}
 
 	if schema.MinLength != 0 {
@@ -83,6 +110,14 @@ func GenerateValidationRules(sref *openapi3.SchemaRef, required bool) string {
 		rules = append(rules, fmt.Sprintf(`max=%d`, *schema.MaxLength))
 	}
 
+	if schema.MinItems != 0 {
+		rules = append(rules, fmt.Sprintf(`min=%d`, schema.MinItems))
+	}
+
+	if schema.MaxItems != nil {
+		rules = append(rules, fmt.Sprintf(`max=%d`, *schema.MaxItems))
+	}
+
 	if schema.Enum != nil {
 		values := ""
 		for _, v := range schema.Enum {
@@ -90,23 +125,400 @@ func GenerateValidationRules(sref *openapi3.SchemaRef, required bool) string {
 			case string:
 				values += fmt.Sprintf(`%s `, v.(string))
 			case float64:
-				values += fmt.Sprintf(`%.0f `, v.(float64))
+				values += fmt.Sprintf(`%s `, formatFloat(v.(float64)))
 			}
 		}
 
 		rules = append(rules, fmt.Sprintf(`oneof=%v`, strings.TrimSpace(values)))
 	}
 
-	if format, hasType := SchemaFormatToRule[SchemaFormat(schema.Format)]; hasType != false {
+	if format, hasType := SchemaFormatToRule[SchemaFormat(schema.Format)]; hasType != false && goType == "string" {
 		rules = append(rules, fmt.Sprintf(`%s`, format))
 	}
 
 	return strings.Join(rules, ",")
 }
 
+// Formats a number from a schema as a Go literal, without losing precision,
+// so 1.5 stays 1.5, and 10 is 10, rather than 10.000000.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
 func IsISO8601Date(fl validator.FieldLevel) bool {
 	ISO8601DateRegexString := "^(-?(?:[1-9][0-9]*)?[0-9]{4})-(1[0-2]|0[1-9])-(3[01]|0[1-9]|[12][0-9])(?:T|\\s)(2[0-3]|[01][0-9]):([0-5][0-9]):([0-5][0-9])?(Z)?$"
 	ISO8601DateRegex := regexp.MustCompile(ISO8601DateRegexString)
 
 	return ISO8601DateRegex.MatchString(fl.Field().String())
 }
+
+// Generates a Validate method for each of the given types, which checks a
+// value against the constraints of the schema it was generated from. Types
+// without any constraints have nothing to check, so get no method, see
+// hasValidateMethod.
+func GenerateValidateMethods(t *template.Template, types []TypeDefinition) (string, error) {
+	var filteredTypes []TypeDefinition
+	for _, td := range types {
+		if hasValidateMethod(td) {
+			filteredTypes = append(filteredTypes, td)
+		}
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	context := struct {
+		Types []TypeDefinition
+	}{
+		Types: filteredTypes,
+	}
+	err := t.ExecuteTemplate(w, "validate.tmpl", context)
+	if err != nil {
+		return "", errors.Wrap(err, "error generating Validate methods")
+	}
+	err = w.Flush()
+	if err != nil {
+		return "", errors.Wrap(err, "error flushing output buffer for Validate methods")
+	}
+	return buf.String(), nil
+}
+
+// Returns whether a type defined by the given schema may have methods, which
+// those defined as interfaces or pointers can't. References are to types which
+// we define, but their Go type tells us what they're defined as.
+func canHaveMethods(s Schema) bool {
+	return !strings.HasPrefix(s.GoType, "interface") && !strings.HasPrefix(s.GoType, "*")
+}
+
+// Returns whether we generate a Validate method for a type. Aliases can't
+// have methods, and a type with a Validate field can't have a Validate method,
+// so we skip those, along with types which have nothing to check.
+func hasValidateMethod(td TypeDefinition) bool {
+	if td.IsAlias || hasValidateField(td.Schema) || !canHaveMethods(td.Schema) {
+		return false
+	}
+	return hasStatements(genValidate(td))
+}
+
+func hasValidateField(s Schema) bool {
+	for _, p := range s.Properties {
+		if p.GoFieldName() == "Validate" {
+			return true
+		}
+	}
+	return false
+}
+
+// This function generates the body of a type's Validate method, which adds
+// every violation to errs, a ValidationErrors.
+func genValidate(td TypeDefinition) string {
+	g := &validateGen{buf: &bytes.Buffer{}}
+	if td.Schema.RefType != "" {
+		// The type is defined as another type, which knows how to validate
+		// itself.
+		g.printf("errs.Nest(\"\", openapi_types.ValidateValue(%s(v)))", td.Schema.RefType)
+	} else {
+		g.value(td.Schema, "v", `""`)
+	}
+	return g.buf.String()
+}
+
+// This function generates the checks for the path parameters and the
+// parameters object of an operation, which the server wrapper runs once they
+// have been bound.
+func genValidateParams(op OperationDefinition) string {
+	g := &validateGen{buf: &bytes.Buffer{}}
+	for _, p := range op.PathParams {
+		g.value(p.Schema, p.GoVariableName(), strconv.Quote("/"+p.ParamName))
+	}
+	for _, td := range op.TypeDefinitions {
+		if td.TypeName == op.OperationId+"Params" && hasValidateMethod(td) {
+			g.printf("errs.Nest(\"\", params.Validate())")
+		}
+	}
+	return g.buf.String()
+}
+
+// validateGen accumulates the code which validates a value. Values are Go
+// expressions, and so are paths, since we only know array indexes and map keys
+// at run time.
+type validateGen struct {
+	buf   *bytes.Buffer
+	depth int // For naming loop variables uniquely
+}
+
+func (g *validateGen) printf(format string, args ...interface{}) {
+	fmt.Fprintf(g.buf, format+"\n", args...)
+}
+
+// Adds a check which records msg, formatted with args, when cond holds.
+func (g *validateGen) check(cond string, path string, msg string, args ...string) {
+	g.printf("if %s {", cond)
+	g.printf("errs.Add(%s, %s)", path, strings.Join(append([]string{strconv.Quote(msg)}, args...), ", "))
+	g.printf("}")
+}
+
+// Runs fn with a fresh buffer, and returns what it generated.
+func (g *validateGen) sub(fn func()) string {
+	saved := g.buf
+	g.buf = &bytes.Buffer{}
+	fn()
+	code := g.buf.String()
+	g.buf = saved
+	return code
+}
+
+// Generates the checks for value, which has the schema s, and is found at
+// path. Schemas which we've put together ourselves, such as for parameter
+// objects, don't have an OpenAPI schema, and so no constraints of their own.
+func (g *validateGen) value(s Schema, value string, path string) {
+	if s.RefType != "" {
+		g.printf("errs.Nest(%s, openapi_types.ValidateValue(%s))", path, value)
+		return
+	}
+	switch {
+	case len(s.Properties) != 0 || s.HasAdditionalProperties:
+		g.object(s, value, path)
+	case strings.HasPrefix(s.GoType, "[]") && s.ArrayType != nil:
+		g.array(s, value, path)
+	case s.OAPISchema == nil:
+	case strings.HasPrefix(s.GoType, "map["):
+		g.propertyCount(s.OAPISchema, fmt.Sprintf("len(%s)", value), path)
+	case s.GoType == "string":
+		g.string(s.OAPISchema, value, path)
+	case StringInArray(s.GoType, numericGoTypes):
+		g.number(s.OAPISchema, value, path)
+	case s.GoType == "json.Number":
+		// Parameters are numbers in strings, which we check once they've
+		// been parsed.
+		number := fmt.Sprintf("f%d", g.depth)
+		g.depth++
+		code := g.sub(func() { g.number(s.OAPISchema, number, path) })
+		if hasStatements(code) {
+			g.printf("if %s, err := %s.Float64(); err != nil {", number, operand(value))
+			g.printf("errs.Add(%s, \"must be a number\")", path)
+			g.printf("} else {\n%s}", code)
+		}
+	}
+}
+
+// Wraps code in an if statement which checks that a pointer isn't nil, unless
+// there's nothing to wrap.
+func (g *validateGen) ifNotNil(pointer string, code string) {
+	if !hasStatements(code) {
+		g.buf.WriteString(code)
+		return
+	}
+	g.printf("if %s != nil {\n%s}", pointer, code)
+}
+
+// Returns whether generated code is more than just comments.
+func hasStatements(code string) bool {
+	for _, line := range strings.Split(code, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "//") {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns an expression for value which may be followed by a selector or an
+// index, which a dereferenced pointer can't be without parentheses.
+func operand(value string) string {
+	if strings.HasPrefix(value, "*") {
+		return "(" + value + ")"
+	}
+	return value
+}
+
+func (g *validateGen) object(s Schema, value string, path string) {
+	for _, p := range s.Properties {
+		if p.JsonIgnore {
+			continue
+		}
+		field := operand(value) + "." + p.GoFieldName()
+		fieldPath := appendPath(path, p.JsonFieldName)
+		if strings.HasPrefix(p.GoTypeDef(), "*") {
+			g.ifNotNil(field, g.sub(func() { g.value(p.Schema, "*"+field, fieldPath) }))
+		} else {
+			g.value(p.Schema, field, fieldPath)
+		}
+	}
+
+	if s.HasAdditionalProperties && s.AdditionalPropertiesType != nil {
+		key := fmt.Sprintf("k%d", g.depth)
+		g.depth++
+		addProps := operand(value) + ".AdditionalProperties"
+		code := g.sub(func() {
+			g.value(*s.AdditionalPropertiesType, addProps+"["+key+"]", appendPathKey(path, key))
+		})
+		if hasStatements(code) {
+			g.printf("for %s := range %s {\n%s}", key, addProps, code)
+		}
+	}
+
+	o := s.OAPISchema
+	if o == nil || (o.MinProps == 0 && o.MaxProps == nil) {
+		return
+	}
+	// Count the properties which are set. Required ones always are, and
+	// optional ones when they're not nil, or empty, for the types which
+	// aren't pointers.
+	count := fmt.Sprintf("n%d", g.depth)
+	g.depth++
+	required := 0
+	var optional []string
+	for _, p := range s.Properties {
+		field := operand(value) + "." + p.GoFieldName()
+		switch {
+		case p.JsonIgnore:
+		case strings.HasPrefix(p.GoTypeDef(), "*"):
+			optional = append(optional, field+" != nil")
+		case !p.Required:
+			optional = append(optional, fmt.Sprintf("len(%s) != 0", field))
+		default:
+			required++
+		}
+	}
+	if s.HasAdditionalProperties {
+		g.printf("%s := %d + len(%s.AdditionalProperties)", count, required, operand(value))
+	} else {
+		g.printf("%s := %d", count, required)
+	}
+	for _, cond := range optional {
+		g.printf("if %s {\n%s++\n}", cond, count)
+	}
+	g.propertyCount(o, count, path)
+}
+
+func (g *validateGen) propertyCount(o *openapi3.Schema, count string, path string) {
+	if o.MinProps != 0 {
+		g.check(fmt.Sprintf("%s < %d", count, o.MinProps), path,
+			"must have at least %d properties", strconv.FormatUint(o.MinProps, 10))
+	}
+	if o.MaxProps != nil {
+		g.check(fmt.Sprintf("%s > %d", count, *o.MaxProps), path,
+			"must have at most %d properties", strconv.FormatUint(*o.MaxProps, 10))
+	}
+}
+
+func (g *validateGen) array(s Schema, value string, path string) {
+	if o := s.OAPISchema; o != nil {
+		if o.MinItems != 0 {
+			g.check(fmt.Sprintf("len(%s) < %d", value, o.MinItems), path,
+				"must have at least %d items", strconv.FormatUint(o.MinItems, 10))
+		}
+		if o.MaxItems != nil {
+			g.check(fmt.Sprintf("len(%s) > %d", value, *o.MaxItems), path,
+				"must have at most %d items", strconv.FormatUint(*o.MaxItems, 10))
+		}
+		if o.UniqueItems {
+			g.check(fmt.Sprintf("!openapi_types.UniqueItems(%s)", value), path,
+				"must not contain duplicate items")
+		}
+	}
+
+	index := fmt.Sprintf("i%d", g.depth)
+	g.depth++
+	code := g.sub(func() {
+		g.value(*s.ArrayType, operand(value)+"["+index+"]", appendPathIndex(path, index))
+	})
+	if hasStatements(code) {
+		g.printf("for %s := range %s {\n%s}", index, value, code)
+	}
+}
+
+func (g *validateGen) string(o *openapi3.Schema, value string, path string) {
+	length := fmt.Sprintf("utf8.RuneCountInString(string(%s))", value)
+	if o.MinLength != 0 {
+		g.check(fmt.Sprintf("%s < %d", length, o.MinLength), path,
+			"must be at least %d characters long", strconv.FormatUint(o.MinLength, 10))
+	}
+	if o.MaxLength != nil {
+		g.check(fmt.Sprintf("%s > %d", length, *o.MaxLength), path,
+			"must be at most %d characters long", strconv.FormatUint(*o.MaxLength, 10))
+	}
+	if o.Pattern != "" {
+		if _, err := regexp.Compile(o.Pattern); err != nil {
+			g.printf("// The pattern %q isn't supported by Go, so it isn't checked.", o.Pattern)
+		} else {
+			g.check(fmt.Sprintf("!openapi_types.MatchPattern(%s, string(%s))", strconv.Quote(o.Pattern), value), path,
+				"must match the pattern %s", strconv.Quote(o.Pattern))
+		}
+	}
+
+	var values []string
+	for _, v := range o.Enum {
+		if str, ok := v.(string); ok && !StringInArray(strconv.Quote(str), values) {
+			values = append(values, strconv.Quote(str))
+		}
+	}
+	g.enum(fmt.Sprintf("string(%s)", value), values, path)
+}
+
+func (g *validateGen) number(o *openapi3.Schema, value string, path string) {
+	number := fmt.Sprintf("float64(%s)", value)
+	if o.Min != nil {
+		if o.ExclusiveMin {
+			g.check(fmt.Sprintf("%s <= %s", number, formatFloat(*o.Min)), path,
+				"must be greater than %s", strconv.Quote(formatFloat(*o.Min)))
+		} else {
+			g.check(fmt.Sprintf("%s < %s", number, formatFloat(*o.Min)), path,
+				"must be at least %s", strconv.Quote(formatFloat(*o.Min)))
+		}
+	}
+	if o.Max != nil {
+		if o.ExclusiveMax {
+			g.check(fmt.Sprintf("%s >= %s", number, formatFloat(*o.Max)), path,
+				"must be less than %s", strconv.Quote(formatFloat(*o.Max)))
+		} else {
+			g.check(fmt.Sprintf("%s > %s", number, formatFloat(*o.Max)), path,
+				"must be at most %s", strconv.Quote(formatFloat(*o.Max)))
+		}
+	}
+	if o.MultipleOf != nil {
+		g.check(fmt.Sprintf("!openapi_types.IsMultipleOf(%s, %s)", number, formatFloat(*o.MultipleOf)), path,
+			"must be a multiple of %s", strconv.Quote(formatFloat(*o.MultipleOf)))
+	}
+
+	var values []string
+	for _, v := range o.Enum {
+		if f, ok := v.(float64); ok && !StringInArray(formatFloat(f), values) {
+			values = append(values, formatFloat(f))
+		}
+	}
+	g.enum(number, values, path)
+}
+
+func (g *validateGen) enum(value string, values []string, path string) {
+	if len(values) == 0 {
+		return
+	}
+	// The message lists the values as they'd appear in JSON.
+	g.printf("switch %s {\ncase %s:\ndefault:", value, strings.Join(values, ", "))
+	g.printf("errs.Add(%s, \"must be one of %%s\", %s)", path, strconv.Quote(strings.Join(values, ", ")))
+	g.printf("}")
+}
+
+// Appends a property name to a JSON pointer expression, escaping it as JSON
+// pointers require. When the path is a constant, so is the result.
+func appendPath(path string, name string) string {
+	name = strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+	if prefix, err := strconv.Unquote(path); err == nil {
+		return strconv.Quote(prefix + "/" + name)
+	}
+	return path + " + " + strconv.Quote("/"+name)
+}
+
+// Appends the value of an index variable to a JSON pointer expression.
+func appendPathIndex(path string, index string) string {
+	if prefix, err := strconv.Unquote(path); err == nil {
+		return fmt.Sprintf("fmt.Sprintf(%s, %s)", strconv.Quote(prefix+"/%d"), index)
+	}
+	return fmt.Sprintf(`fmt.Sprintf("%%s/%%d", %s, %s)`, path, index)
+}
+
+// Appends the value of a map key variable to a JSON pointer expression.
+func appendPathKey(path string, key string) string {
+	return appendPath(path, "") + " + " + key
+}
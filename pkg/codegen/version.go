@@ -0,0 +1,89 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pkg/errors"
+)
+
+// Version is the version of oapi-codegen, which generated files record in
+// their headers. Releases set it with -ldflags, and otherwise the command
+// takes it from the build info of its module, where there is one.
+var Version = "devel"
+
+// The lines of the header of generated files which record what they were
+// generated by, and from.
+const (
+	headerVersionPrefix   = "// Code generated by github.com/deepmap/oapi-codegen version "
+	headerVersionSuffix   = " DO NOT EDIT."
+	headerInputHashPrefix = "// Input hash: "
+)
+
+// InputHash returns a hash of what generated code depends on, other than the
+// version of the generator: the spec, the package name and the options.
+// Generated files record it in their headers, so that whether they're up to
+// date can be told without generating them again.
+func InputHash(swagger *openapi3.Swagger, packageName string, opts Options) (string, error) {
+	spec, err := json.Marshal(swagger)
+	if err != nil {
+		return "", errors.Wrap(err, "error marshaling spec")
+	}
+	options, err := json.Marshal(opts)
+	if err != nil {
+		return "", errors.Wrap(err, "error marshaling options")
+	}
+	hash := sha256.New()
+	for _, part := range [][]byte{spec, []byte(packageName), options} {
+		// Each part is followed by a zero byte, which none of them contain,
+		// so that they can't run into each other.
+		hash.Write(part)
+		hash.Write([]byte{0})
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// GeneratedHeader is what the header of a generated file records.
+type GeneratedHeader struct {
+	Version   string // The version of the generator
+	InputHash string // See InputHash
+}
+
+// ReadGeneratedHeader reads the header of generated code, and returns false
+// if it doesn't have one which records the version and input hash, as code
+// generated before they were recorded doesn't.
+func ReadGeneratedHeader(code []byte) (GeneratedHeader, bool) {
+	var header GeneratedHeader
+	scanner := bufio.NewScanner(bytes.NewReader(code))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, headerVersionPrefix) && strings.HasSuffix(line, headerVersionSuffix):
+			header.Version = strings.TrimSuffix(strings.TrimPrefix(line, headerVersionPrefix), headerVersionSuffix)
+		case strings.HasPrefix(line, headerInputHashPrefix):
+			header.InputHash = strings.TrimPrefix(line, headerInputHashPrefix)
+		case strings.HasPrefix(line, "package "):
+			// The header ends with the package clause.
+			return header, header.Version != "" && header.InputHash != ""
+		}
+	}
+	return header, false
+}
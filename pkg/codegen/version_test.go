@@ -0,0 +1,88 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package codegen
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const versionSpec = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Version
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: The pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: string
+`
+
+func TestGeneratedHeader(t *testing.T) {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(versionSpec))
+	require.NoError(t, err)
+	opts := Options{GenerateTypes: true, GenerateClient: true}
+
+	inputHash, err := InputHash(swagger, "api", opts)
+	require.NoError(t, err)
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, inputHash)
+
+	code, err := Generate(swagger, "api", opts)
+	require.NoError(t, err)
+	header, ok := ReadGeneratedHeader([]byte(code))
+	require.True(t, ok)
+	assert.Equal(t, GeneratedHeader{Version: Version, InputHash: inputHash}, header)
+
+	// Generating doesn't change the spec in a way which changes its hash.
+	again, err := InputHash(swagger, "api", opts)
+	require.NoError(t, err)
+	assert.Equal(t, inputHash, again)
+
+	// The hash changes with the package name, options and spec.
+	otherPackage, err := InputHash(swagger, "other", opts)
+	require.NoError(t, err)
+	assert.NotEqual(t, inputHash, otherPackage)
+
+	otherOptions, err := InputHash(swagger, "api", Options{GenerateTypes: true})
+	require.NoError(t, err)
+	assert.NotEqual(t, inputHash, otherOptions)
+
+	swagger.Paths["/pets"].Get.OperationID = "getPets"
+	otherSpec, err := InputHash(swagger, "api", opts)
+	require.NoError(t, err)
+	assert.NotEqual(t, inputHash, otherSpec)
+}
+
+func TestReadGeneratedHeaderMissing(t *testing.T) {
+	_, ok := ReadGeneratedHeader([]byte("// Code generated by hand.\npackage api\n"))
+	assert.False(t, ok)
+
+	// The header must come before the package clause.
+	_, ok = ReadGeneratedHeader([]byte("package api\n\n" +
+		"// Code generated by github.com/deepmap/oapi-codegen version v1.0.0 DO NOT EDIT.\n" +
+		"// Input hash: sha256:00\n"))
+	assert.False(t, ok)
+}
@@ -130,6 +130,15 @@ func ValidateRequestFromContext(ctx echo.Context, router *openapi3filter.Router,
 				fmt.Sprintf("error validating request: %s", err))
 		}
 	}
+
+	// readOnly properties are only ever sent by the server, so a client
+	// mustn't set them.
+	if options == nil || !options.Options.ExcludeRequestBody {
+		err = validateNoReadOnlyProperties(req, route.Operation)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+	}
 	return nil
 }
 
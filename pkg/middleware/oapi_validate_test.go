@@ -69,6 +69,9 @@ paths:
               properties:
                 name:
                   type: string
+                id:
+                  type: integer
+                  readOnly: true
   /protected_resource:
     get:
       operationId: getProtectedResource
@@ -209,6 +212,21 @@ func TestOapiRequestValidator(t *testing.T) {
 		called = false
 	}
 
+	// Send a body which sets a readOnly property
+	{
+		body := struct {
+			Name string `json:"name"`
+			Id   int    `json:"id"`
+		}{
+			Name: "Marcin",
+			Id:   5,
+		}
+		rec := doPost(t, e, "http://deepmap.ai/resource", body)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.False(t, called, "Handler should not have been called")
+		called = false
+	}
+
 	e.GET("/protected_resource", func(c echo.Context) error {
 		called = true
 		return c.NoContent(http.StatusNoContent)
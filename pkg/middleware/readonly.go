@@ -0,0 +1,97 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// openapi3filter doesn't know about readOnly properties, so a client may send
+// a server owned field, such as an ID, and it would be accepted. This function
+// checks a JSON request body for any properties which the operation's schema
+// marks as readOnly, and returns an error naming the first one found.
+func validateNoReadOnlyProperties(req *http.Request, op *openapi3.Operation) error {
+	if op.RequestBody == nil || op.RequestBody.Value == nil || req.Body == nil {
+		return nil
+	}
+	mediaType := op.RequestBody.Value.Content.Get("application/json")
+	if mediaType == nil || mediaType.Schema == nil {
+		return nil
+	}
+	if !strings.Contains(req.Header.Get("Content-Type"), "json") {
+		return nil
+	}
+
+	data, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return fmt.Errorf("error reading request body: %s", err)
+	}
+	// Put the body back for the handler.
+	req.Body = ioutil.NopCloser(bytes.NewReader(data))
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		// Malformed bodies are reported by the regular validation.
+		return nil
+	}
+	return findReadOnlyProperty(mediaType.Schema, value, "")
+}
+
+// Walks a decoded JSON value alongside its schema, looking for properties
+// which are set, but readOnly.
+func findReadOnlyProperty(sref *openapi3.SchemaRef, value interface{}, path string) error {
+	if sref == nil || sref.Value == nil {
+		return nil
+	}
+	schema := sref.Value
+
+	for _, s := range schema.AllOf {
+		if err := findReadOnlyProperty(s, value, path); err != nil {
+			return err
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, propValue := range v {
+			prop, found := schema.Properties[name]
+			if !found || prop.Value == nil {
+				continue
+			}
+			propPath := path + "/" + name
+			if prop.Value.ReadOnly {
+				return fmt.Errorf("property '%s' is read-only", propPath)
+			}
+			if err := findReadOnlyProperty(prop, propValue, propPath); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			itemPath := fmt.Sprintf("%s/%d", path, i)
+			if err := findReadOnlyProperty(schema.Items, item, itemPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otelinstrument implements runtime.Instrumentation with OpenTelemetry,
+// so that the operations of generated clients and servers are traced, with
+// the trace propagated in the headers of their requests, and their durations
+// recorded, as the HTTP semantic conventions describe. It's a module of its
+// own, so that only those who use it depend on OpenTelemetry.
+package otelinstrument
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+)
+
+// The name of the instrumentation library, which tracers and meters are
+// named after.
+const instrumentationName = "github.com/deepmap/oapi-codegen/pkg/otelinstrument"
+
+// Options configures Instrumentation. Any which are nil are taken from the
+// otel package's globals.
+type Options struct {
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+	Propagator     propagation.TextMapPropagator
+}
+
+// Instrumentation traces operations, and records their durations in the
+// http.client.request.duration and http.server.request.duration histograms.
+// Spans are named after the operation's operationId, or its method and path
+// template when it has none, and, like the metrics, have the method and the
+// path template as http.route, rather than the URL of each request.
+type Instrumentation struct {
+	tracer         trace.Tracer
+	propagator     propagation.TextMapPropagator
+	clientDuration metric.Float64Histogram
+	serverDuration metric.Float64Histogram
+}
+
+var _ runtime.Instrumentation = (*Instrumentation)(nil)
+
+// New returns the Instrumentation for opts.
+func New(opts Options) (*Instrumentation, error) {
+	if opts.TracerProvider == nil {
+		opts.TracerProvider = otel.GetTracerProvider()
+	}
+	if opts.MeterProvider == nil {
+		opts.MeterProvider = otel.GetMeterProvider()
+	}
+	if opts.Propagator == nil {
+		opts.Propagator = otel.GetTextMapPropagator()
+	}
+	meter := opts.MeterProvider.Meter(instrumentationName)
+	clientDuration, err := meter.Float64Histogram("http.client.request.duration",
+		metric.WithUnit("s"), metric.WithDescription("Duration of HTTP client requests."))
+	if err != nil {
+		return nil, err
+	}
+	serverDuration, err := meter.Float64Histogram("http.server.request.duration",
+		metric.WithUnit("s"), metric.WithDescription("Duration of HTTP server requests."))
+	if err != nil {
+		return nil, err
+	}
+	return &Instrumentation{
+		tracer:         opts.TracerProvider.Tracer(instrumentationName),
+		propagator:     opts.Propagator,
+		clientDuration: clientDuration,
+		serverDuration: serverDuration,
+	}, nil
+}
+
+// StartOperation starts the span of an operation. A server continues the
+// trace which the request's headers propagate, if any, and a client adds the
+// headers which propagate its span's.
+func (i *Instrumentation) StartOperation(ctx context.Context, kind runtime.OperationKind, op *runtime.OperationInfo,
+	req *http.Request) (context.Context, runtime.EndOperationFunc) {
+	start := time.Now()
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(op.Method),
+		semconv.HTTPRoute(op.Path),
+	}
+	spanKind := trace.SpanKindClient
+	duration := i.clientDuration
+	if kind == runtime.ServerOperation {
+		spanKind = trace.SpanKindServer
+		duration = i.serverDuration
+		ctx = i.propagator.Extract(ctx, propagation.HeaderCarrier(req.Header))
+	}
+	ctx, span := i.tracer.Start(ctx, spanName(op), trace.WithSpanKind(spanKind), trace.WithAttributes(attrs...))
+	if kind == runtime.ClientOperation {
+		i.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	}
+
+	return ctx, func(statusCode int, err error) {
+		if statusCode != 0 {
+			attrs = append(attrs, semconv.HTTPResponseStatusCode(statusCode))
+			span.SetAttributes(semconv.HTTPResponseStatusCode(statusCode))
+		}
+		if err != nil {
+			span.RecordError(err)
+		}
+		if failed(kind, statusCode, err) {
+			span.SetStatus(codes.Error, http.StatusText(statusCode))
+		}
+		span.End()
+		duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+	}
+}
+
+// Returns the name of the span of an operation.
+func spanName(op *runtime.OperationInfo) string {
+	if op.OperationID != "" {
+		return op.OperationID
+	}
+	return op.Method + " " + op.Path
+}
+
+// Returns whether an operation failed, as far as the status of its span is
+// concerned. A response with a 4xx status is a failure of the client which
+// sent it, but not of the server which rejected it.
+func failed(kind runtime.OperationKind, statusCode int, err error) bool {
+	if kind == runtime.ClientOperation {
+		return err != nil || statusCode >= 400
+	}
+	return statusCode >= 500 || statusCode == 0 && err != nil
+}
@@ -0,0 +1,202 @@
+package otelinstrument
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/deepmap/oapi-codegen/internal/test/instrumentation"
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+)
+
+type server struct {
+	// The span which each handler was called in.
+	spans []trace.SpanContext
+}
+
+func (s *server) AddPet(ctx echo.Context) error {
+	s.spans = append(s.spans, trace.SpanContextFromContext(ctx.Request().Context()))
+	return ctx.NoContent(http.StatusCreated)
+}
+
+func (s *server) GetPet(ctx echo.Context, id string) error {
+	s.spans = append(s.spans, trace.SpanContextFromContext(ctx.Request().Context()))
+	if id != "1" {
+		return echo.NewHTTPError(http.StatusNotFound)
+	}
+	return ctx.JSON(http.StatusOK, instrumentation.Pet{Name: "Fido"})
+}
+
+// Returns the in-memory exporter and reader which the instrumentation's spans
+// and metrics go to.
+func newTestInstrumentation(t *testing.T) (*Instrumentation, *tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	exporter := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+	i, err := New(Options{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		Propagator:     propagation.TraceContext{},
+	})
+	require.NoError(t, err)
+	return i, exporter, reader
+}
+
+func TestInstrumentation(t *testing.T) {
+	serverInstrumentation, serverSpans, serverMetrics := newTestInstrumentation(t)
+	s := &server{}
+	e := echo.New()
+	require.NoError(t, instrumentation.RegisterInstrumentedHandlers(e, s, "https://example.com/api", serverInstrumentation))
+	ts := httptest.NewServer(e)
+	defer ts.Close()
+
+	clientInstrumentation, clientSpans, clientMetrics := newTestInstrumentation(t)
+	client := &instrumentation.Client{Server: ts.URL + "/api", Instrumentation: clientInstrumentation}
+	ctx := context.Background()
+
+	rsp, err := client.GetPet(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rsp.StatusCode)
+	rsp, err = client.GetPet(ctx, "2")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rsp.StatusCode)
+	rsp, err = client.AddPetWithBody(ctx, "application/json", strings.NewReader(`{"name": "Rex"}`))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rsp.StatusCode)
+
+	// Failures to send are recorded too.
+	broken := &instrumentation.Client{Server: "http://127.0.0.1:0", Instrumentation: clientInstrumentation}
+	_, err = broken.GetPet(ctx, "1")
+	assert.Error(t, err)
+
+	clientStubs := clientSpans.GetSpans()
+	require.Len(t, clientStubs, 4)
+	for i, expected := range []struct {
+		name, method, route string
+		status              int
+		code                codes.Code
+	}{
+		{"getPet", "GET", "/pets/{id}", http.StatusOK, codes.Unset},
+		{"getPet", "GET", "/pets/{id}", http.StatusNotFound, codes.Error},
+		{"addPet", "POST", "/pets", http.StatusCreated, codes.Unset},
+		{"getPet", "GET", "/pets/{id}", 0, codes.Error},
+	} {
+		span := clientStubs[i]
+		assert.Equal(t, expected.name, span.Name)
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+		attrs := attribute.NewSet(span.Attributes...)
+		method, _ := attrs.Value("http.request.method")
+		assert.Equal(t, expected.method, method.AsString())
+		// The route is the path template, not the URL.
+		route, _ := attrs.Value("http.route")
+		assert.Equal(t, expected.route, route.AsString())
+		status, found := attrs.Value("http.response.status_code")
+		assert.Equal(t, expected.status != 0, found)
+		assert.Equal(t, int64(expected.status), status.AsInt64())
+		assert.Equal(t, expected.code, span.Status.Code)
+	}
+	assert.Len(t, clientStubs[3].Events, 1, "the error is recorded")
+
+	// The server continues the client's trace, and its handlers are called
+	// in its spans.
+	serverStubs := serverSpans.GetSpans()
+	require.Len(t, serverStubs, 3)
+	require.Len(t, s.spans, 3)
+	for i, expected := range []struct {
+		name   string
+		status int
+		code   codes.Code
+	}{
+		{"getPet", http.StatusOK, codes.Unset},
+		// A client's mistake isn't the server's.
+		{"getPet", http.StatusNotFound, codes.Unset},
+		{"addPet", http.StatusCreated, codes.Unset},
+	} {
+		span := serverStubs[i]
+		assert.Equal(t, expected.name, span.Name)
+		assert.Equal(t, trace.SpanKindServer, span.SpanKind)
+		assert.Equal(t, clientStubs[i].SpanContext.TraceID(), span.SpanContext.TraceID())
+		assert.Equal(t, clientStubs[i].SpanContext.SpanID(), span.Parent.SpanID())
+		assert.True(t, span.Parent.IsRemote())
+		assert.Equal(t, span.SpanContext.SpanID(), s.spans[i].SpanID())
+		attrs := attribute.NewSet(span.Attributes...)
+		status, _ := attrs.Value("http.response.status_code")
+		assert.Equal(t, int64(expected.status), status.AsInt64())
+		assert.Equal(t, expected.code, span.Status.Code)
+	}
+
+	clientCounts := durationCounts(t, clientMetrics, "http.client.request.duration")
+	assert.Equal(t, map[string]uint64{
+		"GET /pets/{id} 200": 1,
+		"GET /pets/{id} 404": 1,
+		"POST /pets 201":     1,
+		"GET /pets/{id} 0":   1,
+	}, clientCounts)
+	serverCounts := durationCounts(t, serverMetrics, "http.server.request.duration")
+	assert.Equal(t, map[string]uint64{
+		"GET /pets/{id} 200": 1,
+		"GET /pets/{id} 404": 1,
+		"POST /pets 201":     1,
+	}, serverCounts)
+}
+
+// Collects the counts of the duration histogram with the given name, keyed by
+// the method, route and status code of their attributes, which is 0 when
+// there's none.
+func durationCounts(t *testing.T, reader *sdkmetric.ManualReader, name string) map[string]uint64 {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	counts := make(map[string]uint64)
+	for _, sm := range rm.ScopeMetrics {
+		assert.Equal(t, instrumentationName, sm.Scope.Name)
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			assert.Equal(t, "s", m.Unit)
+			histogram, ok := m.Data.(metricdata.Histogram[float64])
+			require.True(t, ok)
+			for _, point := range histogram.DataPoints {
+				method, _ := point.Attributes.Value("http.request.method")
+				route, _ := point.Attributes.Value("http.route")
+				status, _ := point.Attributes.Value("http.response.status_code")
+				key := method.AsString() + " " + route.AsString() + " " + status.Emit()
+				if status.Type() == attribute.INVALID {
+					key = method.AsString() + " " + route.AsString() + " 0"
+				}
+				counts[key] += point.Count
+			}
+		}
+	}
+	return counts
+}
+
+// Operations without an operationId, which are named with x-go-name, are
+// named after their method and route.
+func TestSpanNameWithoutOperationID(t *testing.T) {
+	i, spans, _ := newTestInstrumentation(t)
+	req := httptest.NewRequest(http.MethodDelete, "/owners", nil)
+	_, end := i.StartOperation(context.Background(), runtime.ServerOperation,
+		&runtime.OperationInfo{Method: http.MethodDelete, Path: "/owners"}, req)
+	end(0, errors.New("failed"))
+
+	stubs := spans.GetSpans()
+	require.Len(t, stubs, 1)
+	assert.Equal(t, "DELETE /owners", stubs[0].Name)
+	// A handler which fails without responding fails the server.
+	assert.Equal(t, codes.Error, stubs[0].Status.Code)
+}
@@ -14,7 +14,6 @@
 package runtime
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -40,6 +39,16 @@ func BindStyledParameter(style string, explode bool, paramName string,
 	// This is the basic type of the destination object.
 	t := v.Type()
 
+	// A time is a struct, but it's bound from a single value, not from
+	// properties, as are other types which unmarshal themselves from text.
+	if isTextType(t) {
+		value, err := StyledParameterValue(style, explode, paramName, value)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return BindStringToObject(value, dest)
+	}
+
 	if t.Kind() == reflect.Struct {
 		// We've got a destination object, we'll create a JSON representation
 		// of the input value, and let the json library deal with the unmarshaling
@@ -69,10 +78,62 @@ func BindStyledParameter(style string, explode bool, paramName string,
 		return nil
 	}
 
-	// Try to bind the remaining types as a base type.
+	// Try to bind the remaining types as a base type, without the prefix of
+	// its style.
+	value, err := StyledParameterValue(style, explode, paramName, value)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
 	return BindStringToObject(value, dest)
 }
 
+// The functions below are used by generated code, which binds parameters of
+// types it knows, without reflection. They're part of BindStyledParameter.
+
+// StyledParameterValue returns the value of a styled primitive parameter,
+// without the leading period of the label style, or the leading semicolon
+// and name of the matrix style.
+func StyledParameterValue(style string, explode bool, paramName string, value string) (string, error) {
+	if value == "" {
+		return "", fmt.Errorf("parameter '%s' is empty, can't bind its value", paramName)
+	}
+	switch style {
+	case "label":
+		if value[0] != '.' {
+			return "", fmt.Errorf("invalid format for label parameter '%s', should start with '.'", paramName)
+		}
+		return value[1:], nil
+	case "matrix":
+		// This is ;paramName=value, whether or not it's exploded.
+		nameEnd := len(paramName) + 1
+		if len(value) <= nameEnd || value[0] != ';' || value[1:nameEnd] != paramName || value[nameEnd] != '=' {
+			return "", fmt.Errorf("expected parameter '%s' to start with ;%s=", paramName, paramName)
+		}
+		return value[nameEnd+1:], nil
+	}
+	return value, nil
+}
+
+// StyledParameterParts splits a styled parameter into the values of an
+// array, or into the properties of an object, which, if it's exploded, are
+// name=value pairs, and otherwise are names and values in turn.
+func StyledParameterParts(style string, explode bool, object bool, paramName string, value string) ([]string, error) {
+	if value == "" {
+		return nil, fmt.Errorf("parameter '%s' is empty, can't bind its value", paramName)
+	}
+	return splitStyledParameter(style, explode, object, paramName, value)
+}
+
+// SplitStyledProperty splits a property of an exploded object parameter,
+// such as role=admin, into its name and value.
+func SplitStyledProperty(paramName string, property string) (string, string, error) {
+	i := strings.IndexByte(property, '=')
+	if i < 0 || strings.IndexByte(property[i+1:], '=') >= 0 {
+		return "", "", fmt.Errorf("parameter '%s' has invalid exploded format", paramName)
+	}
+	return property[:i], property[i+1:], nil
+}
+
 // This is a complex set of operations, but each given parameter style can be
 // packed together in multiple ways, using different styles of separators, and
 // different packing strategies based on the explode flag. This function takes
@@ -196,39 +257,44 @@ func bindSplitPartsToDestinationArray(parts []string, dest interface{}) error {
 // ["firstName=Alex", "role=admin"], where in the non-exploded case, we would
 // pass "firstName", "Alex", "role", "admin"]
 //
-// We punt the hard work of binding these values to the object to the json
-// library. We'll turn those arrays into JSON strings, and unmarshal
-// into the struct.
+// Each value is bound to the field whose json name is its property, so
+// properties may be of any type which BindStringToObject binds.
 func bindSplitPartsToDestinationStruct(paramName string, parts []string, explode bool, dest interface{}) error {
-	// We've got a destination object, we'll create a JSON representation
-	// of the input value, and let the json library deal with the unmarshaling
-	var fields []string
+	properties := make(map[string]string, len(parts))
 	if explode {
-		fields = make([]string, len(parts))
-		for i, property := range parts {
-			propertyParts := strings.Split(property, "=")
-			if len(propertyParts) != 2 {
-				return fmt.Errorf("parameter '%s' has invalid exploded format", paramName)
+		for _, property := range parts {
+			name, value, err := SplitStyledProperty(paramName, property)
+			if err != nil {
+				return err
 			}
-			fields[i] = "\"" + propertyParts[0] + "\":\"" + propertyParts[1] + "\""
+			properties[name] = value
 		}
 	} else {
 		if len(parts)%2 != 0 {
 			return fmt.Errorf("parameter '%s' has invalid format, property/values need to be pairs", paramName)
 		}
-		fields = make([]string, len(parts)/2)
 		for i := 0; i < len(parts); i += 2 {
-			key := parts[i]
-			value := parts[i+1]
-			fields[i/2] = "\"" + key + "\":\"" + value + "\""
+			properties[parts[i]] = parts[i+1]
 		}
 	}
-	jsonParam := "{" + strings.Join(fields, ",") + "}"
-	err := json.Unmarshal([]byte(jsonParam), dest)
-	if err != nil {
-		return fmt.Errorf("error binding parameter %s fields: %s", paramName, err)
-	}
-	return nil
+	return bindPropertiesToStruct(paramName, properties, dest)
+}
+
+// Binds the values of properties to the fields of the struct which dest
+// points to, by their json names. Properties which the struct doesn't have
+// are ignored, as json.Unmarshal would.
+func bindPropertiesToStruct(paramName string, properties map[string]string, dest interface{}) error {
+	v := reflect.Indirect(reflect.ValueOf(dest))
+	return forEachStructField(v, func(name string, field reflect.Value) error {
+		value, found := properties[name]
+		if !found {
+			return nil
+		}
+		if err := BindStringToObject(value, field.Addr().Interface()); err != nil {
+			return fmt.Errorf("error binding parameter %s fields: field '%s': %s", paramName, name, err)
+		}
+		return nil
+	})
 }
 
 // This works much like BindStyledParameter, however it takes a query argument
@@ -288,6 +354,11 @@ func BindQueryParameter(style string, explode bool, required bool, paramName str
 	// This is the basic type of the destination object.
 	t := v.Type()
 	k := t.Kind()
+	if isTextType(t) {
+		// Times and the like are bound from single values, like strings,
+		// whatever their kind.
+		k = reflect.String
+	}
 
 	switch style {
 	case "form":
@@ -405,13 +476,15 @@ func BindQueryParameter(style string, explode bool, required bool, paramName str
 			objectMap[k] = v[0]
 		}
 
-		// Marshal and unmarshal the objectMap into dest
-		data, err := json.Marshal(objectMap)
-		if err != nil {
-			return err
+		if k != reflect.Struct {
+			return echo.NewHTTPError(http.StatusInternalServerError,
+				fmt.Sprintf("deepObject parameter '%s' must be bound to a struct", paramName))
 		}
-		if err = json.Unmarshal(data, dest); err != nil {
-			return err
+		if err := bindPropertiesToStruct(paramName, objectMap, output); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		if !required {
+			dv.Set(reflect.ValueOf(output))
 		}
 		return nil
 	case "spaceDelimited", "pipeDelimited":
@@ -436,41 +509,21 @@ func bindParamsToExplodedObject(paramName string, values url.Values, dest interf
 			"unmarshaling query arg '%s' into wrong type", paramName)
 	}
 
-	t := v.Type()
-
-	for i := 0; i < t.NumField(); i++ {
-		fieldT := t.Field(i)
-
-		// Skip unsettable fields, such as internal ones.
-		if !v.Field(i).CanSet() {
-			continue
-		}
-
-		// Find the json annotation on the field, and use the json specified
-		// name if available, otherwise, just the field name.
-		tag := fieldT.Tag.Get("json")
-		fieldName := fieldT.Name
-		if tag != "" {
-			tagParts := strings.Split(tag, ",")
-			name := tagParts[0]
-			if name != "" {
-				fieldName = name
-			}
-		}
-
+	return forEachStructField(v, func(fieldName string, field reflect.Value) error {
 		// At this point, we look up field name in the parameter list.
 		fieldVal, found := values[fieldName]
-		if found {
-			if len(fieldVal) != 1 {
-				return echo.NewHTTPError(http.StatusBadRequest,
-					fmt.Sprintf("field '%s' specified multiple times for param '%s'", fieldName, paramName))
-			}
-			err := BindStringToObject(fieldVal[0], v.Field(i).Addr().Interface())
-			if err != nil {
-				return echo.NewHTTPError(http.StatusBadRequest,
-					fmt.Sprintf("could not bind query arg '%s' to request object: %s", paramName, err))
-			}
+		if !found {
+			return nil
 		}
-	}
-	return nil
+		if len(fieldVal) != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest,
+				fmt.Sprintf("field '%s' specified multiple times for param '%s'", fieldName, paramName))
+		}
+		err := BindStringToObject(fieldVal[0], field.Addr().Interface())
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest,
+				fmt.Sprintf("could not bind query arg '%s' to request object: %s", paramName, err))
+		}
+		return nil
+	})
 }
@@ -16,8 +16,11 @@ package runtime
 import (
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/deepmap/oapi-codegen/pkg/types"
 )
 
 func TestSplitParameter(t *testing.T) {
@@ -250,3 +253,160 @@ func TestBindQueryParameter(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedDeepObject, actual)
 }
+
+func TestBindStyledParameterTime(t *testing.T) {
+	var when time.Time
+	err := BindStyledParameter("simple", false, "when", "2019-10-21T12:30:00Z", &when)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2019, 10, 21, 12, 30, 0, 0, time.UTC), when)
+
+	err = BindStyledParameter("simple", false, "when", "yesterday", &when)
+	assert.Error(t, err)
+}
+
+func TestStyledParameterValue(t *testing.T) {
+	tests := []struct {
+		style    string
+		value    string
+		expected string
+	}{
+		{"simple", "5", "5"},
+		{"label", ".5", "5"},
+		{"matrix", ";id=5", "5"},
+		{"matrix", ";id=", ""},
+	}
+	for _, test := range tests {
+		for _, explode := range []bool{false, true} {
+			value, err := StyledParameterValue(test.style, explode, "id", test.value)
+			assert.NoError(t, err, test.value)
+			assert.Equal(t, test.expected, value, test.value)
+
+			// It's the value which BindStyledParameter binds.
+			var bound string
+			err = BindStyledParameter(test.style, explode, "id", test.value, &bound)
+			assert.NoError(t, err, test.value)
+			assert.Equal(t, bound, value, test.value)
+		}
+	}
+
+	for _, test := range []struct {
+		style string
+		value string
+	}{
+		{"simple", ""},
+		{"label", "5"},
+		{"matrix", ";idx=5"},
+		{"matrix", ";i=5"},
+		{"matrix", "id=5"},
+		{"matrix", ";id"},
+	} {
+		_, err := StyledParameterValue(test.style, false, "id", test.value)
+		assert.Error(t, err, test.value)
+	}
+}
+
+func TestStyledParameterParts(t *testing.T) {
+	parts, err := StyledParameterParts("label", true, true, "id", ".role=admin.firstName=Alex")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"role=admin", "firstName=Alex"}, parts)
+
+	_, err = StyledParameterParts("simple", false, false, "id", "")
+	assert.Error(t, err)
+}
+
+func TestSplitStyledProperty(t *testing.T) {
+	name, value, err := SplitStyledProperty("id", "role=admin")
+	assert.NoError(t, err)
+	assert.Equal(t, "role", name)
+	assert.Equal(t, "admin", value)
+
+	name, value, err = SplitStyledProperty("id", "role=")
+	assert.NoError(t, err)
+	assert.Equal(t, "role", name)
+	assert.Equal(t, "", value)
+
+	_, _, err = SplitStyledProperty("id", "role")
+	assert.Error(t, err)
+	_, _, err = SplitStyledProperty("id", "role=admin=user")
+	assert.Error(t, err)
+}
+
+func TestBindObjectFields(t *testing.T) {
+	type Base struct {
+		ID textID `json:"id"`
+	}
+	type Object struct {
+		Base
+		Day   types.Date `json:"day"`
+		Since *time.Time `json:"since,omitempty"`
+		Limit *int       `json:"limit,omitempty"`
+		Name  string     `json:"name"`
+	}
+	since := time.Date(2019, 10, 21, 12, 30, 0, 0, time.UTC)
+	limit := 3
+	expected := Object{
+		Base:  Base{textID{"pet", 5}},
+		Day:   types.Date{Time: time.Date(2019, 10, 21, 0, 0, 0, 0, time.UTC)},
+		Since: &since,
+		Limit: &limit,
+	}
+
+	var object Object
+	err := BindStyledParameter("simple", false, "filter",
+		"id,pet-5,day,2019-10-21,since,2019-10-21T12:30:00Z,limit,3", &object)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, object)
+
+	object = Object{}
+	err = BindStyledParameter("matrix", true, "filter",
+		";id=pet-5;day=2019-10-21;since=2019-10-21T12:30:00Z;limit=3", &object)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, object)
+
+	var optional *Object
+	err = BindQueryParameter("form", true, false, "filter", url.Values{
+		"id":    {"pet-5"},
+		"day":   {"2019-10-21"},
+		"since": {"2019-10-21T12:30:00Z"},
+		"limit": {"3"},
+	}, &optional)
+	assert.NoError(t, err)
+	assert.Equal(t, &expected, optional)
+
+	optional = nil
+	err = BindQueryParameter("deepObject", true, false, "filter", url.Values{
+		"filter[id]":    {"pet-5"},
+		"filter[day]":   {"2019-10-21"},
+		"filter[since]": {"2019-10-21T12:30:00Z"},
+		"filter[limit]": {"3"},
+	}, &optional)
+	assert.NoError(t, err)
+	assert.Equal(t, &expected, optional)
+
+	err = BindStyledParameter("simple", true, "filter", "limit=lots", &object)
+	assert.Error(t, err)
+	err = BindQueryParameter("deepObject", true, true, "filter", url.Values{
+		"filter[day]": {"yesterday"},
+	}, &object)
+	assert.Error(t, err)
+}
+
+func TestBindTextParameters(t *testing.T) {
+	var id textID
+	assert.NoError(t, BindStyledParameter("label", false, "id", ".pet-5", &id))
+	assert.Equal(t, textID{"pet", 5}, id)
+
+	var day *types.Date
+	err := BindQueryParameter("form", true, false, "day", url.Values{"day": {"2019-10-21"}}, &day)
+	assert.NoError(t, err)
+	assert.Equal(t, "2019-10-21", day.String())
+
+	var days []types.Date
+	assert.NoError(t, BindStyledParameter("simple", false, "days", "2019-10-21,2019-10-22", &days))
+	assert.Len(t, days, 2)
+
+	var when time.Time
+	err = BindQueryParameter("form", true, true, "when", url.Values{"when": {"2019-10-21"}}, &when)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2019, 10, 21, 0, 0, 0, 0, time.UTC), when)
+}
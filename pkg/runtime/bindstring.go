@@ -14,19 +14,40 @@
 package runtime
 
 import (
+	"encoding"
 	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 )
 
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Returns whether values of the type t are bound from and styled as a single
+// string, like primitives, though they may be structs or slices. Those are
+// times, and types which marshal themselves as text, such as types.Date.
+func isTextType(t reflect.Type) bool {
+	pt := reflect.PtrTo(t)
+	return t == timeType || pt.Implements(textUnmarshalerType) || pt.Implements(textMarshalerType)
+}
+
 // This function takes a string, and attempts to assign it to the destination
 // interface via whatever type conversion is necessary. We have to do this
 // via reflection instead of a much simpler type switch so that we can handle
 // type aliases. This function was the easy way out, the better way, since we
 // know the destination type each place that we use this, is to generate code
 // to read each specific type.
+//
+// Times are parsed as RFC3339 timestamps, or as dates, such as 2019-10-21,
+// and types which implement encoding.TextUnmarshaler unmarshal themselves.
+// A nil pointer in the destination, such as that of an optional field, is
+// given a new value.
 func BindStringToObject(src string, dst interface{}) error {
 	var err error
 
@@ -45,19 +66,52 @@ func BindStringToObject(src string, dst interface{}) error {
 		return errors.New("destination is not settable")
 	}
 
+	if t.Kind() == reflect.Ptr {
+		// Only replace the pointer when the value binds, so that it's left
+		// nil otherwise.
+		value := reflect.New(t.Elem())
+		if err := BindStringToObject(src, value.Interface()); err != nil {
+			return err
+		}
+		v.Set(value)
+		return nil
+	}
+
+	if t == timeType {
+		parsedTime, err := parseTime(src)
+		if err != nil {
+			return fmt.Errorf("error parsing '%s' as RFC3339 time or date: %s", src, err)
+		}
+		v.Set(reflect.ValueOf(parsedTime))
+		return nil
+	}
+
+	if unmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalText([]byte(src)); err != nil {
+			return fmt.Errorf("error binding string parameter: %s", err)
+		}
+		return nil
+	}
+
 	switch t.Kind() {
-	case reflect.Int, reflect.Int32, reflect.Int64:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		var val int64
-		val, err = strconv.ParseInt(src, 10, 64)
+		val, err = strconv.ParseInt(src, 10, t.Bits())
 		if err == nil {
 			v.SetInt(val)
 		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var val uint64
+		val, err = strconv.ParseUint(src, 10, t.Bits())
+		if err == nil {
+			v.SetUint(val)
+		}
 	case reflect.String:
 		v.SetString(src)
 		err = nil
 	case reflect.Float64, reflect.Float32:
 		var val float64
-		val, err = strconv.ParseFloat(src, 64)
+		val, err = strconv.ParseFloat(src, t.Bits())
 		if err == nil {
 			v.SetFloat(val)
 		}
@@ -67,17 +121,6 @@ func BindStringToObject(src string, dst interface{}) error {
 		if err == nil {
 			v.SetBool(val)
 		}
-	case reflect.Struct:
-		// Time is a special case of a struct that we handle
-		if dstTime, ok := dst.(*time.Time); ok {
-			parsedTime, err := time.Parse(time.RFC3339Nano, src)
-			if err != nil {
-				return fmt.Errorf("error parsing '%s' as RFC3339 time: %s", src, err)
-			}
-			*dstTime = parsedTime
-			return nil
-		}
-		fallthrough
 	default:
 		// We've got a bunch of types unimplemented, don't fail silently.
 		err = fmt.Errorf("can not bind to destination of type: %s", t.Kind())
@@ -87,3 +130,57 @@ func BindStringToObject(src string, dst interface{}) error {
 	}
 	return nil
 }
+
+// Parses a time, which may be an RFC3339 timestamp, or only a date, which is
+// midnight UTC.
+func parseTime(src string) (time.Time, error) {
+	parsed, err := time.Parse(time.RFC3339Nano, src)
+	if err == nil {
+		return parsed, nil
+	}
+	if parsedDate, dateErr := time.Parse("2006-01-02", src); dateErr == nil {
+		return parsedDate, nil
+	}
+	return time.Time{}, err
+}
+
+// Calls fn with the name and value of each field of the struct v, which
+// binds or is styled as a property of an object parameter. The name is that
+// of its json tag, if it has one. Fields of embedded structs, which allOf
+// generates, are properties too.
+func forEachStructField(v reflect.Value, fn func(name string, field reflect.Value) error) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		fieldT := t.Field(i)
+		field := v.Field(i)
+
+		// Skip unexported fields, such as internal ones.
+		if fieldT.PkgPath != "" && !fieldT.Anonymous {
+			continue
+		}
+
+		// Find the json annotation on the field, and use the json specified
+		// name if available, otherwise, just the field name.
+		tag := fieldT.Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+		if name == "-" {
+			continue
+		}
+		if fieldT.Anonymous && name == "" && fieldT.Type.Kind() == reflect.Struct && !isTextType(fieldT.Type) {
+			if err := forEachStructField(field, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if fieldT.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = fieldT.Name
+		}
+		if err := fn(name, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
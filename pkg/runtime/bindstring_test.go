@@ -14,10 +14,15 @@
 package runtime
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/deepmap/oapi-codegen/pkg/types"
 )
 
 func TestBindStringToObject(t *testing.T) {
@@ -90,3 +95,59 @@ func TestBindStringToObject(t *testing.T) {
 	parsedTime = parsedTime.UTC()
 	assert.EqualValues(t, now, parsedTime)
 }
+
+// textID is bound and styled as text, rather than as a struct.
+type textID struct {
+	prefix string
+	n      int
+}
+
+func (id textID) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%s-%d", id.prefix, id.n)), nil
+}
+
+func (id *textID) UnmarshalText(text []byte) error {
+	i := strings.LastIndexByte(string(text), '-')
+	if i < 0 {
+		return fmt.Errorf("'%s' isn't an ID", text)
+	}
+	n, err := strconv.Atoi(string(text[i+1:]))
+	if err != nil {
+		return err
+	}
+	*id = textID{prefix: string(text[:i]), n: n}
+	return nil
+}
+
+func TestBindStringToObjectText(t *testing.T) {
+	var id textID
+	assert.NoError(t, BindStringToObject("pet-5", &id))
+	assert.Equal(t, textID{"pet", 5}, id)
+	assert.Error(t, BindStringToObject("pet", &id))
+
+	var date types.Date
+	assert.NoError(t, BindStringToObject("2019-10-21", &date))
+	assert.Equal(t, time.Date(2019, 10, 21, 0, 0, 0, 0, time.UTC), date.Time)
+
+	// Times may be dates, as well as timestamps.
+	var when time.Time
+	assert.NoError(t, BindStringToObject("2019-10-21", &when))
+	assert.Equal(t, time.Date(2019, 10, 21, 0, 0, 0, 0, time.UTC), when)
+	assert.Error(t, BindStringToObject("21/10/2019", &when))
+
+	// Pointers are given values, and left nil if there's an error.
+	var optional *int
+	assert.Error(t, BindStringToObject("five", &optional))
+	assert.Nil(t, optional)
+	assert.NoError(t, BindStringToObject("5", &optional))
+	assert.Equal(t, 5, *optional)
+
+	var u8 uint8
+	assert.NoError(t, BindStringToObject("255", &u8))
+	assert.Equal(t, uint8(255), u8)
+	assert.Error(t, BindStringToObject("256", &u8))
+	assert.Error(t, BindStringToObject("-1", &u8))
+
+	var i32 int32
+	assert.Error(t, BindStringToObject("3000000000", &i32))
+}
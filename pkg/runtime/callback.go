@@ -0,0 +1,224 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CallbackTrigger holds everything which the runtime expressions in a callback
+// URL may refer to, which is the request that triggered the callback, and the
+// response we sent to it.
+type CallbackTrigger struct {
+	Request *http.Request
+	// The path parameters of Request, since http.Request doesn't know them
+	PathParams map[string]string
+	// The request body, which may be the raw JSON, as a []byte or
+	// json.RawMessage, or anything which marshals to it, such as the
+	// generated body type.
+	RequestBody interface{}
+
+	StatusCode     int
+	ResponseHeader http.Header
+	// The response body, in any of the forms which RequestBody may take
+	ResponseBody interface{}
+}
+
+// NewEchoCallbackTrigger creates a CallbackTrigger for the request which an
+// echo handler is serving, along with its decoded body.
+func NewEchoCallbackTrigger(ctx echo.Context, requestBody interface{}) CallbackTrigger {
+	pathParams := make(map[string]string)
+	values := ctx.ParamValues()
+	for i, name := range ctx.ParamNames() {
+		if i < len(values) {
+			pathParams[name] = values[i]
+		}
+	}
+	return CallbackTrigger{
+		Request:     ctx.Request(),
+		PathParams:  pathParams,
+		RequestBody: requestBody,
+	}
+}
+
+// ResolveCallbackURL works out the URL of a callback from its key in the spec,
+// which may contain runtime expressions in braces, for example
+// "{$request.body#/callbackUrl}/events". The expressions which the OpenAPI
+// specification defines are supported: $url, $method, $statusCode, and the
+// headers, query and path parameters, and bodies of the request and response.
+func ResolveCallbackURL(expression string, trigger CallbackTrigger) (string, error) {
+	var result strings.Builder
+	for {
+		start := strings.Index(expression, "{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(expression[start:], "}")
+		if end < 0 {
+			return "", fmt.Errorf("unterminated runtime expression in '%s'", expression)
+		}
+		end += start
+		value, err := evaluateRuntimeExpression(expression[start+1:end], trigger)
+		if err != nil {
+			return "", err
+		}
+		result.WriteString(expression[:start])
+		result.WriteString(value)
+		expression = expression[end+1:]
+	}
+	result.WriteString(expression)
+	return result.String(), nil
+}
+
+// Evaluates a single runtime expression, without its braces.
+func evaluateRuntimeExpression(expr string, trigger CallbackTrigger) (string, error) {
+	switch expr {
+	case "$url":
+		if trigger.Request == nil {
+			return "", fmt.Errorf("%s needs the triggering request", expr)
+		}
+		return requestURL(trigger.Request), nil
+	case "$method":
+		if trigger.Request == nil {
+			return "", fmt.Errorf("%s needs the triggering request", expr)
+		}
+		return trigger.Request.Method, nil
+	case "$statusCode":
+		return strconv.Itoa(trigger.StatusCode), nil
+	}
+
+	var source, rest string
+	switch {
+	case strings.HasPrefix(expr, "$request."):
+		source, rest = "request", strings.TrimPrefix(expr, "$request.")
+	case strings.HasPrefix(expr, "$response."):
+		source, rest = "response", strings.TrimPrefix(expr, "$response.")
+	default:
+		return "", fmt.Errorf("unsupported runtime expression '%s'", expr)
+	}
+
+	var header http.Header
+	var body interface{}
+	if source == "request" {
+		if trigger.Request != nil {
+			header = trigger.Request.Header
+		}
+		body = trigger.RequestBody
+	} else {
+		header, body = trigger.ResponseHeader, trigger.ResponseBody
+	}
+
+	switch {
+	case strings.HasPrefix(rest, "header."):
+		name := strings.TrimPrefix(rest, "header.")
+		if value := header.Get(name); value != "" {
+			return value, nil
+		}
+		return "", fmt.Errorf("%s header '%s' not found", source, name)
+	case source == "request" && strings.HasPrefix(rest, "query."):
+		name := strings.TrimPrefix(rest, "query.")
+		if trigger.Request != nil {
+			if values, found := trigger.Request.URL.Query()[name]; found && len(values) != 0 {
+				return values[0], nil
+			}
+		}
+		return "", fmt.Errorf("query parameter '%s' not found", name)
+	case source == "request" && strings.HasPrefix(rest, "path."):
+		name := strings.TrimPrefix(rest, "path.")
+		if value, found := trigger.PathParams[name]; found {
+			return value, nil
+		}
+		return "", fmt.Errorf("path parameter '%s' not found", name)
+	case rest == "body" || strings.HasPrefix(rest, "body#"):
+		return bodyPointerValue(body, strings.TrimPrefix(strings.TrimPrefix(rest, "body"), "#"))
+	}
+	return "", fmt.Errorf("unsupported runtime expression '%s'", expr)
+}
+
+// Returns the absolute URL of a request which a server received.
+func requestURL(req *http.Request) string {
+	if req.URL.IsAbs() {
+		return req.URL.String()
+	}
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + req.Host + req.URL.RequestURI()
+}
+
+// Looks up a JSON pointer in a body, returning strings as they are, and
+// anything else as JSON.
+func bodyPointerValue(body interface{}, pointer string) (string, error) {
+	if body == nil {
+		return "", fmt.Errorf("body isn't available for JSON pointer '%s'", pointer)
+	}
+	var data []byte
+	switch b := body.(type) {
+	case []byte:
+		data = b
+	case json.RawMessage:
+		data = b
+	default:
+		var err error
+		data, err = json.Marshal(body)
+		if err != nil {
+			return "", fmt.Errorf("error marshaling body: %s", err)
+		}
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return "", fmt.Errorf("error unmarshaling body: %s", err)
+	}
+
+	if pointer != "" {
+		if !strings.HasPrefix(pointer, "/") {
+			return "", fmt.Errorf("invalid JSON pointer '%s'", pointer)
+		}
+		for _, token := range strings.Split(pointer[1:], "/") {
+			token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+			switch v := value.(type) {
+			case map[string]interface{}:
+				child, found := v[token]
+				if !found {
+					return "", fmt.Errorf("JSON pointer '%s' not found in body", pointer)
+				}
+				value = child
+			case []interface{}:
+				i, err := strconv.Atoi(token)
+				if err != nil || i < 0 || i >= len(v) {
+					return "", fmt.Errorf("JSON pointer '%s' not found in body", pointer)
+				}
+				value = v[i]
+			default:
+				return "", fmt.Errorf("JSON pointer '%s' not found in body", pointer)
+			}
+		}
+	}
+
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	out, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
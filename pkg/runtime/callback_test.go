@@ -0,0 +1,81 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveCallbackURL(t *testing.T) {
+	req := httptest.NewRequest("POST", "http://api.example.com/pets/5/subscribe?to=https://me.example.com", nil)
+	req.Header.Set("X-Callback", "https://hooks.example.com")
+	trigger := CallbackTrigger{
+		Request:    req,
+		PathParams: map[string]string{"id": "5"},
+		RequestBody: map[string]interface{}{
+			"callback": map[string]interface{}{"url": "https://body.example.com", "port": 8080},
+			"a/b":      "slash",
+			"urls":     []string{"https://first.example.com"},
+		},
+		StatusCode:     201,
+		ResponseHeader: http.Header{"Location": []string{"/subscriptions/3"}},
+		ResponseBody:   []byte(`{"id": 3}`),
+	}
+
+	resolve := func(expression string) string {
+		url, err := ResolveCallbackURL(expression, trigger)
+		assert.NoError(t, err, expression)
+		return url
+	}
+	assert.Equal(t, "https://body.example.com/events", resolve("{$request.body#/callback/url}/events"))
+	assert.Equal(t, "https://first.example.com", resolve("{$request.body#/urls/0}"))
+	assert.Equal(t, "8080", resolve("{$request.body#/callback/port}"))
+	assert.Equal(t, "slash", resolve("{$request.body#/a~1b}"))
+	assert.Equal(t, "https://hooks.example.com/x", resolve("{$request.header.x-callback}/x"))
+	assert.Equal(t, "https://me.example.com", resolve("{$request.query.to}"))
+	assert.Equal(t, "http://h/5/POST", resolve("http://h/{$request.path.id}/{$method}"))
+	assert.Equal(t, "http://api.example.com/pets/5/subscribe?to=https://me.example.com", resolve("{$url}"))
+	assert.Equal(t, "http://h/201/3/subscriptions/3", resolve("http://h/{$statusCode}/{$response.body#/id}{$response.header.Location}"))
+	assert.Equal(t, "https://static.example.com", resolve("https://static.example.com"))
+
+	for _, expression := range []string{
+		"{$request.body#/missing}",
+		"{$request.body#/urls/1}",
+		"{$request.query.missing}",
+		"{$request.path.missing}",
+		"{$request.cookie.x}",
+		"{$request.body#/urls",
+	} {
+		_, err := ResolveCallbackURL(expression, trigger)
+		assert.Error(t, err, expression)
+	}
+}
+
+func TestNewEchoCallbackTrigger(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest("POST", "/pets/5", nil)
+	ctx := e.NewContext(req, httptest.NewRecorder())
+	ctx.SetParamNames("id")
+	ctx.SetParamValues("5")
+
+	trigger := NewEchoCallbackTrigger(ctx, map[string]string{"url": "https://me.example.com"})
+	url, err := ResolveCallbackURL("{$request.body#/url}/pets/{$request.path.id}", trigger)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://me.example.com/pets/5", url)
+}
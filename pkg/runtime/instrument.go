@@ -0,0 +1,105 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// OperationKind says which side of an operation is instrumented.
+type OperationKind int
+
+const (
+	ClientOperation OperationKind = iota + 1 // A generated client sending a request
+	ServerOperation                          // A generated server handling one
+)
+
+func (k OperationKind) String() string {
+	switch k {
+	case ClientOperation:
+		return "client"
+	case ServerOperation:
+		return "server"
+	}
+	return "unknown"
+}
+
+// Instrumentation is told of the operations which generated clients send and
+// generated servers handle, for tracing and metrics. The otelinstrument
+// package implements it with OpenTelemetry, starting a span named after
+// op.OperationID, with an http.route attribute of op.Path, the path template,
+// and recording the latency and status code when the operation ends. Clients
+// and servers without instrumentation don't call it at all.
+type Instrumentation interface {
+	// StartOperation is called as an operation starts, with the request,
+	// whose headers it may add to, such as to propagate a trace. The context
+	// it returns is the one in which the request is sent or handled, and the
+	// function is called once the operation ends.
+	StartOperation(ctx context.Context, kind OperationKind, op *OperationInfo, req *http.Request) (context.Context, EndOperationFunc)
+}
+
+// EndOperationFunc is called when an operation ends, with the status code of
+// the response, or zero if there's none, and the error which the client or
+// handler returned, if any.
+type EndOperationFunc func(statusCode int, err error)
+
+// InstrumentRequest sends the request of a generated client for op with send,
+// telling instrumentation of it. The operation ends once send returns, which
+// is when the headers of the response have arrived.
+func InstrumentRequest(ctx context.Context, req *http.Request, op *OperationInfo, instrumentation Instrumentation,
+	send func(context.Context, *http.Request, *OperationInfo) (*http.Response, error)) (*http.Response, error) {
+	ctx, end := instrumentation.StartOperation(ctx, ClientOperation, op, req)
+	rsp, err := send(ctx, req, op)
+	statusCode := 0
+	if rsp != nil {
+		statusCode = rsp.StatusCode
+	}
+	end(statusCode, err)
+	return rsp, err
+}
+
+// InstrumentHandler returns a handler which tells instrumentation of the
+// requests for op which handler handles, or, if instrumentation is nil,
+// handler itself. The handler is given the request with the context which
+// StartOperation returns.
+func InstrumentHandler(handler echo.HandlerFunc, op *OperationInfo, instrumentation Instrumentation) echo.HandlerFunc {
+	if instrumentation == nil {
+		return handler
+	}
+	return func(ctx echo.Context) error {
+		req := ctx.Request()
+		opCtx, end := instrumentation.StartOperation(req.Context(), ServerOperation, op, req)
+		ctx.SetRequest(req.WithContext(opCtx))
+		err := handler(ctx)
+		end(handlerStatusCode(ctx, err), err)
+		return err
+	}
+}
+
+// Returns the status code of the response to a request, which, if the handler
+// failed without responding, is the one echo's error handler will respond
+// with.
+func handlerStatusCode(ctx echo.Context, err error) int {
+	rsp := ctx.Response()
+	if err == nil || rsp.Committed {
+		return rsp.Status
+	}
+	if he, ok := err.(*echo.HTTPError); ok {
+		return he.Code
+	}
+	return http.StatusInternalServerError
+}
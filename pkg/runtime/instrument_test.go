@@ -0,0 +1,100 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type contextKey string
+
+// Records how operations end, and puts their IDs in the context.
+type testInstrumentation struct {
+	started []string
+	ended   []int
+	errs    []error
+}
+
+func (i *testInstrumentation) StartOperation(ctx context.Context, kind OperationKind, op *OperationInfo, req *http.Request) (context.Context, EndOperationFunc) {
+	i.started = append(i.started, kind.String()+" "+op.OperationID+" "+RouteKey(op.Method, op.Path))
+	req.Header.Set("X-Trace", op.OperationID)
+	return context.WithValue(ctx, contextKey("op"), op.OperationID), func(statusCode int, err error) {
+		i.ended = append(i.ended, statusCode)
+		i.errs = append(i.errs, err)
+	}
+}
+
+func TestInstrumentHandler(t *testing.T) {
+	op := &OperationInfo{OperationID: "getPet", Method: http.MethodGet, Path: "/pets/{id}"}
+	handler := func(ctx echo.Context) error {
+		switch ctx.Param("id") {
+		case "missing":
+			return echo.NewHTTPError(http.StatusNotFound)
+		case "broken":
+			return errors.New("broken")
+		case "written":
+			_ = ctx.NoContent(http.StatusAccepted)
+			return errors.New("after writing")
+		}
+		assert.Equal(t, "getPet", ctx.Request().Context().Value(contextKey("op")))
+		return ctx.String(http.StatusOK, ctx.Request().Header.Get("X-Trace"))
+	}
+
+	// Without instrumentation, the handler is left as it is.
+	assert.Equal(t, reflect.ValueOf(handler).Pointer(), reflect.ValueOf(InstrumentHandler(handler, op, nil)).Pointer())
+
+	instrumentation := &testInstrumentation{}
+	e := echo.New()
+	e.GET("/pets/:id", InstrumentHandler(handler, op, instrumentation))
+	for _, id := range []string{"1", "missing", "broken", "written"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/pets/"+id, nil))
+	}
+	assert.Equal(t, []string{"server getPet GET /pets/{id}", "server getPet GET /pets/{id}",
+		"server getPet GET /pets/{id}", "server getPet GET /pets/{id}"}, instrumentation.started)
+	assert.Equal(t, []int{http.StatusOK, http.StatusNotFound, http.StatusInternalServerError, http.StatusAccepted}, instrumentation.ended)
+	assert.NoError(t, instrumentation.errs[0])
+	assert.Error(t, instrumentation.errs[2])
+}
+
+func TestInstrumentRequest(t *testing.T) {
+	op := &OperationInfo{OperationID: "listPets", Method: http.MethodGet, Path: "/pets"}
+	instrumentation := &testInstrumentation{}
+	send := func(ctx context.Context, req *http.Request, sent *OperationInfo) (*http.Response, error) {
+		assert.Equal(t, op, sent)
+		assert.Equal(t, "listPets", ctx.Value(contextKey("op")))
+		assert.Equal(t, "listPets", req.Header.Get("X-Trace"))
+		if req.URL.Query().Get("fail") != "" {
+			return nil, errors.New("connection refused")
+		}
+		return &http.Response{StatusCode: http.StatusTeapot}, nil
+	}
+
+	rsp, err := InstrumentRequest(context.Background(), httptest.NewRequest(http.MethodGet, "/pets", nil), op, instrumentation, send)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTeapot, rsp.StatusCode)
+	_, err = InstrumentRequest(context.Background(), httptest.NewRequest(http.MethodGet, "/pets?fail=1", nil), op, instrumentation, send)
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{"client listPets GET /pets", "client listPets GET /pets"}, instrumentation.started)
+	assert.Equal(t, []int{http.StatusTeapot, 0}, instrumentation.ended)
+	assert.Equal(t, err, instrumentation.errs[1])
+}
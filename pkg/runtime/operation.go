@@ -0,0 +1,105 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"encoding/json"
+	"regexp"
+
+	"github.com/labstack/echo/v4"
+)
+
+// OperationContextKey is the key under which generated servers keep the
+// OperationInfo of the current request in the echo context.
+const OperationContextKey = "oapi-codegen/operation"
+
+// SecurityRequirement lists the scopes which a request needs of each of the
+// security schemes it names. A request must satisfy all of them.
+type SecurityRequirement map[string][]string
+
+// OperationInfo is what the spec says about an operation, for routing,
+// authentication and metrics middleware, so that it doesn't have to go back
+// to the spec for it.
+type OperationInfo struct {
+	OperationID string   // The operationId, as it is in the spec
+	Method      string   // GET, POST, etc.
+	Path        string   // The path template, eg /pets/{id}
+	Tags        []string // Any tags, in the order of the spec
+
+	// The security requirements of the operation, of which a request must
+	// satisfy any one. The operation's own requirements replace those of the
+	// spec as a whole. An empty list means that no security is needed.
+	Security []SecurityRequirement
+
+	Deprecated bool
+
+	// The x- extensions of the operation, as raw JSON.
+	Extensions map[string]json.RawMessage
+}
+
+// RouteKey returns the key of a route in the generated OperationsByRoute,
+// eg "GET /pets/{id}".
+func RouteKey(method string, path string) string {
+	return method + " " + path
+}
+
+// Extension decodes the x- extension of the given name into v, and says
+// whether the operation has it.
+func (o *OperationInfo) Extension(name string, v interface{}) (bool, error) {
+	raw, found := o.Extensions[name]
+	if !found {
+		return false, nil
+	}
+	return true, json.Unmarshal(raw, v)
+}
+
+// SetOperationInfo stashes the operation of the current request in the echo
+// context. The generated handler wrappers call this.
+func SetOperationInfo(ctx echo.Context, info *OperationInfo) {
+	ctx.Set(OperationContextKey, info)
+}
+
+// GetOperationInfo returns the operation of the current request, or nil if
+// none was stashed in the echo context.
+func GetOperationInfo(ctx echo.Context) *OperationInfo {
+	info, _ := ctx.Get(OperationContextKey).(*OperationInfo)
+	return info
+}
+
+// The parameters of path templates, as they're turned into echo routes.
+var pathTemplateParamRE = regexp.MustCompile("{[.;?]?([^{}*]+)\\*?}")
+
+// OperationInfoMiddleware stashes the operation of each request in the echo
+// context before calling the next handler. The handler wrappers do this too,
+// but only once they're called, so middleware which wants the operation
+// before the handler runs, such as for authentication, needs this instead.
+// operations are the generated Operations, and basePath is where they were
+// registered, as with RegisterHandlersWithBaseURL. Requests for other routes
+// pass through without an operation.
+func OperationInfoMiddleware(operations []OperationInfo, basePath string) echo.MiddlewareFunc {
+	routes := make(map[string]*OperationInfo, len(operations))
+	for i := range operations {
+		op := &operations[i]
+		route := basePath + pathTemplateParamRE.ReplaceAllString(op.Path, ":$1")
+		routes[RouteKey(op.Method, route)] = op
+	}
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			if op, found := routes[RouteKey(ctx.Request().Method, ctx.Path())]; found {
+				SetOperationInfo(ctx, op)
+			}
+			return next(ctx)
+		}
+	}
+}
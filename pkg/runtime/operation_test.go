@@ -0,0 +1,35 @@
+package runtime
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationInfoMiddleware(t *testing.T) {
+	operations := []OperationInfo{
+		{OperationID: "getPet", Method: "GET", Path: "/pets/{id}"},
+		{OperationID: "getLabel", Method: "GET", Path: "/labels/{.label*}"},
+	}
+	var seen []*OperationInfo
+	handler := func(ctx echo.Context) error {
+		seen = append(seen, GetOperationInfo(ctx))
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	e := echo.New()
+	e.Use(OperationInfoMiddleware(operations, "/api"))
+	e.GET("/api/pets/:id", handler)
+	e.GET("/api/labels/:label", handler)
+	e.GET("/api/other", handler)
+
+	for _, path := range []string{"/api/pets/rex", "/api/labels/.red", "/api/other"} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	}
+	assert.Equal(t, []*OperationInfo{&operations[0], &operations[1], nil}, seen)
+}
@@ -14,6 +14,10 @@
 package runtime
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
+
 	"github.com/labstack/echo/v4"
 )
 
@@ -31,3 +35,69 @@ type EchoRouter interface {
 	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
 	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
 }
+
+// BaseURLPath returns the path of a server URL, without a trailing slash, so
+// that it may be used as the prefix of the routes which the server serves.
+// "https://example.com/v2/" has the base path "/v2", for example.
+func BaseURLPath(baseURL string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("error parsing base URL '%s': %s", baseURL, err)
+	}
+	return strings.TrimRight(u.Path, "/"), nil
+}
+
+// OperationURL returns the URL which a client requests an operation at. Its
+// path, in which parameters have been styled for paths, is relative to the
+// server URL, whether or not that ends in a slash, and an empty path is the
+// server URL itself, as for callbacks and webhooks. The query is made of the
+// styled query parameters, which follow any query of the server URL.
+func OperationURL(server string, path string, query []string) (*url.URL, error) {
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing server URL '%s': %s", server, err)
+	}
+	operationURL := serverURL
+	if path != "" {
+		base := *serverURL
+		if !strings.HasSuffix(base.Path, "/") {
+			base.Path += "/"
+			if base.RawPath != "" {
+				base.RawPath += "/"
+			}
+		}
+		// The path is made relative, so that it's under the server's path,
+		// rather than replacing it.
+		ref, err := url.Parse("." + path)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing operation path '%s': %s", path, err)
+		}
+		operationURL = base.ResolveReference(ref)
+		operationURL.RawQuery = serverURL.RawQuery
+	}
+	if len(query) != 0 {
+		rawQuery := strings.Join(query, "&")
+		if operationURL.RawQuery != "" {
+			rawQuery = operationURL.RawQuery + "&" + rawQuery
+		}
+		operationURL.RawQuery = rawQuery
+	}
+	return operationURL, nil
+}
+
+// PathParam returns the value of a path parameter, with any percent-encoding
+// decoded. Echo routes requests by their escaped paths when they have escaped
+// slashes, which clients escape in parameters, and leaves the parameters
+// escaped then, whereas it decodes them otherwise.
+func PathParam(ctx echo.Context, name string) string {
+	value := ctx.Param(name)
+	if ctx.Request().URL.RawPath == "" {
+		return value
+	}
+	unescaped, err := url.PathUnescape(value)
+	if err != nil {
+		// The URL wouldn't have parsed if this were so.
+		return value
+	}
+	return unescaped
+}
@@ -0,0 +1,59 @@
+package runtime
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationURL(t *testing.T) {
+	tests := []struct {
+		server   string
+		path     string
+		query    []string
+		expected string
+	}{
+		{"https://example.com", "/pets", nil, "https://example.com/pets"},
+		{"https://example.com/", "/pets", nil, "https://example.com/pets"},
+		{"https://example.com/v2", "/pets/a%2Fb", nil, "https://example.com/v2/pets/a%2Fb"},
+		{"https://example.com/v2/", "/pets/a:b", []string{"limit=5", "tag=a%26b"}, "https://example.com/v2/pets/a:b?limit=5&tag=a%26b"},
+		{"https://example.com/v2?key=1", "/pets", []string{"limit=5"}, "https://example.com/v2/pets?key=1&limit=5"},
+		{"https://example.com/v2", "/pets/%2E%2E", nil, "https://example.com/v2/pets/%2E%2E"},
+		// Callbacks and webhooks are sent to their URLs as they are.
+		{"https://example.com/hook?token=a", "", []string{"event=b"}, "https://example.com/hook?token=a&event=b"},
+		{"https://example.com/hook", "", nil, "https://example.com/hook"},
+	}
+	for _, test := range tests {
+		u, err := OperationURL(test.server, test.path, test.query)
+		assert.NoError(t, err, test.expected)
+		assert.Equal(t, test.expected, u.String())
+	}
+
+	_, err := OperationURL("://example.com", "/pets", nil)
+	assert.Error(t, err)
+}
+
+func TestPathParam(t *testing.T) {
+	e := echo.New()
+	var id string
+	e.GET("/pets/:id", func(ctx echo.Context) error {
+		id = PathParam(ctx, "id")
+		return nil
+	})
+
+	for path, expected := range map[string]string{
+		"/pets/a%20b":      "a b",
+		"/pets/a%2Fb":      "a/b",
+		"/pets/a%2Fb%20c":  "a/b c",
+		"/pets/%C3%BC%3F":  "ü?",
+		"/pets/a%252Fb":    "a%2Fb",
+		"/pets/.a.b,c%2Cd": ".a.b,c,d",
+	} {
+		id = ""
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, expected, id, path)
+	}
+}
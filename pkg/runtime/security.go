@@ -0,0 +1,315 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SecurityProvider adds the credentials of a security scheme to requests.
+// scopes are those which the operation's security requirement lists for the
+// scheme, which only OAuth2 and OpenID Connect schemes have.
+type SecurityProvider interface {
+	Authenticate(req *http.Request, scopes []string) error
+}
+
+// SecurityProviderFunc lets a function be used as a SecurityProvider.
+type SecurityProviderFunc func(req *http.Request, scopes []string) error
+
+func (f SecurityProviderFunc) Authenticate(req *http.Request, scopes []string) error {
+	return f(req, scopes)
+}
+
+// ApplySecurity authenticates the request for an operation with the given
+// security requirements, using the providers of the schemes, by name. The
+// operation needs any one of the requirements, so the first of them for all of
+// whose schemes there are providers is applied, in the order of the scheme
+// names. Operations without requirements, such as those with security: [],
+// are left alone, as are requests when there are no providers at all. It's an
+// error if there's no provider for any of the requirements, unless one of them
+// is empty, which means that security is optional.
+func ApplySecurity(req *http.Request, requirements []SecurityRequirement, providers map[string]SecurityProvider) error {
+	if len(requirements) == 0 || len(providers) == 0 {
+		return nil
+	}
+	optional := false
+	for _, requirement := range requirements {
+		if len(requirement) == 0 {
+			optional = true
+			continue
+		}
+		schemes := make([]string, 0, len(requirement))
+		satisfied := true
+		for scheme := range requirement {
+			if providers[scheme] == nil {
+				satisfied = false
+				break
+			}
+			schemes = append(schemes, scheme)
+		}
+		if !satisfied {
+			continue
+		}
+		sort.Strings(schemes)
+		for _, scheme := range schemes {
+			err := providers[scheme].Authenticate(req, requirement[scheme])
+			if err != nil {
+				return fmt.Errorf("error authenticating with security scheme %s: %s", scheme, err)
+			}
+		}
+		return nil
+	}
+	if optional {
+		return nil
+	}
+	return fmt.Errorf("no security provider for any of the security requirements of the operation: %s", describeRequirements(requirements))
+}
+
+// Describes requirements as, eg "[api_key], [oauth petstore_auth]".
+func describeRequirements(requirements []SecurityRequirement) string {
+	parts := make([]string, len(requirements))
+	for i, requirement := range requirements {
+		schemes := make([]string, 0, len(requirement))
+		for scheme := range requirement {
+			schemes = append(schemes, scheme)
+		}
+		sort.Strings(schemes)
+		parts[i] = "[" + strings.Join(schemes, " ") + "]"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// BasicAuthProvider authenticates requests with HTTP basic authentication.
+type BasicAuthProvider struct {
+	Username string
+	Password string
+}
+
+// NewBasicAuthProvider returns a provider for an http security scheme with
+// the basic scheme.
+func NewBasicAuthProvider(username, password string) *BasicAuthProvider {
+	return &BasicAuthProvider{Username: username, Password: password}
+}
+
+func (p *BasicAuthProvider) Authenticate(req *http.Request, scopes []string) error {
+	req.SetBasicAuth(p.Username, p.Password)
+	return nil
+}
+
+// BearerTokenProvider authenticates requests with a bearer token in the
+// Authorization header.
+type BearerTokenProvider struct {
+	Token string
+}
+
+// NewBearerTokenProvider returns a provider for an http security scheme with
+// the bearer scheme.
+func NewBearerTokenProvider(token string) *BearerTokenProvider {
+	return &BearerTokenProvider{Token: token}
+}
+
+func (p *BearerTokenProvider) Authenticate(req *http.Request, scopes []string) error {
+	req.Header.Set("Authorization", "Bearer "+p.Token)
+	return nil
+}
+
+// APIKeyProvider authenticates requests with an API key, which is sent in a
+// header, query parameter or cookie, as an apiKey security scheme says.
+type APIKeyProvider struct {
+	In   string // header, query or cookie
+	Name string // The name of the header, query parameter or cookie
+	Key  string
+}
+
+// NewAPIKeyProvider returns a provider for an apiKey security scheme, which
+// sends the key in the header, query parameter or cookie of the given name.
+func NewAPIKeyProvider(in, name, key string) (*APIKeyProvider, error) {
+	switch in {
+	case "header", "query", "cookie":
+	default:
+		return nil, fmt.Errorf("unsupported API key location %q", in)
+	}
+	return &APIKeyProvider{In: in, Name: name, Key: key}, nil
+}
+
+func (p *APIKeyProvider) Authenticate(req *http.Request, scopes []string) error {
+	switch p.In {
+	case "header":
+		req.Header.Set(p.Name, p.Key)
+	case "query":
+		// The query is built as the parameters' styles say, so it's added to
+		// rather than parsed and encoded again.
+		param := url.QueryEscape(p.Name) + "=" + url.QueryEscape(p.Key)
+		if req.URL.RawQuery != "" {
+			param = req.URL.RawQuery + "&" + param
+		}
+		req.URL.RawQuery = param
+	case "cookie":
+		req.AddCookie(&http.Cookie{Name: p.Name, Value: p.Key})
+	default:
+		return fmt.Errorf("unsupported API key location %q", p.In)
+	}
+	return nil
+}
+
+// OAuth2ClientCredentials authenticates requests with access tokens which it
+// obtains from the token URL of an OAuth2 security scheme with the client
+// credentials flow. Tokens are cached for each set of scopes, and obtained
+// again shortly before they expire.
+type OAuth2ClientCredentials struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+
+	// The scopes to request when an operation's security requirement doesn't
+	// list any.
+	Scopes []string
+
+	// The client with which to request tokens, or http.DefaultClient if nil.
+	Client *http.Client
+
+	// How long before they expire tokens are obtained again, or 10 seconds if
+	// zero.
+	ExpiryLeeway time.Duration
+
+	mutex  sync.Mutex
+	tokens map[string]oauth2Token
+	now    func() time.Time // Replaced by tests
+}
+
+type oauth2Token struct {
+	authorization string // The value of the Authorization header
+	expiry        time.Time
+}
+
+// NewOAuth2ClientCredentials returns a provider for an oauth2 security scheme
+// with the clientCredentials flow, which obtains tokens from tokenURL.
+func NewOAuth2ClientCredentials(tokenURL, clientID, clientSecret string) *OAuth2ClientCredentials {
+	return &OAuth2ClientCredentials{TokenURL: tokenURL, ClientID: clientID, ClientSecret: clientSecret}
+}
+
+func (p *OAuth2ClientCredentials) Authenticate(req *http.Request, scopes []string) error {
+	if len(scopes) == 0 {
+		scopes = p.Scopes
+	}
+	scopes = append([]string{}, scopes...)
+	sort.Strings(scopes)
+	scope := strings.Join(scopes, " ")
+
+	// Tokens are requested while the lock is held, so that concurrent
+	// requests wait for one token rather than each asking for their own.
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := time.Now
+	if p.now != nil {
+		now = p.now
+	}
+	token, found := p.tokens[scope]
+	if !found || (!token.expiry.IsZero() && !now().Before(token.expiry)) {
+		var err error
+		token, err = p.requestToken(req, scope, now())
+		if err != nil {
+			return err
+		}
+		if p.tokens == nil {
+			p.tokens = make(map[string]oauth2Token)
+		}
+		p.tokens[scope] = token
+	}
+	req.Header.Set("Authorization", token.authorization)
+	return nil
+}
+
+// Invalidate forgets the cached tokens, so that new ones are obtained for
+// subsequent requests, for when the server has rejected one.
+func (p *OAuth2ClientCredentials) Invalidate() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.tokens = nil
+}
+
+// Requests a token for the scope, as RFC 6749 section 4.4 says.
+func (p *OAuth2ClientCredentials) requestToken(req *http.Request, scope string, now time.Time) (oauth2Token, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if scope != "" {
+		form.Set("scope", scope)
+	}
+	tokenReq, err := http.NewRequest(http.MethodPost, p.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return oauth2Token{}, fmt.Errorf("error creating token request: %s", err)
+	}
+	tokenReq = tokenReq.WithContext(req.Context())
+	tokenReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	tokenReq.Header.Set("Accept", "application/json")
+	tokenReq.SetBasicAuth(url.QueryEscape(p.ClientID), url.QueryEscape(p.ClientSecret))
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	rsp, err := client.Do(tokenReq)
+	if err != nil {
+		return oauth2Token{}, fmt.Errorf("error requesting token: %s", err)
+	}
+	defer rsp.Body.Close()
+
+	var body struct {
+		AccessToken      string      `json:"access_token"`
+		TokenType        string      `json:"token_type"`
+		ExpiresIn        json.Number `json:"expires_in"`
+		Error            string      `json:"error"`
+		ErrorDescription string      `json:"error_description"`
+	}
+	err = json.NewDecoder(rsp.Body).Decode(&body)
+	if rsp.StatusCode != http.StatusOK {
+		if err == nil && body.Error != "" {
+			if body.ErrorDescription != "" {
+				return oauth2Token{}, fmt.Errorf("token request failed: %s: %s", body.Error, body.ErrorDescription)
+			}
+			return oauth2Token{}, fmt.Errorf("token request failed: %s", body.Error)
+		}
+		return oauth2Token{}, fmt.Errorf("token request failed: %s", rsp.Status)
+	}
+	if err != nil {
+		return oauth2Token{}, fmt.Errorf("error decoding token response: %s", err)
+	}
+	if body.AccessToken == "" {
+		return oauth2Token{}, fmt.Errorf("token response has no access_token")
+	}
+
+	token := oauth2Token{authorization: "Bearer " + body.AccessToken}
+	if body.TokenType != "" && !strings.EqualFold(body.TokenType, "bearer") {
+		token.authorization = body.TokenType + " " + body.AccessToken
+	}
+	if body.ExpiresIn != "" {
+		seconds, err := body.ExpiresIn.Float64()
+		if err != nil {
+			return oauth2Token{}, fmt.Errorf("invalid expires_in in token response: %s", body.ExpiresIn)
+		}
+		leeway := p.ExpiryLeeway
+		if leeway == 0 {
+			leeway = 10 * time.Second
+		}
+		token.expiry = now.Add(time.Duration(seconds*float64(time.Second)) - leeway)
+	}
+	return token, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyProvider(t *testing.T) {
+	_, err := NewAPIKeyProvider("body", "key", "secret")
+	assert.Error(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/pets?tag=a%2Fb", nil)
+	for _, in := range []string{"header", "query", "cookie"} {
+		p, err := NewAPIKeyProvider(in, "api_key", "s&cret")
+		require.NoError(t, err)
+		require.NoError(t, p.Authenticate(req, nil))
+	}
+	assert.Equal(t, "s&cret", req.Header.Get("api_key"))
+	assert.Equal(t, "tag=a%2Fb&api_key=s%26cret", req.URL.RawQuery)
+	cookie, err := req.Cookie("api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "s&cret", cookie.Value)
+}
+
+func TestApplySecurity(t *testing.T) {
+	var applied []string
+	provider := func(name string) SecurityProvider {
+		return SecurityProviderFunc(func(req *http.Request, scopes []string) error {
+			applied = append(applied, name+":"+strings.Join(scopes, ","))
+			return nil
+		})
+	}
+	providers := map[string]SecurityProvider{
+		"api_key":  provider("api_key"),
+		"basic":    provider("basic"),
+		"petstore": provider("petstore"),
+	}
+	tests := []struct {
+		requirements []SecurityRequirement
+		applied      []string
+		err          bool
+	}{
+		// No security, as with security: []
+		{nil, nil, false},
+		{[]SecurityRequirement{}, nil, false},
+		// Only the listed scheme
+		{[]SecurityRequirement{{"basic": {}}}, []string{"basic:"}, false},
+		// Scopes are passed along
+		{[]SecurityRequirement{{"petstore": {"read", "write"}}}, []string{"petstore:read,write"}, false},
+		// All of the schemes of a requirement, in order
+		{[]SecurityRequirement{{"petstore": {"read"}, "api_key": {}}}, []string{"api_key:", "petstore:read"}, false},
+		// The first requirement which can be satisfied
+		{[]SecurityRequirement{{"oidc": {}}, {"basic": {}}, {"api_key": {}}}, []string{"basic:"}, false},
+		{[]SecurityRequirement{{"oidc": {}, "basic": {}}, {"api_key": {}}}, []string{"api_key:"}, false},
+		// Optional security
+		{[]SecurityRequirement{{"oidc": {}}, {}}, nil, false},
+		// Unsatisfiable
+		{[]SecurityRequirement{{"oidc": {}}}, nil, true},
+	}
+	for i, test := range tests {
+		applied = nil
+		req := httptest.NewRequest(http.MethodGet, "https://example.com/pets", nil)
+		err := ApplySecurity(req, test.requirements, providers)
+		if test.err {
+			assert.Error(t, err, i)
+		} else {
+			assert.NoError(t, err, i)
+		}
+		assert.Equal(t, test.applied, applied, i)
+	}
+
+	// Without providers, requests are left to the request editor.
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/pets", nil)
+	assert.NoError(t, ApplySecurity(req, []SecurityRequirement{{"oidc": {}}}, nil))
+}
+
+func TestOAuth2ClientCredentials(t *testing.T) {
+	var mutex sync.Mutex
+	var requests []string
+	n := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		id, secret, _ := r.BasicAuth()
+		if id != "client%2Fid" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client", "error_description": "who?"})
+			return
+		}
+		assert.Equal(t, "client_credentials", r.PostFormValue("grant_type"))
+		requests = append(requests, r.PostFormValue("scope"))
+		n++
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "token" + string(rune('0'+n)),
+			"token_type":   "bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := NewOAuth2ClientCredentials(tokenServer.URL, "client/id", "secret")
+	p.Scopes = []string{"read"}
+	p.now = func() time.Time { return now }
+
+	authenticate := func(scopes ...string) string {
+		req := httptest.NewRequest(http.MethodGet, "https://example.com/pets", nil)
+		require.NoError(t, p.Authenticate(req, scopes))
+		return req.Header.Get("Authorization")
+	}
+
+	assert.Equal(t, "Bearer token1", authenticate())
+	// Cached
+	assert.Equal(t, "Bearer token1", authenticate("read"))
+	// Tokens are per set of scopes, whatever their order
+	assert.Equal(t, "Bearer token2", authenticate("write", "read"))
+	assert.Equal(t, "Bearer token2", authenticate("read", "write"))
+	assert.Equal(t, []string{"read", "read write"}, requests)
+
+	// Refreshed before they expire
+	now = now.Add(3590 * time.Second)
+	assert.Equal(t, "Bearer token3", authenticate())
+	assert.Equal(t, "Bearer token3", authenticate())
+
+	p.Invalidate()
+	assert.Equal(t, "Bearer token4", authenticate())
+
+	// Errors from the token URL
+	p = NewOAuth2ClientCredentials(tokenServer.URL, "client/id", "wrong")
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/pets", nil)
+	err := p.Authenticate(req, nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid_client: who?")
+	}
+	assert.Empty(t, req.Header.Get("Authorization"))
+}
@@ -0,0 +1,83 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/ghodss/yaml"
+	"github.com/labstack/echo/v4"
+)
+
+// SpecHandlerOptions says where RegisterSpecHandlers serves the spec. Each of
+// the forms of the spec is only served if its path is set.
+type SpecHandlerOptions struct {
+	JSONPath string // The path of the spec as JSON, eg /openapi.json
+	YAMLPath string // The path of the spec as YAML, eg /openapi.yaml
+	HTMLPath string // The path of the HTML reference page, eg /docs
+}
+
+// DefaultSpecHandlerOptions serves the spec at /openapi.json and
+// /openapi.yaml, and the reference page at /docs.
+func DefaultSpecHandlerOptions() SpecHandlerOptions {
+	return SpecHandlerOptions{
+		JSONPath: "/openapi.json",
+		YAMLPath: "/openapi.yaml",
+		HTMLPath: "/docs",
+	}
+}
+
+// Content types of the forms of the spec which RegisterSpecHandlers serves.
+const (
+	specContentTypeJSON = echo.MIMEApplicationJSONCharsetUTF8
+	specContentTypeYAML = "application/yaml"
+	specContentTypeHTML = echo.MIMETextHTMLCharsetUTF8
+)
+
+// RegisterSpecHandlers adds routes to the router which serve the spec, in the
+// forms and at the paths given by opts. The spec is rendered once, up front,
+// so any changes to it afterwards aren't served.
+func RegisterSpecHandlers(router EchoRouter, swagger *openapi3.Swagger, opts SpecHandlerOptions) error {
+	jsonSpec, err := json.Marshal(swagger)
+	if err != nil {
+		return fmt.Errorf("error marshaling spec as JSON: %s", err)
+	}
+	if opts.JSONPath != "" {
+		router.GET(opts.JSONPath, specHandler(specContentTypeJSON, jsonSpec))
+	}
+	if opts.YAMLPath != "" {
+		yamlSpec, err := yaml.JSONToYAML(jsonSpec)
+		if err != nil {
+			return fmt.Errorf("error marshaling spec as YAML: %s", err)
+		}
+		router.GET(opts.YAMLPath, specHandler(specContentTypeYAML, yamlSpec))
+	}
+	if opts.HTMLPath != "" {
+		page, err := RenderSpecHTML(swagger)
+		if err != nil {
+			return err
+		}
+		router.GET(opts.HTMLPath, specHandler(specContentTypeHTML, page))
+	}
+	return nil
+}
+
+func specHandler(contentType string, body []byte) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		return ctx.Blob(http.StatusOK, contentType, body)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSpec = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Pet <Store>
+  description: All about pets.
+servers:
+  - url: https://api.example.com/v1
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      summary: Get a pet
+      deprecated: true
+      parameters:
+        - name: id
+          in: path
+          required: true
+          description: The ID of the pet.
+          schema:
+            type: integer
+            format: int64
+      responses:
+        '200':
+          description: The pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      description: A pet.
+      required: [name]
+      properties:
+        name:
+          type: string
+        tags:
+          type: array
+          items:
+            type: string
+`
+
+func loadTestSpec(t *testing.T) *openapi3.Swagger {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return swagger
+}
+
+func serve(e *echo.Echo, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestRegisterSpecHandlers(t *testing.T) {
+	e := echo.New()
+	err := RegisterSpecHandlers(e, loadTestSpec(t), SpecHandlerOptions{
+		JSONPath: "/spec.json",
+		YAMLPath: "/spec.yaml",
+	})
+	assert.NoError(t, err)
+
+	rec := serve(e, "/spec.json")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, echo.MIMEApplicationJSONCharsetUTF8, rec.Header().Get(echo.HeaderContentType))
+	served, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData(rec.Body.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, "getPet", served.Paths["/pets/{id}"].Get.OperationID)
+
+	rec = serve(e, "/spec.yaml")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/yaml", rec.Header().Get(echo.HeaderContentType))
+	assert.Contains(t, rec.Body.String(), "operationId: getPet")
+
+	// The HTML page wasn't asked for
+	rec = serve(e, "/docs")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestRenderSpecHTML(t *testing.T) {
+	page, err := RenderSpecHTML(loadTestSpec(t))
+	assert.NoError(t, err)
+	html := string(page)
+
+	// Everything's escaped
+	assert.Contains(t, html, "<title>Pet &lt;Store&gt;</title>")
+	assert.Contains(t, html, "https://api.example.com/v1")
+	assert.Contains(t, html, `<section id="operation-getPet">`)
+	assert.Contains(t, html, `<span class="badge">deprecated</span>`)
+	assert.Contains(t, html, "The ID of the pet.")
+	assert.Contains(t, html, "integer (int64)")
+	assert.Contains(t, html, `<a href="#schema-Pet">Pet</a>`)
+	assert.Contains(t, html, `<section id="schema-Pet">`)
+	assert.Contains(t, html, "array of string")
+	// Nothing is loaded from elsewhere, so the page works offline
+	assert.NotContains(t, html, "<script")
+	assert.NotContains(t, html, "http://")
+	assert.NotContains(t, html, `src="`)
+}
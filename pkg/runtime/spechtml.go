@@ -0,0 +1,355 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// The order in which the reference page lists the operations of a path.
+var specHTMLMethods = []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE", "CONNECT"}
+
+// What the reference page template is rendered from.
+type specPage struct {
+	Title       string
+	Version     string
+	Description string
+	Servers     []*openapi3.Server
+	Operations  []specOperation
+	Schemas     []specSchema
+}
+
+type specOperation struct {
+	Anchor      string
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	Tags        []string
+	Deprecated  bool
+	Parameters  []specField
+	Bodies      []specContent
+	Responses   []specResponse
+}
+
+type specField struct {
+	Name        string
+	In          string
+	Type        specType
+	Required    bool
+	Deprecated  bool
+	Description string
+}
+
+type specContent struct {
+	ContentType string
+	Type        specType
+}
+
+type specResponse struct {
+	Status      string
+	Description string
+	Content     []specContent
+}
+
+type specSchema struct {
+	Name        string
+	Type        specType
+	Description string
+	Properties  []specField
+}
+
+// The type of a schema, as it's shown on the page, which links to the schema
+// it refers to, if any.
+type specType struct {
+	Name string
+	Link string
+}
+
+// RenderSpecHTML renders the spec as a reference page, which is a single HTML
+// document with no scripts, styles or fonts from elsewhere, so that it works
+// offline.
+func RenderSpecHTML(swagger *openapi3.Swagger) ([]byte, error) {
+	page := specPage{
+		Title:       swagger.Info.Title,
+		Version:     swagger.Info.Version,
+		Description: swagger.Info.Description,
+		Servers:     swagger.Servers,
+	}
+
+	paths := make([]string, 0, len(swagger.Paths))
+	for path := range swagger.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		pathItem := swagger.Paths[path]
+		for _, method := range specHTMLMethods {
+			op := pathItem.GetOperation(method)
+			if op == nil {
+				continue
+			}
+			page.Operations = append(page.Operations, newSpecOperation(method, path, pathItem, op))
+		}
+	}
+
+	names := make([]string, 0, len(swagger.Components.Schemas))
+	for name := range swagger.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		sref := swagger.Components.Schemas[name]
+		if sref == nil || sref.Value == nil {
+			continue
+		}
+		schema := specSchema{
+			Name:        name,
+			Type:        newSpecType(&openapi3.SchemaRef{Value: sref.Value}),
+			Description: sref.Value.Description,
+			Properties:  newSpecProperties(sref.Value),
+		}
+		page.Schemas = append(page.Schemas, schema)
+	}
+
+	var buf bytes.Buffer
+	if err := specHTMLTemplate.Execute(&buf, page); err != nil {
+		return nil, fmt.Errorf("error rendering spec as HTML: %s", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func newSpecOperation(method string, path string, pathItem *openapi3.PathItem, op *openapi3.Operation) specOperation {
+	o := specOperation{
+		Anchor:      "operation-" + strings.ToLower(method) + "-" + path,
+		Method:      method,
+		Path:        path,
+		Summary:     op.Summary,
+		Description: op.Description,
+		Tags:        op.Tags,
+		Deprecated:  op.Deprecated,
+	}
+	if op.OperationID != "" {
+		o.Anchor = "operation-" + op.OperationID
+	}
+
+	params := append(openapi3.Parameters{}, pathItem.Parameters...)
+	params = append(params, op.Parameters...)
+	for _, pref := range params {
+		p := pref.Value
+		if p == nil {
+			continue
+		}
+		field := specField{
+			Name:        p.Name,
+			In:          p.In,
+			Required:    p.Required,
+			Deprecated:  p.Deprecated,
+			Description: p.Description,
+		}
+		if p.Schema != nil {
+			field.Type = newSpecType(p.Schema)
+		} else {
+			for _, contentType := range sortedContentTypes(p.Content) {
+				field.Type = newSpecType(p.Content[contentType].Schema)
+				break
+			}
+		}
+		o.Parameters = append(o.Parameters, field)
+	}
+
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		o.Bodies = newSpecContent(op.RequestBody.Value.Content)
+	}
+
+	statuses := make([]string, 0, len(op.Responses))
+	for status := range op.Responses {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		rref := op.Responses[status]
+		if rref == nil || rref.Value == nil {
+			continue
+		}
+		o.Responses = append(o.Responses, specResponse{
+			Status:      status,
+			Description: rref.Value.Description,
+			Content:     newSpecContent(rref.Value.Content),
+		})
+	}
+	return o
+}
+
+func newSpecContent(content openapi3.Content) []specContent {
+	var out []specContent
+	for _, contentType := range sortedContentTypes(content) {
+		out = append(out, specContent{
+			ContentType: contentType,
+			Type:        newSpecType(content[contentType].Schema),
+		})
+	}
+	return out
+}
+
+func newSpecProperties(schema *openapi3.Schema) []specField {
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var fields []specField
+	for _, name := range names {
+		pref := schema.Properties[name]
+		field := specField{
+			Name:     name,
+			Type:     newSpecType(pref),
+			Required: stringInSlice(name, schema.Required),
+		}
+		if pref != nil && pref.Value != nil && pref.Ref == "" {
+			field.Description = pref.Value.Description
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// Describes the type of a schema, eg "string (date-time)", "array of Pet", or
+// just the name of the schema it refers to.
+func newSpecType(sref *openapi3.SchemaRef) specType {
+	if sref == nil {
+		return specType{}
+	}
+	if sref.Ref != "" {
+		name := sref.Ref[strings.LastIndex(sref.Ref, "/")+1:]
+		if strings.HasPrefix(sref.Ref, "#/components/schemas/") {
+			return specType{Name: name, Link: "#schema-" + name}
+		}
+		return specType{Name: name}
+	}
+	schema := sref.Value
+	if schema == nil {
+		return specType{}
+	}
+	switch {
+	case schema.Type == "array":
+		items := newSpecType(schema.Items)
+		return specType{Name: strings.TrimSpace("array of " + items.Name), Link: items.Link}
+	case len(schema.AllOf) != 0:
+		return specType{Name: "all of " + specTypeNames(schema.AllOf)}
+	case len(schema.AnyOf) != 0:
+		return specType{Name: "any of " + specTypeNames(schema.AnyOf)}
+	case len(schema.OneOf) != 0:
+		return specType{Name: "one of " + specTypeNames(schema.OneOf)}
+	case schema.Format != "":
+		return specType{Name: fmt.Sprintf("%s (%s)", schema.Type, schema.Format)}
+	case schema.Type == "":
+		return specType{Name: "object"}
+	}
+	return specType{Name: schema.Type}
+}
+
+func specTypeNames(srefs []*openapi3.SchemaRef) string {
+	names := make([]string, len(srefs))
+	for i, sref := range srefs {
+		names[i] = newSpecType(sref).Name
+	}
+	return strings.Join(names, ", ")
+}
+
+func sortedContentTypes(content openapi3.Content) []string {
+	contentTypes := make([]string, 0, len(content))
+	for contentType := range content {
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+	return contentTypes
+}
+
+func stringInSlice(s string, slice []string) bool {
+	for _, e := range slice {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+var specHTMLTemplate = template.Must(template.New("spec").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0 auto; max-width: 60em; padding: 1em 2em; color: #222; line-height: 1.4; }
+h1 small { color: #777; font-weight: normal; }
+section { border-top: 1px solid #ddd; padding: 0.5em 0; }
+code, .path { font-family: Menlo, Consolas, monospace; }
+.method { display: inline-block; min-width: 4.5em; padding: 0.1em 0.4em; border-radius: 3px; color: #fff; background: #555; font-size: 0.8em; font-weight: bold; text-align: center; }
+.method-GET { background: #2b7bb9; } .method-POST { background: #2f9e44; } .method-PUT { background: #c77c02; }
+.method-PATCH { background: #8f6bb3; } .method-DELETE { background: #c92a2a; }
+.deprecated { text-decoration: line-through; } .badge { color: #c92a2a; font-size: 0.8em; }
+.description { white-space: pre-wrap; }
+table { border-collapse: collapse; width: 100%; margin: 0.5em 0; }
+th, td { border: 1px solid #ddd; padding: 0.3em 0.5em; text-align: left; vertical-align: top; }
+th { background: #f5f5f5; }
+nav ul { columns: 2; }
+</style>
+</head>
+<body>
+<h1>{{.Title}} <small>{{.Version}}</small></h1>
+{{with .Description}}<p class="description">{{.}}</p>{{end}}
+{{with .Servers}}<h2>Servers</h2>
+<ul>{{range .}}<li><code>{{.URL}}</code>{{with .Description}} &mdash; {{.}}{{end}}</li>{{end}}</ul>{{end}}
+{{with .Operations}}<h2>Operations</h2>
+<nav><ul>{{range .}}<li><a href="#{{.Anchor}}"><span class="method method-{{.Method}}">{{.Method}}</span> <span class="path{{if .Deprecated}} deprecated{{end}}">{{.Path}}</span></a></li>{{end}}</ul></nav>
+{{range .}}<section id="{{.Anchor}}">
+<h3><span class="method method-{{.Method}}">{{.Method}}</span> <span class="path{{if .Deprecated}} deprecated{{end}}">{{.Path}}</span>{{if .Deprecated}} <span class="badge">deprecated</span>{{end}}</h3>
+{{with .Summary}}<p><strong>{{.}}</strong></p>{{end}}
+{{with .Description}}<p class="description">{{.}}</p>{{end}}
+{{with .Tags}}<p>Tags: {{range $i, $tag := .}}{{if $i}}, {{end}}{{$tag}}{{end}}</p>{{end}}
+{{with .Parameters}}<h4>Parameters</h4>
+<table><tr><th>Name</th><th>In</th><th>Type</th><th>Required</th><th>Description</th></tr>
+{{range .}}<tr><td><code{{if .Deprecated}} class="deprecated"{{end}}>{{.Name}}</code></td><td>{{.In}}</td><td>{{template "type" .Type}}</td><td>{{if .Required}}yes{{else}}no{{end}}</td><td class="description">{{.Description}}</td></tr>
+{{end}}</table>{{end}}
+{{with .Bodies}}<h4>Request body</h4>
+<table><tr><th>Content type</th><th>Type</th></tr>
+{{range .}}<tr><td><code>{{.ContentType}}</code></td><td>{{template "type" .Type}}</td></tr>
+{{end}}</table>{{end}}
+{{with .Responses}}<h4>Responses</h4>
+<table><tr><th>Status</th><th>Description</th><th>Content</th></tr>
+{{range .}}<tr><td>{{.Status}}</td><td class="description">{{.Description}}</td><td>{{range .Content}}<code>{{.ContentType}}</code> {{template "type" .Type}}<br>{{end}}</td></tr>
+{{end}}</table>{{end}}
+</section>
+{{end}}{{end}}
+{{with .Schemas}}<h2>Schemas</h2>
+{{range .}}<section id="schema-{{.Name}}">
+<h3><code>{{.Name}}</code> <small>{{template "type" .Type}}</small></h3>
+{{with .Description}}<p class="description">{{.}}</p>{{end}}
+{{with .Properties}}<table><tr><th>Property</th><th>Type</th><th>Required</th><th>Description</th></tr>
+{{range .}}<tr><td><code>{{.Name}}</code></td><td>{{template "type" .Type}}</td><td>{{if .Required}}yes{{else}}no{{end}}</td><td class="description">{{.Description}}</td></tr>
+{{end}}</table>{{end}}
+</section>
+{{end}}{{end}}
+</body>
+</html>
+{{define "type"}}{{if .Link}}<a href="{{.Link}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}{{end}}
+`))
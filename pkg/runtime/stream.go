@@ -0,0 +1,177 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strconv"
+)
+
+// SSEEvent is a single event of a text/event-stream.
+type SSEEvent struct {
+	ID    string
+	Event string // The event type, which is empty for the default, "message"
+	Data  []byte // The data lines of the event, joined with newlines
+	Retry int    // The reconnection time in milliseconds, if the event set one
+}
+
+// SSEReader reads the events of a text/event-stream response body, one at a
+// time, as they arrive, rather than buffering the whole body.
+type SSEReader struct {
+	body   io.ReadCloser
+	reader *bufio.Reader
+	event  SSEEvent
+	err    error
+}
+
+// NewSSEReader creates an SSEReader for body, which it closes when Close is
+// called.
+func NewSSEReader(body io.ReadCloser) *SSEReader {
+	return &SSEReader{body: body, reader: bufio.NewReader(body)}
+}
+
+// Next reads the next event, which Event then returns. It returns false at the
+// end of the stream, or when reading fails, in which case Err returns the
+// error.
+func (r *SSEReader) Next() bool {
+	if r.err != nil {
+		return false
+	}
+	var event SSEEvent
+	var data [][]byte
+	hasData := false
+	for {
+		line, err := readLine(r.reader)
+		if err != nil && len(line) == 0 {
+			if err != io.EOF {
+				r.err = err
+			}
+			// An event which isn't terminated by a blank line is discarded.
+			return false
+		}
+
+		if len(line) == 0 {
+			// A blank line dispatches the event, unless it has no data.
+			if !hasData {
+				event = SSEEvent{}
+				continue
+			}
+			event.Data = append([]byte{}, bytes.Join(data, []byte("\n"))...)
+			r.event = event
+			return true
+		}
+		if line[0] == ':' {
+			continue // A comment
+		}
+
+		field, value := line, []byte(nil)
+		if i := bytes.IndexByte(line, ':'); i >= 0 {
+			field, value = line[:i], line[i+1:]
+			if len(value) != 0 && value[0] == ' ' {
+				value = value[1:]
+			}
+		}
+		switch string(field) {
+		case "data":
+			data = append(data, value)
+			hasData = true
+		case "event":
+			event.Event = string(value)
+		case "id":
+			event.ID = string(value)
+		case "retry":
+			if retry, err := strconv.Atoi(string(value)); err == nil {
+				event.Retry = retry
+			}
+		}
+	}
+}
+
+// Event returns the event which Next read.
+func (r *SSEReader) Event() SSEEvent {
+	return r.event
+}
+
+// Err returns the error which stopped Next, if any.
+func (r *SSEReader) Err() error {
+	return r.err
+}
+
+// Close closes the response body.
+func (r *SSEReader) Close() error {
+	return r.body.Close()
+}
+
+// LineReader reads the lines of a response body, such as the values of an
+// application/x-ndjson response, one at a time. Blank lines are skipped.
+type LineReader struct {
+	body   io.ReadCloser
+	reader *bufio.Reader
+	line   []byte
+	err    error
+}
+
+// NewLineReader creates a LineReader for body, which it closes when Close is
+// called.
+func NewLineReader(body io.ReadCloser) *LineReader {
+	return &LineReader{body: body, reader: bufio.NewReader(body)}
+}
+
+// Next reads the next line, which Line then returns. It returns false at the
+// end of the body, or when reading fails, in which case Err returns the error.
+func (r *LineReader) Next() bool {
+	if r.err != nil {
+		return false
+	}
+	for {
+		line, err := readLine(r.reader)
+		if len(line) != 0 {
+			r.line = line
+			return true
+		}
+		if err != nil {
+			if err != io.EOF {
+				r.err = err
+			}
+			return false
+		}
+	}
+}
+
+// Line returns the line which Next read, without its line ending.
+func (r *LineReader) Line() []byte {
+	return r.line
+}
+
+// Err returns the error which stopped Next, if any.
+func (r *LineReader) Err() error {
+	return r.err
+}
+
+// Close closes the response body.
+func (r *LineReader) Close() error {
+	return r.body.Close()
+}
+
+// Reads a line, of any length, without its line ending, which may be "\n" or
+// "\r\n". The last line may have no ending, in which case it's returned along
+// with io.EOF.
+func readLine(reader *bufio.Reader) ([]byte, error) {
+	line, err := reader.ReadBytes('\n')
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	line = bytes.TrimSuffix(line, []byte("\r"))
+	return line, err
+}
@@ -0,0 +1,83 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtime
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSEReader(t *testing.T) {
+	body := ": a comment\n" +
+		"data: first\n" +
+		"\n" +
+		"event: update\r\n" +
+		"id: 2\r\n" +
+		"retry: 1500\r\n" +
+		"data:line one\r\n" +
+		"data: line two\r\n" +
+		"\r\n" +
+		"event: ignored\n" +
+		"\n" +
+		"data\n" +
+		"\n" +
+		"data: unterminated"
+	r := NewSSEReader(ioutil.NopCloser(strings.NewReader(body)))
+
+	var events []SSEEvent
+	for r.Next() {
+		events = append(events, r.Event())
+	}
+	assert.NoError(t, r.Err())
+	assert.Equal(t, []SSEEvent{
+		{Data: []byte("first")},
+		{ID: "2", Event: "update", Data: []byte("line one\nline two"), Retry: 1500},
+		{Data: []byte("")},
+	}, events)
+	assert.NoError(t, r.Close())
+}
+
+func TestLineReader(t *testing.T) {
+	r := NewLineReader(ioutil.NopCloser(strings.NewReader("{\"a\":1}\n\r\n{\"a\":2}\r\n{\"a\":3}")))
+
+	var lines []string
+	for r.Next() {
+		lines = append(lines, string(r.Line()))
+	}
+	assert.NoError(t, r.Err())
+	assert.Equal(t, []string{`{"a":1}`, `{"a":2}`, `{"a":3}`}, lines)
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestStreamReaderErrors(t *testing.T) {
+	sse := NewSSEReader(ioutil.NopCloser(io.MultiReader(strings.NewReader("data: x\n\n"), failingReader{})))
+	assert.True(t, sse.Next())
+	assert.False(t, sse.Next())
+	assert.EqualError(t, sse.Err(), "connection reset")
+	assert.False(t, sse.Next())
+
+	lines := NewLineReader(ioutil.NopCloser(failingReader{}))
+	assert.False(t, lines.Next())
+	assert.EqualError(t, lines.Err(), "connection reset")
+}
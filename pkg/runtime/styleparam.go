@@ -14,6 +14,7 @@
 package runtime
 
 import (
+	"encoding"
 	"fmt"
 	"reflect"
 	"sort"
@@ -22,9 +23,53 @@ import (
 	"time"
 )
 
+// ParamLocation is where a parameter is in a request, which decides how its
+// styled value is escaped.
+type ParamLocation int
+
+const (
+	ParamLocationUndefined ParamLocation = iota
+	ParamLocationQuery
+	ParamLocationPath
+	ParamLocationHeader
+	ParamLocationCookie
+)
+
+// StyleParamOptions are the options of StyleParamWithOptions.
+type StyleParamOptions struct {
+	// Location is where the parameter is. The names and values of path and
+	// query parameters are percent-encoded, as RFC 6570 expands the
+	// variables of URI templates, leaving only unreserved characters, while
+	// those of other parameters aren't.
+	Location ParamLocation
+	// AllowReserved leaves the reserved characters of query parameters, such
+	// as '/' and '?', and existing percent-encodings, as they are, as the
+	// allowReserved property of parameters does.
+	AllowReserved bool
+}
+
 // Given an input value, such as a primitive type, array or object, turn it
-// into a parameter based on style/explode definition.
+// into a parameter based on style/explode definition. Nothing is escaped.
 func StyleParam(style string, explode bool, paramName string, value interface{}) (string, error) {
+	return StyleParamWithOptions(style, explode, paramName, value, StyleParamOptions{})
+}
+
+// StyleParamWithOptions styles a parameter like StyleParam, and escapes it
+// for where it is in the request, so that it may be put into a URL as it is.
+func StyleParamWithOptions(style string, explode bool, paramName string, value interface{}, opts StyleParamOptions) (string, error) {
+	escape := paramEscaper(opts)
+	styled, err := styleParam(style, explode, paramName, value, escape)
+	if err != nil {
+		return "", err
+	}
+	// A path segment which is only dots would be removed from the path.
+	if opts.Location == ParamLocationPath && (styled == "." || styled == "..") {
+		styled = strings.Repeat("%2E", len(styled))
+	}
+	return styled, nil
+}
+
+func styleParam(style string, explode bool, paramName string, value interface{}, escape func(string) string) (string, error) {
 	t := reflect.TypeOf(value)
 	v := reflect.ValueOf(value)
 
@@ -38,6 +83,12 @@ func StyleParam(style string, explode bool, paramName string, value interface{})
 		t = v.Type()
 	}
 
+	// Times and types which marshal themselves as text are styled like
+	// primitives, whatever their kind.
+	if isTextType(t) {
+		return stylePrimitive(style, explode, paramName, value, escape)
+	}
+
 	switch t.Kind() {
 	case reflect.Slice:
 		n := v.Len()
@@ -45,17 +96,18 @@ func StyleParam(style string, explode bool, paramName string, value interface{})
 		for i := 0; i < n; i++ {
 			sliceVal[i] = v.Index(i).Interface()
 		}
-		return styleSlice(style, explode, paramName, sliceVal)
+		return styleSlice(style, explode, paramName, sliceVal, escape)
 	case reflect.Struct:
-		return styleStruct(style, explode, paramName, value)
+		return styleStruct(style, explode, paramName, value, escape)
 	default:
-		return stylePrimitive(style, explode, paramName, value)
+		return stylePrimitive(style, explode, paramName, value, escape)
 	}
 }
 
-func styleSlice(style string, explode bool, paramName string, values []interface{}) (string, error) {
+func styleSlice(style string, explode bool, paramName string, values []interface{}, escape func(string) string) (string, error) {
 	var prefix string
 	var separator string
+	name := escape(paramName)
 
 	switch style {
 	case "simple":
@@ -68,32 +120,32 @@ func styleSlice(style string, explode bool, paramName string, values []interface
 			separator = ","
 		}
 	case "matrix":
-		prefix = fmt.Sprintf(";%s=", paramName)
+		prefix = fmt.Sprintf(";%s=", name)
 		if explode {
 			separator = prefix
 		} else {
 			separator = ","
 		}
 	case "form":
-		prefix = fmt.Sprintf("%s=", paramName)
+		prefix = fmt.Sprintf("%s=", name)
 		if explode {
 			separator = "&" + prefix
 		} else {
 			separator = ","
 		}
 	case "spaceDelimited":
-		prefix = fmt.Sprintf("%s=", paramName)
+		prefix = fmt.Sprintf("%s=", name)
 		if explode {
 			separator = "&" + prefix
 		} else {
-			separator = " "
+			separator = escape(" ")
 		}
 	case "pipeDelimited":
-		prefix = fmt.Sprintf("%s=", paramName)
+		prefix = fmt.Sprintf("%s=", name)
 		if explode {
 			separator = "&" + prefix
 		} else {
-			separator = "|"
+			separator = escape("|")
 		}
 	default:
 		return "", fmt.Errorf("unsupported style '%s'", style)
@@ -107,6 +159,7 @@ func styleSlice(style string, explode bool, paramName string, values []interface
 		if err != nil {
 			return "", fmt.Errorf("error formatting '%s': %s", paramName, err)
 		}
+		parts[i] = escape(parts[i])
 	}
 	return prefix + strings.Join(parts, separator), nil
 }
@@ -122,43 +175,24 @@ func sortedKeys(strMap map[string]string) []string {
 	return keys
 }
 
-func styleStruct(style string, explode bool, paramName string, value interface{}) (string, error) {
-	// This is a special case. The struct may be a time, in which case, marshal
-	// it in RFC3339 format.
-	if timeVal, ok := value.(*time.Time); ok {
-		return timeVal.Format(time.RFC3339Nano), nil
-	}
-
-	// Otherwise, we need to build a dictionary of the struct's fields. Each
-	// field may only be a primitive value.
-	v := reflect.ValueOf(value)
-	t := reflect.TypeOf(value)
+func styleStruct(style string, explode bool, paramName string, value interface{}, escape func(string) string) (string, error) {
+	// We need to build a dictionary of the struct's fields. Each field may
+	// only be a primitive value, or a type which is styled like one.
 	fieldDict := make(map[string]string)
-
-	for i := 0; i < t.NumField(); i++ {
-		fieldT := t.Field(i)
-		// Find the json annotation on the field, and use the json specified
-		// name if available, otherwise, just the field name.
-		tag := fieldT.Tag.Get("json")
-		fieldName := fieldT.Name
-		if tag != "" {
-			tagParts := strings.Split(tag, ",")
-			name := tagParts[0]
-			if name != "" {
-				fieldName = name
-			}
-		}
-		f := v.Field(i)
-
+	err := forEachStructField(reflect.Indirect(reflect.ValueOf(value)), func(name string, f reflect.Value) error {
 		// Unset optional fields will be nil pointers, skip over those.
-		if f.Type().Kind() == reflect.Ptr && f.IsNil() {
-			continue
+		if f.Kind() == reflect.Ptr && f.IsNil() {
+			return nil
 		}
 		str, err := primitiveToString(f.Interface())
 		if err != nil {
-			return "", fmt.Errorf("error formatting '%s': %s", paramName, err)
+			return fmt.Errorf("error formatting '%s': %s", paramName, err)
 		}
-		fieldDict[fieldName] = str
+		fieldDict[name] = str
+		return nil
+	})
+	if err != nil {
+		return "", err
 	}
 
 	var parts []string
@@ -169,13 +203,13 @@ func styleStruct(style string, explode bool, paramName string, value interface{}
 		if explode {
 			for _, k := range sortedKeys(fieldDict) {
 				v := fieldDict[k]
-				parts = append(parts, k+"="+v)
+				parts = append(parts, escape(k)+"="+escape(v))
 			}
 		} else {
 			for _, k := range sortedKeys(fieldDict) {
 				v := fieldDict[k]
-				parts = append(parts, k)
-				parts = append(parts, v)
+				parts = append(parts, escape(k))
+				parts = append(parts, escape(v))
 			}
 		}
 	}
@@ -199,13 +233,13 @@ func styleStruct(style string, explode bool, paramName string, value interface{}
 			prefix = ";"
 		} else {
 			separator = ","
-			prefix = fmt.Sprintf(";%s=", paramName)
+			prefix = fmt.Sprintf(";%s=", escape(paramName))
 		}
 	case "form":
 		if explode {
 			separator = "&"
 		} else {
-			prefix = fmt.Sprintf("%s=", paramName)
+			prefix = fmt.Sprintf("%s=", escape(paramName))
 			separator = ","
 		}
 	case "deepObject":
@@ -215,7 +249,7 @@ func styleStruct(style string, explode bool, paramName string, value interface{}
 			}
 			for _, k := range sortedKeys(fieldDict) {
 				v := fieldDict[k]
-				part := fmt.Sprintf("%s[%s]=%s", paramName, k, v)
+				part := escape(fmt.Sprintf("%s[%s]", paramName, k)) + "=" + escape(v)
 				parts = append(parts, part)
 			}
 			separator = "&"
@@ -227,7 +261,7 @@ func styleStruct(style string, explode bool, paramName string, value interface{}
 	return prefix + strings.Join(parts, separator), nil
 }
 
-func stylePrimitive(style string, explode bool, paramName string, value interface{}) (string, error) {
+func stylePrimitive(style string, explode bool, paramName string, value interface{}, escape func(string) string) (string, error) {
 	strVal, err := primitiveToString(value)
 	if err != nil {
 		return "", err
@@ -239,30 +273,52 @@ func stylePrimitive(style string, explode bool, paramName string, value interfac
 	case "label":
 		prefix = "."
 	case "matrix":
-		prefix = fmt.Sprintf(";%s=", paramName)
+		prefix = fmt.Sprintf(";%s=", escape(paramName))
 	case "form":
-		prefix = fmt.Sprintf("%s=", paramName)
+		prefix = fmt.Sprintf("%s=", escape(paramName))
 	default:
 		return "", fmt.Errorf("unsupported style '%s'", style)
 	}
-	return prefix + strVal, nil
+	return prefix + escape(strVal), nil
 }
 
 // Converts a primitive value to a string. We need to do this based on the
-// Kind of an interface, not the Type to work with aliased types.
+// Kind of an interface, not the Type to work with aliased types. Times are
+// formatted as RFC3339 timestamps, and types which implement
+// encoding.TextMarshaler marshal themselves.
 func primitiveToString(value interface{}) (string, error) {
 	var output string
 
 	// Values may come in by pointer for optionals, so make sure to dereferene.
 	v := reflect.Indirect(reflect.ValueOf(value))
+	if !v.IsValid() {
+		return "", fmt.Errorf("value is a nil pointer")
+	}
 	t := v.Type()
 	kind := t.Kind()
 
+	if t == timeType {
+		return v.Interface().(time.Time).Format(time.RFC3339Nano), nil
+	}
+	marshaler, ok := v.Interface().(encoding.TextMarshaler)
+	if !ok && v.CanAddr() {
+		marshaler, ok = v.Addr().Interface().(encoding.TextMarshaler)
+	}
+	if ok {
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		return string(text), nil
+	}
+
 	switch kind {
-	case reflect.Int8, reflect.Int32, reflect.Int64, reflect.Int:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		output = strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		output = strconv.FormatUint(v.Uint(), 10)
 	case reflect.Float32, reflect.Float64:
-		output = strconv.FormatFloat(v.Float(), 'f', -1, 64)
+		output = strconv.FormatFloat(v.Float(), 'f', -1, t.Bits())
 	case reflect.Bool:
 		if v.Bool() {
 			output = "true"
@@ -276,3 +332,54 @@ func primitiveToString(value interface{}) (string, error) {
 	}
 	return output, nil
 }
+
+// The reserved characters of RFC 3986, which are delimiters in URLs.
+const reservedChars = ":/?#[]@!$&'()*+,;="
+
+// Returns the function which escapes the names and values of parameters, for
+// where they are in the request.
+func paramEscaper(opts StyleParamOptions) func(string) string {
+	switch opts.Location {
+	case ParamLocationPath:
+		return func(s string) string {
+			return escapeParam(s, false)
+		}
+	case ParamLocationQuery:
+		allowReserved := opts.AllowReserved
+		return func(s string) string {
+			return escapeParam(s, allowReserved)
+		}
+	}
+	return func(s string) string {
+		return s
+	}
+}
+
+// Percent-encodes every character of s which isn't unreserved, as RFC 6570
+// expands variables, or, with allowReserved, every character which is
+// neither unreserved nor reserved, and isn't part of a percent-encoding, as
+// it expands reserved variables.
+func escapeParam(s string, allowReserved bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case isUnreserved(c),
+			allowReserved && strings.IndexByte(reservedChars, c) >= 0,
+			allowReserved && c == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]):
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
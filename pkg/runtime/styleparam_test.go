@@ -14,9 +14,15 @@
 package runtime
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/deepmap/oapi-codegen/pkg/types"
 )
 
 func TestStyleParam(t *testing.T) {
@@ -236,3 +242,191 @@ func TestStyleParam(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, "firstName,Alex", result)
 }
+
+func TestStyleParamText(t *testing.T) {
+	when := time.Date(2019, 10, 21, 12, 30, 0, 0, time.UTC)
+
+	// Times and text types are styled like primitives, by value or pointer.
+	for _, value := range []interface{}{when, &when} {
+		result, err := StyleParam("form", true, "when", value)
+		assert.NoError(t, err)
+		assert.Equal(t, "when=2019-10-21T12:30:00Z", result)
+	}
+
+	result, err := StyleParam("simple", false, "day", types.Date{Time: when})
+	assert.NoError(t, err)
+	assert.Equal(t, "2019-10-21", result)
+
+	result, err = StyleParam("label", false, "id", textID{"pet", 5})
+	assert.NoError(t, err)
+	assert.Equal(t, ".pet-5", result)
+
+	result, err = StyleParam("matrix", true, "ids", []textID{{"pet", 5}, {"pet", 6}})
+	assert.NoError(t, err)
+	assert.Equal(t, ";ids=pet-5;ids=pet-6", result)
+
+	// The fields of objects may be text types too, and nil ones are left out.
+	type Base struct {
+		ID textID `json:"id"`
+	}
+	type Object struct {
+		Base
+		Day     types.Date `json:"day"`
+		Since   *time.Time `json:"since,omitempty"`
+		Until   *time.Time `json:"until,omitempty"`
+		Ignored string     `json:"-"`
+	}
+	object := Object{Base: Base{textID{"pet", 5}}, Day: types.Date{Time: when}, Since: &when, Ignored: "x"}
+
+	result, err = StyleParam("simple", true, "id", object)
+	assert.NoError(t, err)
+	assert.Equal(t, "day=2019-10-21,id=pet-5,since=2019-10-21T12:30:00Z", result)
+
+	result, err = StyleParam("deepObject", true, "filter", &object)
+	assert.NoError(t, err)
+	assert.Equal(t, "filter[day]=2019-10-21&filter[id]=pet-5&filter[since]=2019-10-21T12:30:00Z", result)
+}
+
+func TestStyleParamEscaping(t *testing.T) {
+	path := StyleParamOptions{Location: ParamLocationPath}
+	query := StyleParamOptions{Location: ParamLocationQuery}
+	reserved := StyleParamOptions{Location: ParamLocationQuery, AllowReserved: true}
+	type Object struct {
+		Name string `json:"na me"`
+		Tag  string `json:"tag"`
+	}
+	object := Object{Name: "a,b", Tag: "c=d"}
+
+	tests := []struct {
+		style    string
+		explode  bool
+		value    interface{}
+		opts     StyleParamOptions
+		expected string
+	}{
+		{"simple", false, "a/b?c#d", path, "a%2Fb%3Fc%23d"},
+		{"simple", false, "ü -._~", path, "%C3%BC%20-._~"},
+		{"simple", false, []string{"a,b", "c"}, path, "a%2Cb,c"},
+		{"simple", true, object, path, "na%20me=a%2Cb,tag=c%3Dd"},
+		{"label", false, "a/b", path, ".a%2Fb"},
+		{"label", true, []string{"a/b", "c"}, path, ".a%2Fb.c"},
+		{"matrix", false, "a;b", path, ";id=a%3Bb"},
+		{"matrix", true, []string{"a", "b=c"}, path, ";id=a;id=b%3Dc"},
+		{"matrix", true, object, path, ";na%20me=a%2Cb;tag=c%3Dd"},
+		{"simple", false, ".", path, "%2E"},
+		{"simple", false, "..", path, "%2E%2E"},
+		{"form", true, "a&b=c d+e", query, "id=a%26b%3Dc%20d%2Be"},
+		{"form", false, []string{"a,b", "c"}, query, "id=a%2Cb,c"},
+		{"form", true, []string{"a&b", "c"}, query, "id=a%26b&id=c"},
+		{"form", true, object, query, "na%20me=a%2Cb&tag=c%3Dd"},
+		{"spaceDelimited", false, []string{"a b", "c"}, query, "id=a%20b%20c"},
+		{"pipeDelimited", false, []string{"a|b", "c"}, query, "id=a%7Cb%7Cc"},
+		{"deepObject", true, object, query, "id%5Bna%20me%5D=a%2Cb&id%5Btag%5D=c%3Dd"},
+		{"form", true, "/a?b=c&d %2F %zz", reserved, "id=/a?b=c&d%20%2F%20%25zz"},
+		{"deepObject", true, object, reserved, "id[na%20me]=a,b&id[tag]=c=d"},
+		{"pipeDelimited", false, []string{"a", "b"}, reserved, "id=a%7Cb"},
+		// Other parameters aren't escaped.
+		{"simple", false, "a/b c", StyleParamOptions{Location: ParamLocationHeader}, "a/b c"},
+		{"form", true, "a&b", StyleParamOptions{}, "id=a&b"},
+	}
+	for _, test := range tests {
+		result, err := StyleParamWithOptions(test.style, test.explode, "id", test.value, test.opts)
+		assert.NoError(t, err, test.expected)
+		assert.Equal(t, test.expected, result)
+	}
+}
+
+// What the client styles and escapes, the server binds as it was.
+func TestStyleParamRoundTrip(t *testing.T) {
+	type Object struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+	values := []string{"a/b", "c?d#e", "f g+h", "i%j", "ü&k=l", "[m]", "@:$!'()*"}
+
+	for _, style := range []string{"simple", "label", "matrix"} {
+		for _, explode := range []bool{false, true} {
+			name := fmt.Sprintf("%s %v", style, explode)
+			opts := StyleParamOptions{Location: ParamLocationPath}
+
+			for _, value := range values {
+				styled, err := StyleParamWithOptions(style, explode, "id", value, opts)
+				assert.NoError(t, err, name)
+				var bound string
+				assert.NoError(t, BindStyledParameter(style, explode, "id", pathSegment(t, styled), &bound), name)
+				assert.Equal(t, value, bound, name)
+			}
+
+			var array []string
+			styled, err := StyleParamWithOptions(style, explode, "id", values, opts)
+			assert.NoError(t, err, name)
+			assert.NoError(t, BindStyledParameter(style, explode, "id", pathSegment(t, styled), &array), name)
+			assert.Equal(t, values, array, name)
+
+			object := Object{Name: "a/b?c#d e", Count: 3}
+			var boundObject Object
+			styled, err = StyleParamWithOptions(style, explode, "id", object, opts)
+			assert.NoError(t, err, name)
+			assert.NoError(t, BindStyledParameter(style, explode, "id", pathSegment(t, styled), &boundObject), name)
+			assert.Equal(t, object, boundObject, name)
+		}
+	}
+
+	opts := StyleParamOptions{Location: ParamLocationQuery}
+	for _, value := range values {
+		styled, err := StyleParamWithOptions("form", true, "id", value, opts)
+		assert.NoError(t, err, value)
+		var bound string
+		assert.NoError(t, BindQueryParameter("form", true, true, "id", parseQuery(t, styled), &bound), value)
+		assert.Equal(t, value, bound, value)
+	}
+
+	styled, err := StyleParamWithOptions("form", true, "id", values, opts)
+	assert.NoError(t, err)
+	var array []string
+	assert.NoError(t, BindQueryParameter("form", true, true, "id", parseQuery(t, styled), &array))
+	assert.Equal(t, values, array)
+
+	object := Object{Name: "a&b=c[d] e", Count: 3}
+	for _, style := range []string{"form", "deepObject"} {
+		styled, err := StyleParamWithOptions(style, true, "id", object, opts)
+		assert.NoError(t, err, style)
+		var bound Object
+		assert.NoError(t, BindQueryParameter(style, true, true, "id", parseQuery(t, styled), &bound), style)
+		assert.Equal(t, object, bound, style)
+	}
+}
+
+// Returns a styled path parameter as a server gets it, decoded, from the URL
+// of a request.
+func pathSegment(t *testing.T, styled string) string {
+	u, err := OperationURL("https://example.com/api", "/items/"+styled+"/more", nil)
+	if !assert.NoError(t, err) {
+		return ""
+	}
+	u, err = url.Parse(u.String())
+	if !assert.NoError(t, err) {
+		return ""
+	}
+	segments := strings.Split(u.EscapedPath(), "/")
+	if !assert.Len(t, segments, 5, u.String()) {
+		return ""
+	}
+	segment, err := url.PathUnescape(segments[3])
+	assert.NoError(t, err)
+	return segment
+}
+
+// Returns styled query parameters as a server gets them, from the URL of a
+// request.
+func parseQuery(t *testing.T, styled string) url.Values {
+	u, err := OperationURL("https://example.com/api", "/items", []string{styled})
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	u, err = url.Parse(u.String())
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	return u.Query()
+}
@@ -0,0 +1,131 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+)
+
+// AssertConformsTo checks that the response is one which the spec allows for
+// the operation of the request: that the operation declares its status code,
+// or a default response, that the headers it declares have values which
+// match their schemas, and that the body has a declared content type and
+// matches its schema. It reports any mismatch as a test error, and returns
+// whether there were none. The request is matched against the paths of the
+// spec without its servers, as RegisterHandlers routes them.
+func (c *CompletedRequest) AssertConformsTo(t *testing.T, swagger *openapi3.Swagger) bool {
+	t.Helper()
+	if err := c.conformsTo(swagger); err != nil {
+		t.Errorf("response to %s %s doesn't conform to the spec: %s", c.Request.Method, c.Request.URL.Path, err)
+		return false
+	}
+	return true
+}
+
+func (c *CompletedRequest) conformsTo(swagger *openapi3.Swagger) error {
+	withoutServers := *swagger
+	withoutServers.Servers = nil
+	router := openapi3filter.NewRouter()
+	if err := router.AddSwagger(&withoutServers); err != nil {
+		return err
+	}
+	route, pathParams, err := router.FindRoute(c.Request.Method, c.Request.URL)
+	if err != nil {
+		return err
+	}
+
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+		},
+		Status: c.Recorder.Code,
+		Header: c.Recorder.Header(),
+		Options: &openapi3filter.Options{
+			IncludeResponseStatus: true,
+		},
+	}
+	input.SetBodyBytes(c.Recorder.Body.Bytes())
+	if err := openapi3filter.ValidateResponse(context.Background(), input); err != nil {
+		return err
+	}
+
+	responseRef := route.Operation.Responses.Get(c.Recorder.Code)
+	if responseRef == nil {
+		responseRef = route.Operation.Responses.Default()
+	}
+	if responseRef == nil || responseRef.Value == nil {
+		return nil
+	}
+	return validateResponseHeaders(responseRef.Value, c.Recorder.Header())
+}
+
+// Checks the headers which a response declares against their schemas.
+// kin-openapi doesn't tell us which headers are required, so missing ones
+// are allowed.
+func validateResponseHeaders(response *openapi3.Response, header http.Header) error {
+	for name, headerRef := range response.Headers {
+		if headerRef == nil || headerRef.Value == nil || headerRef.Value.Schema == nil {
+			continue
+		}
+		value := header.Get(name)
+		if value == "" || http.CanonicalHeaderKey(name) == "Content-Type" {
+			continue
+		}
+		schema := headerRef.Value.Schema.Value
+		decoded, err := decodeHeaderValue(schema, value)
+		if err == nil {
+			err = schema.VisitJSON(decoded)
+		}
+		if err != nil {
+			return fmt.Errorf("header %s doesn't match its schema: %s", name, err)
+		}
+	}
+	return nil
+}
+
+// Decodes a header value, in the simple style, into what its schema expects
+// of a JSON value.
+func decodeHeaderValue(schema *openapi3.Schema, value string) (interface{}, error) {
+	switch schema.Type {
+	case "integer", "number":
+		return strconv.ParseFloat(value, 64)
+	case "boolean":
+		return strconv.ParseBool(value)
+	case "array":
+		var items []interface{}
+		for _, item := range strings.Split(value, ",") {
+			if schema.Items == nil || schema.Items.Value == nil {
+				items = append(items, item)
+				continue
+			}
+			decoded, err := decodeHeaderValue(schema.Items.Value, item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, decoded)
+		}
+		return items, nil
+	}
+	return value, nil
+}
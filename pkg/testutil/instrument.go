@@ -0,0 +1,92 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package testutil
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+)
+
+// RecordedOperation is an operation which an OperationRecorder was told of.
+type RecordedOperation struct {
+	Kind        runtime.OperationKind
+	OperationID string
+	Method      string
+	Route       string // The path template, eg /pets/{id}
+	Header      http.Header
+
+	Ended      bool
+	StatusCode int
+	Err        error
+	Duration   time.Duration
+}
+
+// OperationRecorder is an instrumentation which records the operations of
+// generated clients and servers in memory, for tests, in the order in which
+// they started.
+type OperationRecorder struct {
+	mutex      sync.Mutex
+	operations []*RecordedOperation
+}
+
+// NewOperationRecorder returns a recorder which hasn't recorded anything.
+func NewOperationRecorder() *OperationRecorder {
+	return &OperationRecorder{}
+}
+
+func (r *OperationRecorder) StartOperation(ctx context.Context, kind runtime.OperationKind, op *runtime.OperationInfo,
+	req *http.Request) (context.Context, runtime.EndOperationFunc) {
+	recorded := &RecordedOperation{
+		Kind:        kind,
+		OperationID: op.OperationID,
+		Method:      op.Method,
+		Route:       op.Path,
+		Header:      req.Header.Clone(),
+	}
+	r.mutex.Lock()
+	r.operations = append(r.operations, recorded)
+	r.mutex.Unlock()
+
+	start := time.Now()
+	return ctx, func(statusCode int, err error) {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		recorded.Ended = true
+		recorded.StatusCode = statusCode
+		recorded.Err = err
+		recorded.Duration = time.Since(start)
+	}
+}
+
+// Operations returns copies of the operations recorded so far.
+func (r *OperationRecorder) Operations() []RecordedOperation {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	operations := make([]RecordedOperation, len(r.operations))
+	for i, op := range r.operations {
+		operations[i] = *op
+	}
+	return operations
+}
+
+// Reset forgets the operations recorded so far.
+func (r *OperationRecorder) Reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.operations = nil
+}
@@ -0,0 +1,204 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+)
+
+// WithOperation sets the method and path of the request from the operation
+// with the given operationId, and sends the parameters given by params the
+// way the spec says, as the generated client would. Each of params is either
+// a map of parameter names to values, or a struct, such as the generated
+// Params type of the operation, whose fields are named by their json tags.
+// Nil values are left out. It's an error to leave out a required parameter,
+// or to give one which the operation doesn't have.
+//
+//	NewRequest().WithOperation(swagger, "findPets", FindPetsParams{Limit: &limit}).Go(t, e)
+//	NewRequest().WithOperation(swagger, "getPet", map[string]interface{}{"id": 7}).Go(t, e)
+func (r *RequestBuilder) WithOperation(swagger *openapi3.Swagger, operationID string, params ...interface{}) *RequestBuilder {
+	path, method, operation := findOperation(swagger, operationID)
+	if operation == nil {
+		r.Error = fmt.Errorf("no operation with operationId %s", operationID)
+		return r
+	}
+	values, err := paramValues(params)
+	if err != nil {
+		r.Error = fmt.Errorf("error in parameters of %s: %s", operationID, err)
+		return r
+	}
+
+	var query []string
+	for _, param := range operationParameters(swagger.Paths[path], operation) {
+		value, found := values[param.Name]
+		delete(values, param.Name)
+		if !found {
+			if param.Required {
+				r.Error = fmt.Errorf("missing required %s parameter %s of %s", param.In, param.Name, operationID)
+				return r
+			}
+			continue
+		}
+		styled, err := styleParameter(param, value)
+		if err != nil {
+			r.Error = fmt.Errorf("error in %s parameter %s of %s: %s", param.In, param.Name, operationID, err)
+			return r
+		}
+		switch param.In {
+		case openapi3.ParameterInPath:
+			path = strings.Replace(path, "{"+param.Name+"}", styled, 1)
+		case openapi3.ParameterInQuery:
+			query = append(query, styled)
+		case openapi3.ParameterInHeader:
+			r.WithHeader(param.Name, styled)
+		case openapi3.ParameterInCookie:
+			r.WithCookieNameValue(param.Name, styled)
+		}
+	}
+	if len(values) != 0 {
+		var unknown []string
+		for name := range values {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+		r.Error = fmt.Errorf("%s has no parameters %s", operationID, strings.Join(unknown, ", "))
+		return r
+	}
+	if len(query) != 0 {
+		path += "?" + strings.Join(query, "&")
+	}
+	return r.WithMethod(method, path)
+}
+
+// Returns the path, method and operation with the given operationId, or a
+// nil operation if there isn't one.
+func findOperation(swagger *openapi3.Swagger, operationID string) (string, string, *openapi3.Operation) {
+	for path, pathItem := range swagger.Paths {
+		for method, operation := range pathItem.Operations() {
+			if operation.OperationID == operationID {
+				return path, method, operation
+			}
+		}
+	}
+	return "", "", nil
+}
+
+// Returns the parameters of an operation, which are those of its path, unless
+// the operation overrides them, followed by its own.
+func operationParameters(pathItem *openapi3.PathItem, operation *openapi3.Operation) []*openapi3.Parameter {
+	var params []*openapi3.Parameter
+	for _, paramRef := range pathItem.Parameters {
+		if param := paramRef.Value; param != nil && operation.Parameters.GetByInAndName(param.In, param.Name) == nil {
+			params = append(params, param)
+		}
+	}
+	for _, paramRef := range operation.Parameters {
+		if paramRef.Value != nil {
+			params = append(params, paramRef.Value)
+		}
+	}
+	return params
+}
+
+// Turns the maps and structs which WithOperation is given into a single map
+// of parameter names to values, leaving out nil ones.
+func paramValues(params []interface{}) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	for _, p := range params {
+		v := reflect.Indirect(reflect.ValueOf(p))
+		switch {
+		case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+			for _, key := range v.MapKeys() {
+				if value := v.MapIndex(key); !isNil(value) {
+					values[key.String()] = value.Interface()
+				}
+			}
+		case v.Kind() == reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				field := v.Type().Field(i)
+				name := strings.Split(field.Tag.Get("json"), ",")[0]
+				if field.PkgPath != "" || name == "-" {
+					continue
+				}
+				if name == "" {
+					name = field.Name
+				}
+				if value := v.Field(i); !isNil(value) {
+					values[name] = value.Interface()
+				}
+			}
+		default:
+			return nil, fmt.Errorf("parameters must be given by a map or a struct, not %T", p)
+		}
+	}
+	return values, nil
+}
+
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
+
+// Styles a parameter the way the generated client does: as JSON, if that's
+// its content type, as it is, if it has some other content type, or else as
+// its style and explode say.
+func styleParameter(param *openapi3.Parameter, value interface{}) (string, error) {
+	if len(param.Content) != 0 {
+		var str string
+		if _, isJSON := param.Content["application/json"]; isJSON && len(param.Content) == 1 {
+			buf, err := json.Marshal(value)
+			if err != nil {
+				return "", err
+			}
+			str = string(buf)
+		} else {
+			str = fmt.Sprint(value)
+		}
+		switch param.In {
+		case openapi3.ParameterInQuery:
+			return param.Name + "=" + url.QueryEscape(str), nil
+		case openapi3.ParameterInCookie:
+			return url.QueryEscape(str), nil
+		}
+		return str, nil
+	}
+
+	style, explode := "form", true
+	if param.In == openapi3.ParameterInPath || param.In == openapi3.ParameterInHeader {
+		style, explode = "simple", false
+	}
+	if param.Style != "" {
+		style = param.Style
+	}
+	if param.Explode != nil {
+		explode = *param.Explode
+	}
+	if param.In == openapi3.ParameterInCookie {
+		// Cookies carry only the value, which is what simple gives us.
+		style = "simple"
+	}
+	return runtime.StyleParam(style, explode, param.Name, value)
+}
@@ -151,6 +151,7 @@ func (r *RequestBuilder) Go(t *testing.T, e *echo.Echo) *CompletedRequest {
 
 	return &CompletedRequest{
 		Recorder: rec,
+		Request:  req,
 	}
 }
 
@@ -158,6 +159,7 @@ func (r *RequestBuilder) Go(t *testing.T, e *echo.Echo) *CompletedRequest {
 // ResponseRecorder with some nice helper functions.
 type CompletedRequest struct {
 	Recorder *httptest.ResponseRecorder
+	Request  *http.Request // The request which was sent
 }
 
 // This function takes a destination object as input, and unmarshals the object
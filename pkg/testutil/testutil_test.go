@@ -0,0 +1,179 @@
+package testutil
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `
+openapi: "3.0.0"
+info:
+  version: 1.0.0
+  title: Pets
+servers:
+  - url: https://example.com/api
+paths:
+  /pets:
+    get:
+      operationId: findPets
+      parameters:
+        - name: tags
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: X-Trace
+          in: header
+          schema:
+            type: string
+        - name: session
+          in: cookie
+          schema:
+            type: string
+        - name: filter
+          in: query
+          content:
+            application/json:
+              schema:
+                type: object
+      responses:
+        '200':
+          description: The pets
+          headers:
+            X-Total:
+              schema:
+                type: integer
+                minimum: 0
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+    get:
+      operationId: getPet
+      responses:
+        '200':
+          description: The pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        '404':
+          description: No such pet
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+`
+
+type Pet struct {
+	Name string `json:"name"`
+}
+
+type FindPetsParams struct {
+	Tags   *[]string `json:"tags,omitempty"`
+	Limit  *int      `json:"limit,omitempty"`
+	XTrace *string   `json:"X-Trace,omitempty"`
+}
+
+func loadTestSpec(t *testing.T) *openapi3.Swagger {
+	swagger, err := openapi3.NewSwaggerLoader().LoadSwaggerFromData([]byte(testSpec))
+	require.NoError(t, err)
+	return swagger
+}
+
+func TestWithOperation(t *testing.T) {
+	swagger := loadTestSpec(t)
+
+	tags := []string{"cat", "dog"}
+	limit := 10
+	trace := "abc"
+	r := NewRequest().WithOperation(swagger, "findPets",
+		FindPetsParams{Tags: &tags, Limit: &limit, XTrace: &trace},
+		map[string]interface{}{"session": "s1", "filter": map[string]string{"kind": "cat"}})
+	require.NoError(t, r.Error)
+	assert.Equal(t, "GET", r.Method)
+	assert.Equal(t, "/pets?tags=cat&tags=dog&limit=10&filter=%7B%22kind%22%3A%22cat%22%7D", r.Path)
+	assert.Equal(t, "abc", r.Headers["X-Trace"])
+	assert.Equal(t, []*http.Cookie{{Name: "session", Value: "s1"}}, r.Cookies)
+
+	// Nil parameters are left out.
+	r = NewRequest().WithOperation(swagger, "findPets", FindPetsParams{})
+	require.NoError(t, r.Error)
+	assert.Equal(t, "/pets", r.Path)
+
+	// Parameters of the path apply to its operations.
+	r = NewRequest().WithOperation(swagger, "getPet", map[string]interface{}{"id": 7})
+	require.NoError(t, r.Error)
+	assert.Equal(t, "/pets/7", r.Path)
+
+	r = NewRequest().WithOperation(swagger, "getPet")
+	assert.EqualError(t, r.Error, "missing required path parameter id of getPet")
+	r = NewRequest().WithOperation(swagger, "getPet", map[string]interface{}{"id": 7, "name": "rex"})
+	assert.EqualError(t, r.Error, "getPet has no parameters name")
+	r = NewRequest().WithOperation(swagger, "deletePet")
+	assert.EqualError(t, r.Error, "no operation with operationId deletePet")
+	r = NewRequest().WithOperation(swagger, "getPet", 7)
+	assert.EqualError(t, r.Error, "error in parameters of getPet: parameters must be given by a map or a struct, not int")
+}
+
+func TestAssertConformsTo(t *testing.T) {
+	swagger := loadTestSpec(t)
+	e := echo.New()
+	e.GET("/pets", func(ctx echo.Context) error {
+		switch ctx.QueryParam("limit") {
+		case "1":
+			// The name is missing.
+			return ctx.JSON(http.StatusOK, []map[string]string{{}})
+		case "2":
+			ctx.Response().Header().Set("X-Total", "-1")
+		case "3":
+			return ctx.String(http.StatusOK, "rex")
+		case "4":
+			return ctx.NoContent(http.StatusTeapot)
+		default:
+			ctx.Response().Header().Set("X-Total", "1")
+		}
+		return ctx.JSON(http.StatusOK, []Pet{{Name: "rex"}})
+	})
+	e.GET("/pets/:id", func(ctx echo.Context) error {
+		return ctx.NoContent(http.StatusNotFound)
+	})
+
+	find := func(limit int) *CompletedRequest {
+		return NewRequest().WithOperation(swagger, "findPets", FindPetsParams{Limit: &limit}).Go(t, e)
+	}
+	assert.True(t, find(0).AssertConformsTo(t, swagger))
+	assert.True(t, NewRequest().WithOperation(swagger, "getPet", map[string]interface{}{"id": 1}).Go(t, e).AssertConformsTo(t, swagger))
+
+	assert.Error(t, find(1).conformsTo(swagger))
+	err := find(2).conformsTo(swagger)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "header X-Total doesn't match its schema")
+	}
+	assert.Error(t, find(3).conformsTo(swagger))
+	assert.Error(t, find(4).conformsTo(swagger))
+	assert.Error(t, NewRequest().Get("/owners").Go(t, e).conformsTo(swagger))
+}
@@ -0,0 +1,49 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDate_MarshalJSON(t *testing.T) {
+	testDate := time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC)
+	b := struct {
+		DateField Date `json:"date"`
+	}{
+		DateField: Date{testDate},
+	}
+	jsonBytes, err := json.Marshal(b)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"date":"2019-04-01"}`, string(jsonBytes))
+}
+
+func TestDate_UnmarshalJSON(t *testing.T) {
+	testDate := time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC)
+	jsonStr := `{"date":"2019-04-01"}`
+	b := struct {
+		DateField Date `json:"date"`
+	}{}
+	err := json.Unmarshal([]byte(jsonStr), &b)
+	assert.NoError(t, err)
+	assert.Equal(t, testDate, b.DateField.Time)
+
+	// A full timestamp isn't a date.
+	err = json.Unmarshal([]byte(`{"date":"2019-04-01T00:00:00Z"}`), &b)
+	assert.Error(t, err)
+}
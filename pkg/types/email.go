@@ -0,0 +1,64 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+)
+
+// This is deliberately lenient, it only makes sure that there is something
+// on either side of a single @, and a dot in the domain.
+var emailRegex = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// ErrInvalidEmail is returned when marshaling or unmarshaling an Email which
+// doesn't look like an email address.
+var ErrInvalidEmail = errors.New("email: not a valid email address")
+
+// Email represents an OpenAPI string with the "email" format. It's validated
+// when marshaled and unmarshaled, except that the zero value, "", may be
+// marshaled, so that structs with an Email which was never set still are.
+type Email string
+
+func (e Email) MarshalJSON() ([]byte, error) {
+	if e != "" && !emailRegex.MatchString(string(e)) {
+		return nil, ErrInvalidEmail
+	}
+	return json.Marshal(string(e))
+}
+
+func (e *Email) UnmarshalJSON(data []byte) error {
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalText([]byte(s))
+}
+
+func (e Email) MarshalText() ([]byte, error) {
+	if e != "" && !emailRegex.MatchString(string(e)) {
+		return nil, ErrInvalidEmail
+	}
+	return []byte(e), nil
+}
+
+func (e *Email) UnmarshalText(data []byte) error {
+	if !emailRegex.MatchString(string(data)) {
+		return ErrInvalidEmail
+	}
+	*e = Email(data)
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmail_JSON(t *testing.T) {
+	var e Email
+	err := json.Unmarshal([]byte(`"alice@example.com"`), &e)
+	assert.NoError(t, err)
+	assert.Equal(t, Email("alice@example.com"), e)
+
+	b, err := json.Marshal(e)
+	assert.NoError(t, err)
+	assert.Equal(t, `"alice@example.com"`, string(b))
+
+	err = json.Unmarshal([]byte(`"not an email"`), &e)
+	assert.Equal(t, ErrInvalidEmail, err)
+
+	_, err = json.Marshal(Email("bob"))
+	assert.Error(t, err)
+
+	// Structs with an Email which was never set can be marshaled.
+	b, err = json.Marshal(struct{ Email Email }{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Email":""}`, string(b))
+	text, err := Email("").MarshalText()
+	assert.NoError(t, err)
+	assert.Empty(t, text)
+}
@@ -0,0 +1,99 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+)
+
+// File represents an OpenAPI string with the "binary" format, which is the
+// contents of a file. It can be built from raw bytes, or from a file uploaded
+// in a multipart form, in which case the contents are only read on demand.
+// In JSON, the contents are base64 encoded, as for the "byte" format.
+type File struct {
+	multipart *multipart.FileHeader
+	data      []byte
+	filename  string
+}
+
+// InitFromMultipart sets up the File to read from an uploaded file.
+func (file *File) InitFromMultipart(header *multipart.FileHeader) {
+	file.multipart = header
+	file.data = nil
+	file.filename = header.Filename
+}
+
+// InitFromBytes sets up the File to hold the given contents.
+func (file *File) InitFromBytes(data []byte, filename string) {
+	file.data = data
+	file.filename = filename
+	file.multipart = nil
+}
+
+func (file File) MarshalJSON() ([]byte, error) {
+	b, err := file.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(b)
+}
+
+func (file *File) UnmarshalJSON(data []byte) error {
+	var b []byte
+	err := json.Unmarshal(data, &b)
+	if err != nil {
+		return err
+	}
+	file.InitFromBytes(b, "")
+	return nil
+}
+
+// Bytes returns the contents of the file.
+func (file File) Bytes() ([]byte, error) {
+	if file.multipart != nil {
+		f, err := file.multipart.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return ioutil.ReadAll(f)
+	}
+	return file.data, nil
+}
+
+// Reader returns a reader over the contents of the file, which the caller
+// must close.
+func (file File) Reader() (io.ReadCloser, error) {
+	if file.multipart != nil {
+		return file.multipart.Open()
+	}
+	return ioutil.NopCloser(bytes.NewReader(file.data)), nil
+}
+
+// Filename returns the name of the file, if it's known.
+func (file File) Filename() string {
+	return file.filename
+}
+
+// FileSize returns the size of the file in bytes.
+func (file File) FileSize() int64 {
+	if file.multipart != nil {
+		return file.multipart.Size
+	}
+	return int64(len(file.data))
+}
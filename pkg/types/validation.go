@@ -0,0 +1,137 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Validator is implemented by every generated type. Validate checks a value
+// against the constraints in its schema.
+type Validator interface {
+	Validate() error
+}
+
+// ValidationError is a single violation of a schema constraint.
+type ValidationError struct {
+	// Path is the JSON pointer to the offending value, eg /pets/0/name. It's
+	// empty when the value being validated is itself at fault.
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationErrors is every violation found by a Validate method.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Add records a violation at the given path.
+func (e *ValidationErrors) Add(path string, format string, args ...interface{}) {
+	*e = append(*e, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// Nest records the violations from validating a value found at path. The
+// paths of the nested violations are relative to the value, so they're
+// prefixed with path.
+func (e *ValidationErrors) Nest(path string, err error) {
+	if err == nil {
+		return
+	}
+	nested, ok := err.(ValidationErrors)
+	if !ok {
+		e.Add(path, "%s", err)
+		return
+	}
+	for _, n := range nested {
+		*e = append(*e, ValidationError{Path: path + n.Path, Message: n.Message})
+	}
+}
+
+// Err returns the violations as an error, or nil if there are none, so that
+// a nil ValidationErrors doesn't become a non-nil error.
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// ValidateValue validates v if it implements Validator. Types which don't,
+// such as those provided with x-go-type, are assumed to be valid.
+func ValidateValue(v interface{}) error {
+	if validator, ok := v.(Validator); ok {
+		return validator.Validate()
+	}
+	return nil
+}
+
+var patterns sync.Map
+
+// MatchPattern reports whether s matches the regular expression pattern. The
+// compiled expressions are cached, since Validate methods are called often.
+// Patterns are checked when generating code, so this panics on a bad one.
+func MatchPattern(pattern string, s string) bool {
+	re, found := patterns.Load(pattern)
+	if !found {
+		re, _ = patterns.LoadOrStore(pattern, regexp.MustCompile(pattern))
+	}
+	return re.(*regexp.Regexp).MatchString(s)
+}
+
+// IsMultipleOf reports whether v is a multiple of m, allowing for the
+// rounding errors of floating point division.
+func IsMultipleOf(v float64, m float64) bool {
+	if m == 0 {
+		return false
+	}
+	q := v / m
+	return math.Abs(q-math.Round(q)) < 1e-9
+}
+
+// UniqueItems reports whether all the items of a slice are distinct. Items
+// are compared deeply, as JSON values would be.
+func UniqueItems(slice interface{}) bool {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice {
+		return true
+	}
+	n := v.Len()
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if reflect.DeepEqual(v.Index(i).Interface(), v.Index(j).Interface()) {
+				return false
+			}
+		}
+	}
+	return true
+}
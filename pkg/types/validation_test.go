@@ -0,0 +1,60 @@
+// Copyright 2019 DeepMap, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationErrors(t *testing.T) {
+	var errs ValidationErrors
+	assert.NoError(t, errs.Err())
+
+	var nested ValidationErrors
+	nested.Add("/name", "must be at least %d characters long", 2)
+	nested.Add("", "must have at least %d properties", 1)
+
+	errs.Add("/id", "must be at most %s", "10")
+	errs.Nest("/pets/0", nested.Err())
+	errs.Nest("/owner", errors.New("bad owner"))
+	errs.Nest("/ignored", nil)
+
+	assert.Equal(t, ValidationErrors{
+		{Path: "/id", Message: "must be at most 10"},
+		{Path: "/pets/0/name", Message: "must be at least 2 characters long"},
+		{Path: "/pets/0", Message: "must have at least 1 properties"},
+		{Path: "/owner", Message: "bad owner"},
+	}, errs)
+	assert.EqualError(t, errs.Err(), "/id: must be at most 10; /pets/0/name: must be at least 2 characters long; "+
+		"/pets/0: must have at least 1 properties; /owner: bad owner")
+}
+
+func TestValidationHelpers(t *testing.T) {
+	assert.True(t, MatchPattern("^[a-z]+$", "abc"))
+	assert.False(t, MatchPattern("^[a-z]+$", "aBc"))
+
+	assert.True(t, IsMultipleOf(0.75, 0.25))
+	assert.True(t, IsMultipleOf(0.3, 0.1))
+	assert.False(t, IsMultipleOf(0.8, 0.25))
+	assert.False(t, IsMultipleOf(1, 0))
+
+	assert.True(t, UniqueItems([]string{"a", "b"}))
+	assert.False(t, UniqueItems([]string{"a", "b", "a"}))
+	assert.False(t, UniqueItems([]map[string]int{{"a": 1}, {"a": 1}}))
+
+	assert.NoError(t, ValidateValue("not a Validator"))
+}